@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxIdRange godoc
+//
+// Number of IDs above which a range such as 1-100000 is rejected, as it is most likely a typo.
+const maxIdRange = 1000
+
+// bulkVerbs godoc
+//
+// Defines the words describing what a bulk command does to an item, e.g. "complete" and "Completed".
+type bulkVerbs struct {
+	present string
+	past    string
+}
+
+// parseIdArgument godoc
+//
+// Parses an ID or a range of IDs such as 8-12, which includes both ends.
+//
+// Returns nil and error when the value is not a positive ID or a valid range.
+//
+// Returns the IDs in ascending order and nil on success.
+func parseIdArgument(value string) ([]int64, error) {
+	startValue, endValue, isRange := strings.Cut(value, "-")
+	if !isRange {
+		endValue = startValue
+	}
+	start, startErr := strconv.ParseInt(startValue, 10, 64)
+	end, endErr := strconv.ParseInt(endValue, 10, 64)
+	if startErr != nil || endErr != nil || start <= 0 || end < start {
+		return nil, fmt.Errorf("'%s' is not a valid ID or range of IDs", value)
+	}
+	if end-start >= maxIdRange {
+		return nil, fmt.Errorf("'%s' covers more than %d IDs", value, maxIdRange)
+	}
+
+	ids := make([]int64, 0, end-start+1)
+	for id := start; id <= end; id++ {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// parseIdArguments godoc
+//
+// Parses arguments which are IDs or ranges of IDs, e.g. `3 5 8-12`.
+//
+// Returns nil, nil and error when an argument is not a positive ID or a valid range.
+//
+// Returns the IDs and the ranges in the order they are listed, and nil on success.
+func parseIdArguments(args []string) ([]int64, []todo.IdRange, error) {
+	var ids []int64
+	var ranges []todo.IdRange
+	for _, arg := range args {
+		argIds, err := parseIdArgument(arg)
+		if err != nil {
+			return nil, nil, err
+		}
+		if strings.Contains(arg, "-") {
+			ranges = append(ranges, todo.IdRange{Start: argIds[0], End: argIds[len(argIds)-1]})
+		} else {
+			ids = append(ids, argIds...)
+		}
+	}
+	return ids, ranges, nil
+}
+
+// isIdArgument godoc
+//
+// Returns true when the value is an ID or a range of IDs.
+func isIdArgument(value string) bool {
+	_, err := parseIdArgument(value)
+	return err == nil
+}
+
+// addBulkFlags godoc
+//
+// Adds the `--filter` and `--dry-run` flags of the commands which apply to several items to a command.
+func addBulkFlags(cmd *cobra.Command, verbs bulkVerbs) {
+	cmd.Flags().String(
+		"filter", "", fmt.Sprintf("%s the items matching a filter expression, see 'todo list --help'", verbs.present),
+	)
+	cmd.Flags().Bool("dry-run", false, "Print what would be done without changing any item")
+}
+
+// isBulkCommand godoc
+//
+// Returns true when the flags of a command select several items, or ask for a preview, rather than a single ID.
+func isBulkCommand(cmd *cobra.Command, idArgs []string) bool {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if len(idArgs) != 1 || cmd.Flags().Changed("filter") || dryRun {
+		return true
+	}
+	_, err := strconv.ParseInt(idArgs[0], 10, 64)
+	return err != nil && isIdArgument(idArgs[0])
+}
+
+// getBulkOptions godoc
+//
+// Reads the items selected by the ID arguments or the `--filter` flag of a command, along with `--dry-run`.
+//
+// Returns a commandError when both or neither of the IDs and the filter are given, when an ID is invalid, or when
+// `--output` asks for another format than a table, as bulk operations print a summary rather than items.
+//
+// Returns the options and nil on success.
+func getBulkOptions(cmd *cobra.Command, idArgs []string, summary string) (todo.BulkOptions, error) {
+	filter, _ := cmd.Flags().GetString("filter")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	options := todo.BulkOptions{Filter: filter, DryRun: dryRun}
+	if output, _ := cmd.Flags().GetString("output"); cmd.Flags().Changed("output") {
+		if format, err := todo.ParseOutputFormat(output); err != nil || format != todo.OutputFormatTable {
+			return options, newUsageError(
+				summary, "--output only applies to a single item, bulk operations print a summary.",
+			)
+		}
+	}
+	switch {
+	case len(idArgs) > 0 && filter != "":
+		return options, newUsageError(summary, "Provide IDs or --filter, not both.")
+	case len(idArgs) == 0 && filter == "":
+		return options, newUsageError(summary, "Provide IDs, e.g. 3 5 8-12, or --filter.")
+	}
+
+	ids, ranges, err := parseIdArguments(idArgs)
+	if err != nil {
+		return options, newCommandError(summary, exitInvalidId, "%v.", err)
+	}
+	options.Ids, options.Ranges = ids, ranges
+	return options, nil
+}
+
+// printBulkSummary godoc
+//
+// Writes a line for each item of a bulk operation to out, followed by the outcome of the operation. The items
+// which succeeded are reported as rolled back when the operation failed for another item.
+//
+// Returns a commandError with the exit code of the first failed item when the operation failed for an item.
+//
+// Returns nil otherwise.
+func printBulkSummary(
+	out io.Writer, summary *todo.BulkSummary, dryRun bool, verbs bulkVerbs, errSummary string,
+) error {
+	applied := verbs.past
+	switch {
+	case dryRun:
+		applied = "Would " + verbs.present
+	case !summary.Applied:
+		applied = "Rolled back"
+	}
+	for _, result := range summary.Results {
+		if result.Err != nil {
+			var commandErr *commandError
+			errors.As(newItemError(errSummary, result.Err), &commandErr)
+			fmt.Fprintf(out, "Failed item %d: %s\n", result.ItemId, commandErr.reason)
+			continue
+		}
+		if result.CoveredBy != 0 {
+			fmt.Fprintf(out, "%s item %d: %s, with item %d\n", applied, result.ItemId, result.Name, result.CoveredBy)
+			continue
+		}
+		fmt.Fprintf(out, "%s item %d: %s\n", applied, result.ItemId, result.Name)
+		if result.Completion == nil || result.Completion.NextOccurrence == nil {
+			continue
+		}
+		occurrence := result.Completion.NextOccurrence
+		switch {
+		case summary.Applied:
+			fmt.Fprintf(
+				out, "  Created next occurrence %d, due %s\n",
+				occurrence.GetId(), occurrence.GetDueAt().Format(getDateFormat()),
+			)
+		case dryRun:
+			fmt.Fprintf(out, "  Would create next occurrence, due %s\n", occurrence.GetDueAt().Format(getDateFormat()))
+		}
+	}
+
+	failed := summary.Failed()
+	switch {
+	case len(failed) > 0:
+		var commandErr *commandError
+		errors.As(newItemError(errSummary, failed[0].Err), &commandErr)
+		commandErr.reason = fmt.Sprintf(
+			"%d of %d items failed, no item was changed.", len(failed), len(summary.Results),
+		)
+		return commandErr
+	case len(summary.Results) == 0:
+		fmt.Fprintln(out, "No todo items match")
+	case summary.Applied:
+		fmt.Fprintf(out, "%s %s\n", verbs.past, countItems(len(summary.Results)))
+	default:
+		fmt.Fprintf(out, "Dry run: would %s %s, no item was changed\n", verbs.present, countItems(len(summary.Results)))
+	}
+	return nil
+}
+
+// countItems godoc
+//
+// Returns the number of items followed by "item" or "items".
+func countItems(count int) string {
+	if count == 1 {
+		return "1 item"
+	}
+	return fmt.Sprintf("%d items", count)
+}
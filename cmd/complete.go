@@ -1,8 +1,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
 	"strconv"
 
 	"github.com/spf13/cobra"
@@ -10,31 +11,77 @@ import (
 
 // completeCmd represents the complete command
 var completeCmd = &cobra.Command{
-	Use:     "complete <item id>",
-	Example: "todo complete 1",
-	Short:   "Complete a todo item",
-	Long:    "Complete a todo item by ID",
-	Args:    cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	Use:     "complete <item id>...",
+	Example: "todo complete 1\ntodo complete 3 5 8-12\ntodo complete --filter 'tag:release and status:open' --dry-run",
+	Short:   "Complete todo items",
+	Long: "Complete todo items by ID, by range of IDs such as 8-12 or by --filter.\n\n" +
+		"An item with open subtasks is not completed, unless " + completionPolicyEnv + "=cascade is set, in which " +
+		"case its open subtasks are completed along with it.\n\n" +
+		"Several items are completed in a single transaction: when an item cannot be completed, no item is. " +
+		"Use --dry-run to print what would be completed.",
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		if isBulkCommand(cmd, args) {
+			options, err := getBulkOptions(cmd, args, "Unable to complete todo items.")
+			if err != nil {
+				return err
+			}
+			summary, err := app.TodoUseCase.CompleteItems(cmd.Context(), options)
+			if err != nil {
+				return newItemError("Unable to complete todo items.", err)
+			}
+			return printBulkSummary(out, summary, options.DryRun, completeVerbs, "Unable to complete todo items.")
+		}
+
 		idToComplete, err := strconv.ParseInt(args[0], 10, 64)
 		if err != nil {
-			fmt.Println("Unable to complete todo item.")
-			fmt.Printf("'%s' is not a valid ID.\n", args[0])
-			return
+			return newIdArgumentError("Unable to complete todo item.", args[0])
+		}
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return newUsageError("Unable to complete todo item.", "%v.", err)
 		}
 
-		completedItemId, err := app.TodoUseCase.Complete(idToComplete)
+		completion, err := app.TodoUseCase.Complete(cmd.Context(), idToComplete)
+		if errors.Is(err, todo.ErrOpenSubtasks) {
+			return &commandError{
+				summary: "Unable to complete todo item.",
+				reason: fmt.Sprintf(
+					"Item %d has open subtasks, complete them first or set %s=cascade.", idToComplete, completionPolicyEnv,
+				),
+				code: exitConflict,
+				err:  err,
+			}
+		}
 		if err != nil {
-			log.Errorf("completeCmd: %v", err)
-			log.Fatalln("An error occurred while completing the todo item")
+			return newItemError("Unable to complete todo item.", err)
+		}
+		if format != todo.OutputFormatTable {
+			items := completion.Items
+			if completion.NextOccurrence != nil {
+				items = append(items, completion.NextOccurrence)
+			}
+			return printChangedItems(out, items, format)
 		}
-		if completedItemId == -1 {
-			log.Fatalf("No todo item exists with ID %d\n", idToComplete)
+		fmt.Fprintln(out, "Completed item")
+		if occurrence := completion.NextOccurrence; occurrence != nil {
+			fmt.Fprintf(
+				out, "Created next occurrence %d, due %s\n",
+				occurrence.GetId(), occurrence.GetDueAt().Format(getDateFormat()),
+			)
 		}
-		fmt.Println("Completed item")
+		return nil
 	},
 }
 
+// completeVerbs godoc
+//
+// Words describing what `complete` does to each item.
+var completeVerbs = bulkVerbs{present: "complete", past: "Completed"}
+
 func init() {
+	addBulkFlags(completeCmd, completeVerbs)
+	addOutputFlag(completeCmd)
 	rootCmd.AddCommand(completeCmd)
 }
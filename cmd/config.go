@@ -0,0 +1,427 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/rykeroc/todo-cli/internal/modules/view"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// logLevelEnv godoc
+//
+// Environment variable with the log level, a name such as debug or a number, which overrides the configuration file.
+const logLevelEnv = "TODO_LOG_LEVEL"
+
+// noColorEnv godoc
+//
+// Environment variable which disables colors when color is auto, see https://no-color.org.
+const noColorEnv = "NO_COLOR"
+
+// columnsEnv godoc
+//
+// Environment variable with the width of the terminal, which overrides the width read from the terminal.
+const columnsEnv = "COLUMNS"
+
+// Values of the color configuration key.
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// appConfig godoc
+//
+// Configuration file of the running command, loaded by the PersistentPreRunE hook of the root command.
+var appConfig internal.Config
+
+// configKey godoc
+//
+// Defines a key of the configuration file for the `config` command.
+type configKey struct {
+	// defaultValue is used while the key is unset.
+	defaultValue string
+	description  string
+	// validate returns error when the value cannot be used.
+	validate func(string) error
+}
+
+// configKeys godoc
+//
+// Defaults and validation of each of internal.ConfigKeys.
+var configKeys = map[string]configKey{
+	"output": {
+		defaultValue: string(todo.OutputFormatTable),
+		description:  "Output format of the commands which print items",
+		validate: func(value string) error {
+			_, err := getConfigOutputFormat(value)
+			return err
+		},
+	},
+	"sort": {
+		defaultValue: string(todo.SortOrderPriority),
+		description:  "Order of the items of list, e.g. due:desc,name",
+		validate: func(value string) error {
+			if _, err := todo.ParseSortKeys(value); err != nil {
+				return fmt.Errorf("'%s' is not a sort order, use fields of %s", value, joinValues(todo.SortOrders))
+			}
+			return nil
+		},
+	},
+	"date_format": {
+		defaultValue: time.DateTime,
+		description:  "Go time layout of the dates in tables",
+		validate: func(value string) error {
+			// A layout without any element, e.g. "date", would print the same text for every date
+			if time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC).Format(value) == value {
+				return fmt.Errorf("'%s' is not a Go time layout such as 2006-01-02 15:04", value)
+			}
+			return nil
+		},
+	},
+	"color": {
+		defaultValue: colorAuto,
+		description:  "When tables are colored: auto, always or never",
+		validate: func(value string) error {
+			switch value {
+			case colorAuto, colorAlways, colorNever:
+				return nil
+			}
+			return fmt.Errorf("'%s' is not one of %s, %s or %s", value, colorAuto, colorAlways, colorNever)
+		},
+	},
+	"default_project": {
+		defaultValue: "",
+		description:  "Project of the items created without --project",
+		validate:     func(string) error { return nil },
+	},
+	"log_level": {
+		defaultValue: log.FatalLevel.String(),
+		description:  "Log level: trace, debug, info, warn, error or fatal, overridden by " + logLevelEnv,
+		validate: func(value string) error {
+			if _, err := internal.ParseLogLevel(value); err != nil {
+				return fmt.Errorf("'%s' is not one of trace, debug, info, warn, error or fatal", value)
+			}
+			return nil
+		},
+	},
+	"stale_days": {
+		defaultValue: strconv.Itoa(view.DefaultStaleDays),
+		description:  "Days without an update after which the stale view lists an open item",
+		validate: func(value string) error {
+			if days, err := strconv.Atoi(value); err != nil || days <= 0 {
+				return fmt.Errorf("'%s' is not a positive number of days", value)
+			}
+			return nil
+		},
+	},
+}
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the configuration.",
+	Long: "Read and change the configuration file, which sets the defaults of the commands.\n\n" +
+		"Keys:\n" + describeConfigKeys(),
+}
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:     "get <key>",
+	Example: "todo config get output",
+	Short:   "Print the value of a configuration key.",
+	Long:    "Print the value of a configuration key, or its default when it is unset.",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		value, err := appConfig.Get(args[0])
+		if err != nil {
+			return newConfigKeyError("Unable to get the configuration.", args[0], err)
+		}
+		if value == "" {
+			value = configKeys[args[0]].defaultValue
+		}
+		fmt.Fprintln(out, value)
+		return nil
+	},
+}
+
+// configSetCmd represents the config set command
+var configSetCmd = &cobra.Command{
+	Use:     "set <key> <value>",
+	Example: "todo config set output json\ntodo config set date_format \"02 Jan 2006 15:04\"\ntodo config set output \"\"",
+	Short:   "Change the value of a configuration key.",
+	Long:    "Change the value of a configuration key. An empty value unsets the key, which restores its default.",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		key, value := args[0], strings.TrimSpace(args[1])
+		if err := appConfig.Set(key, value); err != nil {
+			return newConfigKeyError("Unable to set the configuration.", key, err)
+		}
+		if value != "" {
+			if err := configKeys[key].validate(value); err != nil {
+				return newCommandError("Unable to set the configuration.", exitValidation, "Invalid %s: %v.", key, err)
+			}
+		}
+
+		configPath, err := internal.GetConfigPath()
+		if err != nil {
+			return newUnexpectedError("Unable to set the configuration.", err)
+		}
+		if err := internal.SaveConfig(configPath, appConfig); err != nil {
+			return newUnexpectedError("Unable to set the configuration.", err)
+		}
+		if value == "" {
+			fmt.Fprintf(out, "Unset %s\n", key)
+			return nil
+		}
+		fmt.Fprintf(out, "Set %s to %s\n", key, value)
+		return nil
+	},
+}
+
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configuration.",
+	Long:  "Displays the value of every configuration key. Unset keys show their default.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		var buffer bytes.Buffer
+		tw := tabwriter.NewWriter(&buffer, 0, 4, 4, ' ', 0)
+		fmt.Fprintln(tw, "Key\tValue")
+		fmt.Fprintln(tw, "---\t-----")
+		for _, key := range internal.ConfigKeys {
+			value, _ := appConfig.Get(key)
+			if value == "" {
+				value = orDash(configKeys[key].defaultValue) + " (default)"
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", key, strings.TrimSpace(value))
+		}
+		if err := tw.Flush(); err != nil {
+			return newUnexpectedError("Unable to list the configuration.", err)
+		}
+		fmt.Fprint(out, buffer.String())
+		return nil
+	},
+}
+
+// configPathCmd represents the config path command
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the path of the configuration file.",
+	Long: "Print the path of the configuration file. Its directory can also hold a " + internal.EnvFilename +
+		" file which sets environment variables such as " + databaseEnv + ".",
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		configPath, err := internal.GetConfigPath()
+		if err != nil {
+			return newUnexpectedError("Unable to find the configuration.", err)
+		}
+		fmt.Fprintln(out, configPath)
+		return nil
+	},
+}
+
+// loadConfig godoc
+//
+// Loads the .env file and the configuration file into appConfig and sets the log level.
+//
+// The values of the configuration file are validated unless the command is a `config` command, so that an invalid
+// value can still be changed.
+//
+// Returns a commandError on error, nil otherwise.
+func loadConfig(cmd *cobra.Command) error {
+	if err := internal.LoadEnvFile(); err != nil {
+		return newCommandError("Invalid configuration.", exitUsage, "%v.", err)
+	}
+	configPath, err := internal.GetConfigPath()
+	if err != nil {
+		return newUnexpectedError("Unable to load the configuration.", err)
+	}
+	appConfig, err = internal.LoadConfig(configPath)
+	if err != nil {
+		return newCommandError("Invalid configuration.", exitUsage, "%v.", err)
+	}
+
+	if cmd != configCmd && cmd.Parent() != configCmd {
+		for _, key := range internal.ConfigKeys {
+			value, _ := appConfig.Get(key)
+			if value == "" {
+				continue
+			}
+			if err := configKeys[key].validate(value); err != nil {
+				return newCommandError(
+					"Invalid configuration.", exitUsage, "Invalid %s in %s: %v.", key, configPath, err,
+				)
+			}
+		}
+	}
+
+	setLogLevel()
+	return nil
+}
+
+// setLogLevel godoc
+//
+// Sets the log level of the app from TODO_LOG_LEVEL, or else from the configuration file.
+//
+// Defaults to the fatal log level, and to internal.DefaultLogLevel when TODO_LOG_LEVEL is invalid.
+func setLogLevel() {
+	value := appConfig.LogLevel
+	if envLogLevel := os.Getenv(logLevelEnv); envLogLevel != "" {
+		value = envLogLevel
+	}
+	if value == "" {
+		log.SetLevel(log.FatalLevel)
+		return
+	}
+	logLevel, err := internal.ParseLogLevel(value)
+	if err != nil {
+		log.Warnf("Invalid log level: %s", err)
+		log.SetLevel(internal.DefaultLogLevel)
+		return
+	}
+	log.SetLevel(logLevel)
+	log.Debugf("Log level: %s", logLevel)
+}
+
+// newConfigKeyError godoc
+//
+// Returns a commandError for a configuration key which does not exist, or an unexpected error otherwise.
+func newConfigKeyError(summary string, key string, err error) error {
+	if errors.Is(err, internal.ErrUnknownConfigKey) {
+		return newUsageError(
+			summary, "'%s' is not a configuration key, expected one of %s.", key, joinValues(internal.ConfigKeys),
+		)
+	}
+	return newUnexpectedError(summary, err)
+}
+
+// describeConfigKeys godoc
+//
+// Returns one line per configuration key with its description and default, for the help of the config command.
+func describeConfigKeys() string {
+	var builder strings.Builder
+	for _, key := range internal.ConfigKeys {
+		builder.WriteString(fmt.Sprintf("  %-16s %s", key, configKeys[key].description))
+		if defaultValue := configKeys[key].defaultValue; defaultValue != "" {
+			builder.WriteString(fmt.Sprintf(" (default %s)", defaultValue))
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// getConfigOutputFormat godoc
+//
+// Parses the output format of the configuration file.
+//
+// Returns the table format and error when the value is not a supported output format.
+//
+// Returns the output format and nil on success.
+func getConfigOutputFormat(value string) (todo.OutputFormat, error) {
+	format, err := todo.ParseOutputFormat(value)
+	if err != nil {
+		return todo.OutputFormatTable, fmt.Errorf("'%s' is not one of %s", value, joinValues(todo.OutputFormats))
+	}
+	return format, nil
+}
+
+// getDateFormat godoc
+//
+// Returns the Go time layout of the dates printed by the commands.
+func getDateFormat() string {
+	if appConfig.DateFormat == "" {
+		return time.DateTime
+	}
+	return appConfig.DateFormat
+}
+
+// getStaleDays godoc
+//
+// Returns the number of days without an update after which the stale view lists an open item.
+func getStaleDays() int {
+	// The configuration file was validated when it was loaded
+	days, err := strconv.Atoi(appConfig.StaleDays)
+	if err != nil {
+		return view.DefaultStaleDays
+	}
+	return days
+}
+
+// getFormatOptions godoc
+//
+// Returns the options of the tables written to out, from the configuration file.
+//
+// With color set to auto, tables are colored when out is a terminal and NO_COLOR is not set. Tables written to a
+// terminal are shortened to its width, which COLUMNS overrides.
+func getFormatOptions(out io.Writer) todo.FormatOptions {
+	options := todo.FormatOptions{DateFormat: appConfig.DateFormat}
+	if isTerminal(out) {
+		options.Width = terminalWidth(out)
+		if columns, err := strconv.Atoi(os.Getenv(columnsEnv)); err == nil && columns > 0 {
+			options.Width = columns
+		}
+	}
+	switch appConfig.Color {
+	case colorAlways:
+		options.Color = true
+	case colorNever:
+	default:
+		if _, noColor := os.LookupEnv(noColorEnv); !noColor {
+			options.Color = isTerminal(out)
+		}
+	}
+	return options
+}
+
+// isTerminal godoc
+//
+// Returns true when out is a terminal rather than a file or a pipe.
+func isTerminal(out io.Writer) bool {
+	file, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// orDash godoc
+//
+// Returns "-" in place of an empty value.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// joinValues godoc
+//
+// Returns the values joined by commas, for the messages which list the accepted values.
+func joinValues[T ~string](values []T) string {
+	var names []string
+	for _, value := range values {
+		names = append(names, string(value))
+	}
+	return strings.Join(names, ", ")
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd, configSetCmd, configListCmd, configPathCmd)
+	rootCmd.AddCommand(configCmd)
+}
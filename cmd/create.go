@@ -1,26 +1,103 @@
 package cmd
 
 import (
-	log "github.com/sirupsen/logrus"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
 	"github.com/spf13/cobra"
 )
 
 // createCmd represents the `create` command
 var createCmd = &cobra.Command{
-	Use:     `create "<item name>"`,
-	Example: `todo create "My new todo"`,
-	Short:   "Create a todo item.",
-	Long:    `Create a todo item with a specified name.`,
-	Args:    cobra.MatchAll(cobra.ExactArgs(1)),
-	Run: func(cmd *cobra.Command, args []string) {
-		err := app.TodoUseCase.Create(args[0])
+	Use: `create "<item name>"`,
+	Example: "todo create \"My new todo\"\n" +
+		"todo create \"Write release notes\" --due \"next fri 5pm\" --priority high\n" +
+		"todo create \"Fix login\" --tag backend --tag urgent\n" +
+		"todo create \"Draft roadmap\" --project planning\n" +
+		"todo create \"Write tests\" --parent 12\n" +
+		"todo create \"Review dependencies\" --due fri --repeat weekly",
+	Short: "Create a todo item.",
+	Long: "Create a todo item with a specified name, an optional due date, priority, tags, project, parent item " +
+		"and recurrence.\n\n" +
+		"Completing a recurring item creates its next occurrence, due at the next date of the recurrence.",
+	Args: cobra.MatchAll(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		format, err := getOutputFormat(cmd)
 		if err != nil {
-			log.Errorf("createCmd: %v\n", err)
-			log.Fatalln("An error occurred while creating the todo item")
+			return newUsageError("Unable to create todo item.", "%v.", err)
 		}
+		tags, _ := cmd.Flags().GetStringArray("tag")
+		draft := todo.ItemDraft{Name: args[0], Tags: tags}
+
+		dueValue, _ := cmd.Flags().GetString("due")
+		if dueValue != "" {
+			dueAt, err := dateParser.ParseDeadline(dueValue)
+			if err != nil {
+				return newUsageError("Unable to create todo item.", "%v", err)
+			}
+			draft.DueAt = dueAt
+		}
+
+		priorityValue, _ := cmd.Flags().GetString("priority")
+		if priorityValue != "" {
+			priority, err := todo.ParsePriority(priorityValue)
+			if err != nil {
+				return newUsageError(
+					"Unable to create todo item.",
+					"'%s' is not a valid priority, expected %s.", priorityValue, priorityFlagValues,
+				)
+			}
+			draft.Priority = priority
+		}
+
+		projectName, _ := cmd.Flags().GetString("project")
+		if !cmd.Flags().Changed("project") {
+			projectName = appConfig.DefaultProject
+		}
+		if projectName != "" {
+			projectId, err := resolveProjectId(cmd.Context(), "Unable to create todo item.", projectName, false)
+			if err != nil {
+				return err
+			}
+			draft.ProjectId = projectId
+		}
+
+		parentValue, _ := cmd.Flags().GetString("parent")
+		if parentValue != "" {
+			parentId, err := parseParentId(parentValue)
+			if err != nil || parentId == 0 {
+				return newCommandError(
+					"Unable to create todo item.", exitInvalidId, "'%s' is not a valid parent ID.", parentValue,
+				)
+			}
+			draft.ParentId = parentId
+		}
+
+		draft.Recurrence, _ = cmd.Flags().GetString("repeat")
+		draft.Description, _ = cmd.Flags().GetString("note")
+
+		item, err := app.TodoUseCase.Create(cmd.Context(), draft)
+		if err != nil {
+			return newItemError("Unable to create todo item.", err)
+		}
+		if format != todo.OutputFormatTable {
+			return printChangedItems(out, []todo.Item{item}, format)
+		}
+		fmt.Fprintf(out, "Created new todo: %s\n", item.GetName())
+		return nil
 	},
 }
 
 func init() {
+	createCmd.Flags().String("due", "", "Due date of the item, "+dateFlagUsage)
+	createCmd.Flags().String("priority", "", "Priority of the item: "+priorityFlagValues)
+	createCmd.Flags().StringArray("tag", nil, "Tag to attach to the item, can be repeated")
+	createCmd.Flags().String(
+		"project", "", "Name of the project which owns the item, default_project of the configuration when unset",
+	)
+	createCmd.Flags().String("parent", "", "ID of the item which the item is a subtask of")
+	createCmd.Flags().String("repeat", "", "Recurrence of the item, "+recurrenceFlagUsage)
+	createCmd.Flags().String("note", "", "Free-form notes about the item")
+	addOutputFlag(createCmd)
 	rootCmd.AddCommand(createCmd)
 }
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+// dependsCmd represents the depends command
+var dependsCmd = &cobra.Command{
+	Use:     "depends <item id> --on <item id>",
+	Example: "todo depends 3 --on 1\ntodo depends 3 --on 1 --on 2\ntodo depends 3 --on 1 --remove",
+	Short:   "Make a todo item wait on other todo items.",
+	Long: "Make a todo item depend on other todo items by ID. The item is blocked until every item it depends on " +
+		"is completed.\n\n" +
+		"Use --remove to drop the dependencies instead.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		itemId, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return newIdArgumentError("Unable to update the dependencies of the todo item.", args[0])
+		}
+		dependsOnIds, _ := cmd.Flags().GetInt64Slice("on")
+		remove, _ := cmd.Flags().GetBool("remove")
+
+		for _, dependsOnId := range dependsOnIds {
+			if remove {
+				err := app.TodoUseCase.Undepend(cmd.Context(), itemId, dependsOnId)
+				if errors.Is(err, todo.ErrNotFound) {
+					return &commandError{
+						summary: "Unable to remove the dependency.",
+						reason:  fmt.Sprintf("Item %d does not depend on item %d.", itemId, dependsOnId),
+						code:    exitNotFound,
+						err:     err,
+					}
+				}
+				if err != nil {
+					return newItemError("Unable to remove the dependency.", err)
+				}
+				fmt.Fprintf(out, "Item %d no longer depends on item %d\n", itemId, dependsOnId)
+				continue
+			}
+
+			err := app.TodoUseCase.Depend(cmd.Context(), itemId, dependsOnId)
+			if errors.Is(err, todo.ErrDependencyCycle) {
+				reason := fmt.Sprintf("Item %d already waits on item %d.", dependsOnId, itemId)
+				if itemId == dependsOnId {
+					reason = "An item cannot depend on itself."
+				}
+				return &commandError{summary: "Unable to add the dependency.", reason: reason, code: exitConflict, err: err}
+			}
+			if err != nil {
+				return newItemError("Unable to add the dependency.", err)
+			}
+			fmt.Fprintf(out, "Item %d depends on item %d\n", itemId, dependsOnId)
+		}
+		return nil
+	},
+}
+
+func init() {
+	dependsCmd.Flags().Int64Slice("on", nil, "ID of the item to depend on, can be repeated")
+	dependsCmd.Flags().Bool("remove", false, "Remove the dependencies instead of adding them")
+	_ = dependsCmd.MarkFlagRequired("on")
+	rootCmd.AddCommand(dependsCmd)
+}
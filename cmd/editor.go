@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultEditor godoc
+//
+// Editor used when neither $VISUAL nor $EDITOR is set.
+const defaultEditor = "vi"
+
+// editText godoc
+//
+// Opens the user's editor on a temporary file pre-filled with text and returns the saved content.
+//
+// The editor is read from $VISUAL, then $EDITOR, and may include arguments, e.g. `code --wait`.
+//
+// Returns empty string and error when the editor cannot be started or exits with an error.
+//
+// Returns the edited text and nil on success.
+func editText(text string) (string, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = defaultEditor
+	}
+
+	file, err := os.CreateTemp("", "todo-note-*.md")
+	if err != nil {
+		return "", fmt.Errorf("editText: %v", err)
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+	if _, err := file.WriteString(text); err != nil {
+		_ = file.Close()
+		return "", fmt.Errorf("editText: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("editText: %v", err)
+	}
+
+	editorArgs := strings.Fields(editor)
+	command := exec.Command(editorArgs[0], append(editorArgs[1:], file.Name())...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		return "", fmt.Errorf("editText: editor '%s' failed: %v", editor, err)
+	}
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("editText: %v", err)
+	}
+	return string(content), nil
+}
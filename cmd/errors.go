@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/project"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	log "github.com/sirupsen/logrus"
+	"strings"
+)
+
+// Exit codes of the todo process, documented in the README for scripts.
+const (
+	// exitFailure is returned when an unexpected error occurs, e.g. the database cannot be opened.
+	exitFailure = 1
+	// exitUsage is returned when the arguments or flags of a command are invalid.
+	exitUsage = 2
+	// exitNotFound is returned when a todo item, project, tag or dependency does not exist.
+	exitNotFound = 3
+	// exitInvalidId is returned when an ID is not a positive number.
+	exitInvalidId = 4
+	// exitValidation is returned when a value of a todo item is invalid.
+	exitValidation = 5
+	// exitConflict is returned when a change conflicts with the current todo items.
+	exitConflict = 6
+	// exitTimeout is returned when a command does not finish within its `--timeout`.
+	exitTimeout = 7
+)
+
+// commandError godoc
+//
+// Defines an error returned by a command, with the message printed to the user and the exit code of the process.
+type commandError struct {
+	// summary is the first line of the message, e.g. "Unable to remove todo item."
+	summary string
+	// reason explains the summary, it is omitted from the message when empty.
+	reason string
+	code   int
+	err    error
+}
+
+// Error godoc
+//
+// Returns the message printed to the user.
+func (e *commandError) Error() string {
+	if e.reason == "" {
+		return e.summary
+	}
+	return e.summary + "\n" + e.reason
+}
+
+// Unwrap godoc
+//
+// Returns the error which caused the command to fail, if any.
+func (e *commandError) Unwrap() error {
+	return e.err
+}
+
+// newCommandError godoc
+//
+// Returns a commandError with the exit code and a formatted reason.
+func newCommandError(summary string, code int, format string, args ...any) error {
+	return &commandError{summary: summary, reason: fmt.Sprintf(format, args...), code: code}
+}
+
+// newUsageError godoc
+//
+// Returns a commandError for an invalid argument or flag of a command.
+func newUsageError(summary string, format string, args ...any) error {
+	return newCommandError(summary, exitUsage, format, args...)
+}
+
+// newIdArgumentError godoc
+//
+// Returns a commandError for an argument which is not a valid ID.
+func newIdArgumentError(summary string, value string) error {
+	return newCommandError(summary, exitInvalidId, "'%s' is not a valid ID.", value)
+}
+
+// newItemError godoc
+//
+// Maps an error returned by the todo or project use case to a commandError with a user message and an exit code.
+//
+// Unexpected errors are logged and reported without their details.
+func newItemError(summary string, err error) error {
+	commandErr := &commandError{summary: summary, err: err}
+
+	var notFoundErr *todo.NotFoundError
+	var validationErr *todo.ValidationError
+	var filterErr *todo.FilterError
+	var transitionErr *todo.TransitionError
+	var projectNotFoundErr *project.NotFoundError
+	var projectValidationErr *project.ValidationError
+	var projectConflictErr *project.ConflictError
+	switch {
+	case errors.As(err, &projectNotFoundErr):
+		commandErr.code = exitNotFound
+		commandErr.reason = sentence(projectNotFoundErr.Error())
+	case errors.As(err, &projectValidationErr):
+		commandErr.code = exitValidation
+		commandErr.reason = sentence(projectValidationErr.Error())
+	case errors.As(err, &projectConflictErr):
+		commandErr.code = exitConflict
+		commandErr.reason = sentence(projectConflictErr.Error())
+	case errors.As(err, &notFoundErr):
+		commandErr.code = exitNotFound
+		commandErr.reason = sentence(notFoundErr.Error())
+	case errors.Is(err, todo.ErrNotFound):
+		commandErr.code = exitNotFound
+		commandErr.reason = "The todo item does not exist."
+	case errors.Is(err, todo.ErrInvalidID):
+		commandErr.code = exitInvalidId
+		commandErr.reason = "IDs must be positive numbers."
+	case errors.As(err, &filterErr):
+		commandErr.code = exitValidation
+		commandErr.reason = describeFilterError(filterErr)
+	case errors.As(err, &validationErr):
+		commandErr.code = exitValidation
+		commandErr.reason = sentence(validationErr.Error())
+	case errors.Is(err, todo.ErrNothingToUndo):
+		commandErr.code = exitConflict
+		commandErr.reason = "There is nothing to undo."
+	case errors.Is(err, todo.ErrNothingToRedo):
+		commandErr.code = exitConflict
+		commandErr.reason = "There is nothing to redo, or an operation was recorded since the last undo."
+	case errors.As(err, &transitionErr):
+		commandErr.code = exitConflict
+		commandErr.reason = sentence(transitionErr.Error())
+	case errors.Is(err, todo.ErrConflict):
+		commandErr.code = exitConflict
+		commandErr.reason = "The change conflicts with the current todo items."
+	default:
+		log.Errorf("%s %v", summary, err)
+		commandErr.code = exitFailure
+		commandErr.reason = "An unexpected error occurred."
+	}
+	return commandErr
+}
+
+// describeFilterError godoc
+//
+// Returns the reason of an invalid filter expression followed by the expression and a caret under the offending
+// column:
+//
+//	Invalid filter at column 12: unknown field 'stat', expected one of ...
+//	  due<1d and stat:open
+//	             ^
+func describeFilterError(err *todo.FilterError) string {
+	return fmt.Sprintf("%s\n  %s\n  %s^", sentence(err.Error()), err.Expression, strings.Repeat(" ", err.Column-1))
+}
+
+// newUnexpectedError godoc
+//
+// Logs an unexpected error and returns a commandError which does not expose its details.
+func newUnexpectedError(summary string, err error) error {
+	log.Errorf("%s %v", summary, err)
+	return &commandError{summary: summary, reason: "An unexpected error occurred.", code: exitFailure, err: err}
+}
+
+// withTimeout godoc
+//
+// Replaces the error of a command which failed because the context set up for its `--timeout` expired, so that the
+// user learns about the timeout rather than about the query which was aborted.
+//
+// Returns err unchanged when the command did not time out.
+func withTimeout(ctx context.Context, err error) error {
+	if err == nil || ctx == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	summary := "Unable to run the command."
+	var commandErr *commandError
+	if errors.As(err, &commandErr) {
+		summary = commandErr.summary
+	}
+	return &commandError{
+		summary: summary,
+		reason:  "The command did not finish before --timeout expired.",
+		code:    exitTimeout,
+		err:     err,
+	}
+}
+
+// exitCode godoc
+//
+// Returns the exit code of the process for an error returned by a command.
+//
+// Errors which are not commandErrors come from cobra itself, e.g. an unknown flag, and are usage errors.
+func exitCode(err error) int {
+	var commandErr *commandError
+	if errors.As(err, &commandErr) {
+		return commandErr.code
+	}
+	return exitUsage
+}
+
+// sentence godoc
+//
+// Capitalizes an error message and ends it with a period.
+func sentence(message string) string {
+	if message == "" {
+		return message
+	}
+	return strings.ToUpper(message[:1]) + message[1:] + "."
+}
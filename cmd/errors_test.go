@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/project"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewItemError(t *testing.T) {
+	type testCase struct {
+		err             error
+		expectedMessage string
+		expectedCode    int
+	}
+
+	testCases := []testCase{
+		{
+			err:             fmt.Errorf("Remove: %w", &todo.NotFoundError{ItemId: 3}),
+			expectedMessage: "Unable to do it.\nNo todo item exists with ID 3.",
+			expectedCode:    exitNotFound,
+		},
+		{
+			err:             fmt.Errorf("Untag: %w: item 3 is not tagged", todo.ErrNotFound),
+			expectedMessage: "Unable to do it.\nThe todo item does not exist.",
+			expectedCode:    exitNotFound,
+		},
+		{
+			err:             fmt.Errorf("Get: %w -1, IDs must be positive", todo.ErrInvalidID),
+			expectedMessage: "Unable to do it.\nIDs must be positive numbers.",
+			expectedCode:    exitInvalidId,
+		},
+		{
+			err:             fmt.Errorf("Create: %w", &todo.ValidationError{Field: "name", Reason: "cannot be empty"}),
+			expectedMessage: "Unable to do it.\nInvalid name: cannot be empty.",
+			expectedCode:    exitValidation,
+		},
+		{
+			err: fmt.Errorf("List: %w", &todo.FilterError{Expression: "tag:a and stat:open", Column: 11, Reason: "unknown field 'stat'"}),
+			expectedMessage: "Unable to do it.\nInvalid filter at column 11: unknown field 'stat'.\n" +
+				"  tag:a and stat:open\n" +
+				"            ^",
+			expectedCode: exitValidation,
+		},
+		{
+			err:             fmt.Errorf("Depend: %w", todo.ErrDependencyCycle),
+			expectedMessage: "Unable to do it.\nThe change conflicts with the current todo items.",
+			expectedCode:    exitConflict,
+		},
+		{
+			err:             fmt.Errorf("Undo: %w", todo.ErrNothingToUndo),
+			expectedMessage: "Unable to do it.\nThere is nothing to undo.",
+			expectedCode:    exitConflict,
+		},
+		{
+			err:             fmt.Errorf("Rename: %w", &project.NotFoundError{Name: "backend"}),
+			expectedMessage: "Unable to do it.\nNo project exists with name 'backend'.",
+			expectedCode:    exitNotFound,
+		},
+		{
+			err:             fmt.Errorf("Create: %w", &project.ValidationError{Reason: "the project name cannot be empty"}),
+			expectedMessage: "Unable to do it.\nThe project name cannot be empty.",
+			expectedCode:    exitValidation,
+		},
+		{
+			err:             fmt.Errorf("Archive: %w", &project.ConflictError{Reason: "project 'ops' is already archived"}),
+			expectedMessage: "Unable to do it.\nProject 'ops' is already archived.",
+			expectedCode:    exitConflict,
+		},
+		{
+			err:             errors.New("database is locked"),
+			expectedMessage: "Unable to do it.\nAn unexpected error occurred.",
+			expectedCode:    exitFailure,
+		},
+	}
+
+	for _, test := range testCases {
+		err := newItemError("Unable to do it.", test.err)
+		assert.EqualError(t, err, test.expectedMessage)
+		assert.Equal(t, test.expectedCode, exitCode(err))
+		assert.ErrorIs(t, err, test.err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	t.Run("should treat errors from cobra as usage errors", func(t *testing.T) {
+		assert.Equal(t, exitUsage, exitCode(errors.New("unknown flag: --nope")))
+	})
+
+	t.Run("should use the code of a wrapped commandError", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", newIdArgumentError("Unable to show todo item.", "x"))
+		assert.Equal(t, exitInvalidId, exitCode(err))
+	})
+}
+
+func TestWithTimeout(t *testing.T) {
+	expiredCtx, cancel := context.WithTimeout(context.Background(), -1)
+	defer cancel()
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	commandErr := newUnexpectedError("Unable to list todo items.", errors.New("interrupted"))
+
+	t.Run("should report the timeout with the summary of the command", func(t *testing.T) {
+		err := withTimeout(expiredCtx, commandErr)
+		assert.EqualError(t, err, "Unable to list todo items.\nThe command did not finish before --timeout expired.")
+		assert.Equal(t, exitTimeout, exitCode(err))
+	})
+
+	t.Run("should keep the error when the command did not time out", func(t *testing.T) {
+		assert.Equal(t, commandErr, withTimeout(nil, commandErr))
+		assert.Equal(t, commandErr, withTimeout(cancelledCtx, commandErr))
+		assert.Nil(t, withTimeout(expiredCtx, nil))
+	})
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"github.com/rykeroc/todo-cli/internal/dateparse"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// noDueDate godoc
+//
+// Flag value which removes the due date of an item.
+const noDueDate = "none"
+
+// noParent godoc
+//
+// Flag value which makes a subtask a top level item.
+const noParent = "none"
+
+// noRecurrence godoc
+//
+// Flag value which stops an item from repeating.
+const noRecurrence = "none"
+
+// recurrenceFlagUsage godoc
+//
+// Examples of recurrence expressions appended to the usage of recurrence flags.
+const recurrenceFlagUsage = `e.g. daily, weekly, "every 2 weeks", "every mon,thu" or FREQ=MONTHLY;INTERVAL=3`
+
+// completionPolicyEnv godoc
+//
+// Environment variable which selects what `complete` does with the open subtasks of an item: refuse or cascade.
+const completionPolicyEnv = "TODO_COMPLETE_SUBTASKS"
+
+// databaseEnv godoc
+//
+// Environment variable with the path of the database to use instead of the database of the active profile.
+const databaseEnv = "TODO_DB"
+
+// appClock godoc
+//
+// Clock shared by the commands which read the current time.
+var appClock = clock.NewSystemClock()
+
+// dateParser godoc
+//
+// Parser shared by every command flag which accepts a date expression.
+var dateParser = dateparse.NewParser(appClock)
+
+// dateFlagUsage godoc
+//
+// Examples of date expressions appended to the usage of date flags.
+const dateFlagUsage = `e.g. tomorrow, "next fri 5pm", +3d or 2026-11-02`
+
+// parsePastTime godoc
+//
+// Parses the value of a flag which refers to a point in the past, either an age such as 30d or 2w, meaning that long
+// ago, or a date expression such as yesterday or 2026-11-02.
+//
+// Returns the zero time and error when the value is not supported.
+//
+// Returns the resolved time and nil on success.
+func parsePastTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value != "" && value[0] >= '0' && value[0] <= '9' {
+		if past, err := dateParser.Parse("-" + value); err == nil {
+			return past, nil
+		}
+	}
+	return dateParser.Parse(value)
+}
+
+// priorityFlagValues godoc
+//
+// Values accepted by the `--priority` flag.
+const priorityFlagValues = "none, low, medium, high or urgent"
+
+// statusFlagValues godoc
+//
+// Values accepted by the `--status` flag.
+const statusFlagValues = "todo, in-progress, blocked, waiting, done or cancelled"
+
+// parseParentId godoc
+//
+// Parses the value of a `--parent` flag. The value "none" resolves to 0, which refers to top level items.
+//
+// Returns -1 and error when the value is not a valid ID.
+//
+// Returns the parent ID and nil on success.
+func parseParentId(value string) (int64, error) {
+	if value == noParent {
+		return 0, nil
+	}
+	parentId, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parentId <= 0 {
+		return -1, fmt.Errorf("'%s' is not a valid parent ID", value)
+	}
+	return parentId, nil
+}
+
+// addOutputFlag godoc
+//
+// Adds the `--output` flag, which selects the format of the printed items, to a command.
+func addOutputFlag(cmd *cobra.Command) {
+	var names []string
+	for _, format := range todo.OutputFormats {
+		names = append(names, string(format))
+	}
+	cmd.Flags().StringP(
+		"output", "o", string(todo.OutputFormatTable), "Format of the printed items: "+strings.Join(names, ", "),
+	)
+}
+
+// getOutputFormat godoc
+//
+// Reads the `--output` flag of a command, or the output format of the configuration file when the flag is not set.
+//
+// Returns the table format and error when the value is not a supported output format.
+//
+// Returns the output format and nil on success.
+func getOutputFormat(cmd *cobra.Command) (todo.OutputFormat, error) {
+	value, _ := cmd.Flags().GetString("output")
+	if !cmd.Flags().Changed("output") && appConfig.Output != "" {
+		return getConfigOutputFormat(appConfig.Output)
+	}
+	format, err := todo.ParseOutputFormat(value)
+	if err != nil {
+		return todo.OutputFormatTable, fmt.Errorf("'%s' is not a supported output format", value)
+	}
+	return format, nil
+}
+
+// addTableFlags godoc
+//
+// Adds the `--sort`, `--columns` and `--relative` flags, which order the listed items and select how their table is
+// rendered, to a command.
+func addTableFlags(cmd *cobra.Command) {
+	cmd.Flags().String(
+		"sort", "", "Order of the items, fields of "+joinValues(todo.SortOrders)+" each followed by :asc or :desc, "+
+			"e.g. due:desc,name",
+	)
+	cmd.Flags().String("columns", "", "Columns of the table: "+joinValues(todo.ItemColumns))
+	cmd.Flags().Bool("relative", false, "Print the dates of the table relative to now, e.g. 3h ago")
+}
+
+// getTableOptions godoc
+//
+// Reads the `--columns` and `--relative` flags of a command into the options of the tables written to out.
+//
+// Returns the default options and error when a column is not supported.
+//
+// Returns the options and nil on success.
+func getTableOptions(cmd *cobra.Command, out io.Writer) (todo.FormatOptions, error) {
+	options := getFormatOptions(out)
+	value, _ := cmd.Flags().GetString("columns")
+	columns, err := todo.ParseItemColumns(value)
+	if err != nil {
+		return options, fmt.Errorf("'%s' is not a list of columns of %s", value, joinValues(todo.ItemColumns))
+	}
+	options.Columns = columns
+	options.RelativeTime, _ = cmd.Flags().GetBool("relative")
+	return options, nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:     "history",
+	Example: "todo history\ntodo history --limit 50 --relative",
+	Short:   "List the latest operations.",
+	Long: "Displays the latest operations on todo items, newest first, along with the number of items each one " +
+		"changed. `todo undo` reverts the newest operation which is not undone.",
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit < 0 {
+			return newUsageError("Unable to show history.", "--limit cannot be negative.")
+		}
+
+		entries, err := app.TodoUseCase.History(cmd.Context(), limit)
+		if err != nil {
+			return newUnexpectedError("Unable to show history.", err)
+		}
+		options := getFormatOptions(out)
+		options.RelativeTime, _ = cmd.Flags().GetBool("relative")
+		table, err := todo.FormatJournalTable(entries, options)
+		if err != nil {
+			return newUnexpectedError("Unable to print history.", err)
+		}
+		fmt.Fprint(out, table)
+		return nil
+	},
+}
+
+func init() {
+	historyCmd.Flags().Int("limit", 20, "Number of operations to list, 0 lists every operation")
+	historyCmd.Flags().Bool("relative", false, "Show times relative to now, e.g. 3h ago")
+	rootCmd.AddCommand(historyCmd)
+}
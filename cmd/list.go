@@ -2,25 +2,154 @@ package cmd
 
 import (
 	"fmt"
-	log "github.com/sirupsen/logrus"
+	"github.com/rykeroc/todo-cli/internal/modules/project"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
 	"github.com/spf13/cobra"
 )
 
 // listCmd represents the list command
 var listCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all todo items.",
-	Long:  "Displays a list of all existing todo items.",
-	Args:  cobra.ExactArgs(0),
-	Run: func(cmd *cobra.Command, args []string) {
-		err := app.TodoUseCase.List()
+	Use:     "list",
+	Example: "todo list\ntodo list --due overdue\ntodo list --tag backend --not-tag blocked\ntodo list --project backend\ntodo list --tree\ntodo list --ready\ntodo list --recurring\ntodo list --filter 'status:open and (tag:backend or priority>=high) and due<+7d'\ntodo list --view upcoming\ntodo list --sort due:desc,name --columns id,name,due,tags --relative\ntodo list --output json",
+	Short:   "List all todo items.",
+	Long: "Displays a list of all existing todo items.\n\n" +
+		"Use --due to only show open items which are overdue, due today or due this week.\n" +
+		"Use --tag to only show items with all of the tags and --not-tag to hide items with any of the tags.\n" +
+		"Use --project to only show the items of a project. Items of archived projects are hidden otherwise.\n" +
+		"Use --tree to list subtasks below their parent.\n" +
+		"Use --ready to only show todo and in-progress items whose dependencies are all completed.\n" +
+		"Use --recurring to only show items which repeat.\n" +
+		"Use --filter to only show items matching an expression, combined with the other flags. Comparisons are\n" +
+		"written field:value or field<value and combined with and, or, not and parentheses. The fields are\n" +
+		"status (open, closed or a status such as in-progress), tag, project, priority, due, created, updated, name,\n" +
+		"id and parent.\n" +
+		"Dates accept the expressions of `create --due`, e.g. today, fri or +7d, and project, due and parent accept none.\n" +
+		"Use --view to show the items of a built-in or saved view, see `todo view`. --filter narrows the view down,\n" +
+		"and --sort and --columns replace the ones of the view.\n" +
+		"Use --sort to order the items by fields, e.g. due:desc,name. Open items always come first.\n" +
+		"Use --columns to choose the columns of the table and their order, and --relative to print dates such as\n" +
+		"3h ago. Long names are shortened to fit the width of the terminal.\n" +
+		"Use --output to print the items as json, yaml, csv or tsv instead of a table.",
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		viewName, _ := cmd.Flags().GetString("view")
+		return runList(cmd, viewName)
+	},
+}
+
+// runList godoc
+//
+// Lists the items matching the flags of cmd and the view named viewName, if any. Flags which cmd does not define
+// keep their zero value, so that the commands of the built-in views only define the flags they support.
+func runList(cmd *cobra.Command, viewName string) error {
+	out := cmd.OutOrStdout()
+	dueValue, _ := cmd.Flags().GetString("due")
+	dueFilter, err := todo.ParseDueFilter(dueValue)
+	if err != nil {
+		return newUsageError(
+			"Unable to list todo items.", "'%s' is not a valid due filter, expected overdue, today or week.", dueValue,
+		)
+	}
+
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	excludedTags, _ := cmd.Flags().GetStringArray("not-tag")
+
+	format, err := getOutputFormat(cmd)
+	if err != nil {
+		return newUsageError("Unable to list todo items.", "%v.", err)
+	}
+
+	tree, _ := cmd.Flags().GetBool("tree")
+	ready, _ := cmd.Flags().GetBool("ready")
+	recurring, _ := cmd.Flags().GetBool("recurring")
+	filter, _ := cmd.Flags().GetString("filter")
+
+	options, err := getTableOptions(cmd, out)
+	if err != nil {
+		return newUsageError("Unable to list todo items.", "%v.", err)
+	}
+
+	// The configuration file was validated when it was loaded
+	sortKeys, _ := todo.ParseSortKeys(appConfig.Sort)
+	filters := []string{filter}
+	if viewName != "" {
+		selectedView, err := app.ViewUseCase.Find(cmd.Context(), viewName)
 		if err != nil {
-			log.Errorf("listCmd: %v", err)
-			fmt.Println("An error occurred while listing todo items")
+			return newViewError("Unable to list todo items.", viewName, err)
 		}
-	},
+		filters = []string{selectedView.GetFilter(), filter}
+		// Saved views were validated when they were saved
+		if selectedView.GetSort() != "" {
+			sortKeys, _ = todo.ParseSortKeys(selectedView.GetSort())
+		}
+		if selectedView.GetColumns() != "" && !cmd.Flags().Changed("columns") {
+			options.Columns, _ = todo.ParseItemColumns(selectedView.GetColumns())
+		}
+	}
+	if sortValue, _ := cmd.Flags().GetString("sort"); cmd.Flags().Changed("sort") {
+		sortKeys, err = todo.ParseSortKeys(sortValue)
+		if err != nil {
+			return newUsageError(
+				"Unable to list todo items.", "'%s' is not a sort order, use fields of %s each followed by :asc or "+
+					":desc.", sortValue, joinValues(todo.SortOrders),
+			)
+		}
+	}
+
+	var projectId int64
+	projectName, _ := cmd.Flags().GetString("project")
+	if projectName != "" {
+		projectId, err = resolveProjectId(cmd.Context(), "Unable to list todo items.", projectName, true)
+		if err != nil {
+			return err
+		}
+		// `--project none` selects the items without a project
+		if projectId == 0 {
+			projectId = todo.NoProjectId
+		}
+	}
+
+	items, err := app.TodoUseCase.List(cmd.Context(), todo.ListOptions{
+		Due:          dueFilter,
+		Tags:         tags,
+		ExcludedTags: excludedTags,
+		ProjectId:    projectId,
+		Ready:        ready,
+		Recurring:    recurring,
+		Sort:         sortKeys,
+		Filters:      filters,
+	})
+	if err != nil {
+		return newItemError("Unable to list todo items.", err)
+	}
+	if err := printItems(out, items, tree, format, options); err != nil {
+		return newUnexpectedError("Unable to print todo items.", err)
+	}
+
+	// The project summary would break the machine-readable formats
+	if format != todo.OutputFormatTable {
+		return nil
+	}
+	fmt.Fprintln(out)
+	summaries, err := app.ProjectUseCase.Summarize(cmd.Context(), projectId)
+	if err != nil {
+		return newUnexpectedError("Unable to summarize projects.", err)
+	}
+	fmt.Fprint(out, project.FormatSummaryLines(summaries))
+	return nil
 }
 
 func init() {
+	listCmd.Flags().String("due", "", "Only show open items which are due: overdue, today or week")
+	listCmd.Flags().StringArray("tag", nil, "Only show items with this tag, can be repeated")
+	listCmd.Flags().StringArray("not-tag", nil, "Hide items with this tag, can be repeated")
+	listCmd.Flags().String("project", "", "Only show the items of this project, or \"none\" for items without one")
+	listCmd.Flags().Bool("tree", false, "List subtasks below their parent")
+	listCmd.Flags().Bool("ready", false, "Only show todo and in-progress items whose dependencies are all completed")
+	listCmd.Flags().Bool("recurring", false, "Only show items which repeat")
+	listCmd.Flags().String("filter", "", "Only show items matching a filter expression, e.g. 'status:open and due<+7d'")
+	listCmd.Flags().String("view", "", "Only show the items of a built-in or saved view, see 'todo view list'")
+	addTableFlags(listCmd)
+	addOutputFlag(listCmd)
 	rootCmd.AddCommand(listCmd)
 }
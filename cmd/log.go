@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+	"strconv"
+	"time"
+)
+
+// logCmd represents the log command
+var logCmd = &cobra.Command{
+	Use:     "log [<item id>]",
+	Example: "todo log 3\ntodo log --since 1w\ntodo log --since 2026-10-01 --relative",
+	Short:   "Show the changes of an item or the latest activity.",
+	Long: "Displays every change of the fields of a todo item, oldest first, with the command which made it. " +
+		"Removed values are prefixed with - and added values with +. Items deleted for good keep their history.\n\n" +
+		"Without an item ID, displays the changes of every item made since --since, the last 7 days by default.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		var since time.Time
+		if value, _ := cmd.Flags().GetString("since"); cmd.Flags().Changed("since") {
+			var err error
+			since, err = parsePastTime(value)
+			if err != nil {
+				return newUsageError(
+					"Unable to show the log.", "'%s' is not an age such as 1w or a date such as 2026-10-01.", value,
+				)
+			}
+		}
+
+		var events []todo.ItemEvent
+		if len(args) == 1 {
+			itemId, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return newIdArgumentError("Unable to show the log.", args[0])
+			}
+			events, err = app.TodoUseCase.Log(cmd.Context(), itemId, since)
+			if err != nil {
+				return newItemError("Unable to show the log.", err)
+			}
+		} else {
+			if since.IsZero() {
+				since = appClock.Now().AddDate(0, 0, -7)
+			}
+			var err error
+			events, err = app.TodoUseCase.Activity(cmd.Context(), since)
+			if err != nil {
+				return newUnexpectedError("Unable to show the log.", err)
+			}
+		}
+
+		options := getFormatOptions(out)
+		options.RelativeTime, _ = cmd.Flags().GetBool("relative")
+		fmt.Fprint(out, todo.FormatItemEventLog(events, len(args) == 0, options))
+		return nil
+	},
+}
+
+func init() {
+	logCmd.Flags().String("since", "", "Only show the changes made since this age or date, e.g. 1w, 3d or 2026-10-01")
+	logCmd.Flags().Bool("relative", false, "Show times relative to now, e.g. 3h ago")
+	rootCmd.AddCommand(logCmd)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+)
+
+// nextCmd represents the next command
+var nextCmd = &cobra.Command{
+	Use:     "next",
+	Example: "todo next\ntodo next --output json",
+	Short:   "List the todo items which are ready to start.",
+	Long: "Displays the open todo items whose dependencies are all completed, ordered so that an item comes after " +
+		"the items it depends on. Same as `todo list --ready`.",
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return newUsageError("Unable to list todo items.", "%v.", err)
+		}
+
+		items, err := app.TodoUseCase.List(cmd.Context(), todo.ListOptions{Ready: true})
+		if err != nil {
+			return newItemError("Unable to list todo items.", err)
+		}
+		if err := printItems(out, items, false, format, getFormatOptions(out)); err != nil {
+			return newUnexpectedError("Unable to print todo items.", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	addOutputFlag(nextCmd)
+	rootCmd.AddCommand(nextCmd)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+// noteCmd represents the note command
+var noteCmd = &cobra.Command{
+	Use:     `note <item id> ["notes"]`,
+	Example: "todo note 1\ntodo note 1 \"See https://example.com/issue/42\"\ntodo note 1 --clear",
+	Short:   "Edit the notes of a todo item.",
+	Long: "Edit the free-form notes of a todo item by ID.\n\n" +
+		"Without notes, $VISUAL or $EDITOR is opened on the current notes, which are saved when the editor exits. " +
+		"Use --clear to remove the notes.",
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		itemId, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return newIdArgumentError("Unable to edit the notes of the todo item.", args[0])
+		}
+		clearNotes, _ := cmd.Flags().GetBool("clear")
+		if clearNotes && len(args) == 2 {
+			return newUsageError("Unable to edit the notes of the todo item.", "Provide either notes or --clear.")
+		}
+
+		changed := false
+		edit := func(current string) (string, error) {
+			var edited string
+			switch {
+			case clearNotes:
+				edited = ""
+			case len(args) == 2:
+				edited = args[1]
+			default:
+				edited, err = editText(current)
+				if err != nil {
+					return "", err
+				}
+			}
+			changed = edited != current
+			return edited, nil
+		}
+
+		if err := app.TodoUseCase.EditNote(cmd.Context(), itemId, edit); err != nil {
+			return newItemError("Unable to edit the notes of the todo item.", err)
+		}
+		if !changed {
+			fmt.Fprintln(out, "Notes unchanged")
+			return nil
+		}
+		fmt.Fprintln(out, "Saved notes")
+		return nil
+	},
+}
+
+func init() {
+	noteCmd.Flags().Bool("clear", false, "Remove the notes of the item")
+	rootCmd.AddCommand(noteCmd)
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal"
+	"github.com/rykeroc/todo-cli/internal/profile"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage profiles.",
+	Long: "Create, list, select and delete profiles, the named todo lists which each have their own database.\n\n" +
+		"Commands use the active profile unless --profile, --db or " + databaseEnv + " selects another database.",
+}
+
+// profileCreateCmd represents the profile create command
+var profileCreateCmd = &cobra.Command{
+	Use:     "create <profile name>",
+	Example: "todo profile create work\ntodo profile create shared --db ~/Dropbox/todo.db",
+	Short:   "Create a profile.",
+	Long: "Create a profile. Its database is created in the configuration directory unless --db is passed.\n\n" +
+		"Profile names can contain lower case letters, digits, dashes and underscores.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		store, err := newProfileStore()
+		if err != nil {
+			return newUnexpectedError("Unable to create profile.", err)
+		}
+		database, _ := cmd.Flags().GetString("db")
+
+		created, err := store.Create(args[0], database)
+		if err != nil {
+			return newProfileError("Unable to create profile.", args[0], err)
+		}
+		fmt.Fprintf(out, "Created new profile: %s (%s)\n", created.Name, created.Database)
+		return nil
+	},
+}
+
+// profileListCmd represents the profile list command
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles.",
+	Long:  "Displays the profiles with their database, the active profile is marked with an asterisk.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		store, err := newProfileStore()
+		if err != nil {
+			return newUnexpectedError("Unable to list profiles.", err)
+		}
+
+		profiles, err := store.List()
+		if err != nil {
+			return newUnexpectedError("Unable to list profiles.", err)
+		}
+		tabularList, err := profile.FormatProfileTable(profiles)
+		if err != nil {
+			return newUnexpectedError("Unable to print profiles.", err)
+		}
+		fmt.Fprint(out, tabularList)
+		return nil
+	},
+}
+
+// profileUseCmd represents the profile use command
+var profileUseCmd = &cobra.Command{
+	Use:     "use <profile name>",
+	Example: "todo profile use work\ntodo profile use " + profile.DefaultName,
+	Short:   "Select the active profile.",
+	Long:    "Select the profile which commands use from now on.",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		store, err := newProfileStore()
+		if err != nil {
+			return newUnexpectedError("Unable to select profile.", err)
+		}
+
+		if err := store.Use(args[0]); err != nil {
+			return newProfileError("Unable to select profile.", args[0], err)
+		}
+		fmt.Fprintf(out, "Using profile: %s\n", args[0])
+		return nil
+	},
+}
+
+// profileDeleteCmd represents the profile delete command
+var profileDeleteCmd = &cobra.Command{
+	Use:     "delete <profile name>",
+	Example: "todo profile delete work",
+	Short:   "Delete a profile.",
+	Long: "Delete a profile. Its database file is kept and can be used again with --db or `todo profile create --db`.\n\n" +
+		"The default profile and the active profile cannot be deleted.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		store, err := newProfileStore()
+		if err != nil {
+			return newUnexpectedError("Unable to delete profile.", err)
+		}
+
+		deleted, err := store.Delete(args[0])
+		if err != nil {
+			return newProfileError("Unable to delete profile.", args[0], err)
+		}
+		fmt.Fprintf(out, "Deleted profile, its database was kept: %s\n", deleted.Database)
+		return nil
+	},
+}
+
+// newProfileStore godoc
+//
+// Returns the store of the profiles recorded in the configuration directory of the app.
+func newProfileStore() (profile.Store, error) {
+	configDir, err := internal.GetAppConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("newProfileStore: %v", err)
+	}
+	return profile.NewFileStore(configDir), nil
+}
+
+// newProfileError godoc
+//
+// Maps an error returned by the profile store for the profile name to a commandError with a user message and an exit
+// code.
+func newProfileError(summary string, name string, err error) error {
+	commandErr := &commandError{summary: summary, err: err}
+	switch {
+	case errors.Is(err, profile.ErrNotFound):
+		commandErr.code = exitNotFound
+		commandErr.reason = fmt.Sprintf("No profile exists with name '%s'.", name)
+	case errors.Is(err, profile.ErrInvalidName):
+		commandErr.code = exitValidation
+		commandErr.reason = fmt.Sprintf(
+			"Invalid profile name '%s', use lower case letters, digits, dashes and underscores.", name,
+		)
+	case errors.Is(err, profile.ErrExists):
+		commandErr.code = exitConflict
+		commandErr.reason = fmt.Sprintf("A profile named '%s' already exists.", name)
+	case errors.Is(err, profile.ErrInUse) && name == profile.DefaultName:
+		commandErr.code = exitConflict
+		commandErr.reason = "The default profile cannot be deleted."
+	case errors.Is(err, profile.ErrInUse):
+		commandErr.code = exitConflict
+		commandErr.reason = fmt.Sprintf(
+			"Profile '%s' is active, select another profile with `todo profile use` first.", name,
+		)
+	default:
+		return newUnexpectedError(summary, err)
+	}
+	return commandErr
+}
+
+// resolveDatabasePath godoc
+//
+// Returns the path of the database used by a command, from the first of:
+//   - the --db flag
+//   - the --profile flag
+//   - the TODO_DB environment variable
+//   - the active profile
+func resolveDatabasePath(cmd *cobra.Command) (string, error) {
+	if database, _ := cmd.Flags().GetString("db"); database != "" {
+		return absoluteDatabasePath(database)
+	}
+
+	store, err := newProfileStore()
+	if err != nil {
+		return "", newUnexpectedError("Unable to open the database.", err)
+	}
+	if name, _ := cmd.Flags().GetString("profile"); name != "" {
+		selected, err := store.Find(name)
+		if err != nil {
+			return "", newProfileError("Unable to open the database.", name, err)
+		}
+		return selected.Database, nil
+	}
+
+	if database := os.Getenv(databaseEnv); database != "" {
+		return absoluteDatabasePath(database)
+	}
+
+	active, err := store.Active()
+	if err != nil {
+		return "", newUnexpectedError("Unable to open the database.", err)
+	}
+	return active.Database, nil
+}
+
+// absoluteDatabasePath godoc
+//
+// Returns the absolute path of a database path passed by the user, relative paths are relative to the working
+// directory.
+func absoluteDatabasePath(database string) (string, error) {
+	path, err := filepath.Abs(database)
+	if err != nil {
+		return "", newUnexpectedError("Unable to open the database.", fmt.Errorf("absoluteDatabasePath: %v", err))
+	}
+	return path, nil
+}
+
+// needsDatabase godoc
+//
+// Returns false for the commands which do not open the database: help, version, the profile and the config commands.
+func needsDatabase(cmd *cobra.Command) bool {
+	if cmd.Name() == "help" || cmd.Name() == "version" {
+		return false
+	}
+	for _, group := range []*cobra.Command{profileCmd, configCmd} {
+		if cmd == group || cmd.Parent() == group {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	profileCreateCmd.Flags().String("db", "", "Path of the database file of the profile")
+
+	profileCmd.AddCommand(profileCreateCmd, profileListCmd, profileUseCmd, profileDeleteCmd)
+	rootCmd.AddCommand(profileCmd)
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/project"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+// projectCmd represents the project command
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Manage projects.",
+	Long:  "Create, list, rename, archive and delete projects, the named lists which own todo items.",
+}
+
+// projectCreateCmd represents the project create command
+var projectCreateCmd = &cobra.Command{
+	Use:     `create "<project name>"`,
+	Example: `todo project create backend`,
+	Short:   "Create a project.",
+	Long:    "Create a project with a specified name. Project names are unique, ignoring case.",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		createdProject, err := app.ProjectUseCase.Create(cmd.Context(), args[0])
+		if err != nil {
+			return newItemError("Unable to create project.", err)
+		}
+		fmt.Fprintf(out, "Created new project: %s\n", createdProject.GetName())
+		return nil
+	},
+}
+
+// projectListCmd represents the project list command
+var projectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List projects.",
+	Long:  "Displays the active projects with their open and completed item counts.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		includeArchived, _ := cmd.Flags().GetBool("archived")
+		summaries, err := app.ProjectUseCase.List(cmd.Context(), includeArchived)
+		if err != nil {
+			return newUnexpectedError("Unable to list projects.", err)
+		}
+		tabularList, err := project.FormatProjectTable(summaries, appConfig.DateFormat)
+		if err != nil {
+			return newUnexpectedError("Unable to print projects.", err)
+		}
+		fmt.Fprintln(out, tabularList)
+		return nil
+	},
+}
+
+// projectRenameCmd represents the project rename command
+var projectRenameCmd = &cobra.Command{
+	Use:     `rename "<project name>" "<new name>"`,
+	Example: `todo project rename backend api`,
+	Short:   "Rename a project.",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		_, err := app.ProjectUseCase.Rename(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return newItemError("Unable to rename project.", err)
+		}
+		fmt.Fprintln(out, "Renamed project")
+		return nil
+	},
+}
+
+// projectArchiveCmd represents the project archive command
+var projectArchiveCmd = &cobra.Command{
+	Use:     `archive "<project name>"`,
+	Example: "todo project archive backend\ntodo project archive backend --undo",
+	Short:   "Archive a project.",
+	Long: "Archive a project. The items of archived projects are hidden from `todo list` " +
+		"unless the project is selected with --project.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		undo, _ := cmd.Flags().GetBool("undo")
+
+		var err error
+		if undo {
+			_, err = app.ProjectUseCase.Unarchive(cmd.Context(), args[0])
+		} else {
+			_, err = app.ProjectUseCase.Archive(cmd.Context(), args[0])
+		}
+		if err != nil {
+			return newItemError("Unable to archive project.", err)
+		}
+		if undo {
+			fmt.Fprintln(out, "Unarchived project")
+			return nil
+		}
+		fmt.Fprintln(out, "Archived project")
+		return nil
+	},
+}
+
+// projectDeleteCmd represents the project delete command
+var projectDeleteCmd = &cobra.Command{
+	Use:     `delete "<project name>"`,
+	Example: "todo project delete backend\ntodo project delete backend --cascade\ntodo project delete backend --move-to api",
+	Short:   "Delete a project.",
+	Long: "Delete a project. By default its items are kept without a project.\n\n" +
+		"Use --cascade to move the items to the trash as well, or --move-to to move them into another project.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		cascade, _ := cmd.Flags().GetBool("cascade")
+		moveTo, _ := cmd.Flags().GetString("move-to")
+
+		_, err := app.ProjectUseCase.Delete(cmd.Context(), args[0], project.DeleteOptions{
+			Cascade: cascade,
+			MoveTo:  moveTo,
+		})
+		if err != nil {
+			return newItemError("Unable to delete project.", err)
+		}
+		fmt.Fprintln(out, "Deleted project")
+		return nil
+	},
+}
+
+// resolveProjectId godoc
+//
+// Looks up the ID of a project from the value of a `--project` flag.
+//
+// The value "none" resolves to 0, which refers to items without a project. Archived projects are only resolved
+// when allowArchived is true.
+//
+// Returns -1 and a commandError starting with summary when the project cannot be used.
+//
+// Returns the project ID and nil on success.
+func resolveProjectId(ctx context.Context, summary string, name string, allowArchived bool) (int64, error) {
+	if strings.EqualFold(strings.TrimSpace(name), project.NoProjectName) {
+		return 0, nil
+	}
+
+	foundProject, err := app.ProjectUseCase.Find(ctx, name)
+	if err != nil {
+		return -1, newUnexpectedError(summary, fmt.Errorf("resolveProjectId: %v", err))
+	}
+	if foundProject == nil {
+		return -1, newProjectNotFoundError(summary, name)
+	}
+	if foundProject.IsArchived() && !allowArchived {
+		return -1, newCommandError(summary, exitConflict, "Project '%s' is archived.", foundProject.GetName())
+	}
+	return foundProject.GetId(), nil
+}
+
+// newProjectNotFoundError godoc
+//
+// Returns a commandError for a project name which does not match any project.
+func newProjectNotFoundError(summary string, name string) error {
+	return newCommandError(summary, exitNotFound, "No project exists with name '%s'.", name)
+}
+
+func init() {
+	projectListCmd.Flags().Bool("archived", false, "Include archived projects")
+	projectArchiveCmd.Flags().Bool("undo", false, "Make the archived project active again")
+	projectDeleteCmd.Flags().Bool("cascade", false, "Move the items of the project to the trash")
+	projectDeleteCmd.Flags().String("move-to", "", "Move the items of the project into this project")
+	projectDeleteCmd.MarkFlagsMutuallyExclusive("cascade", "move-to")
+
+	projectCmd.AddCommand(projectCreateCmd, projectListCmd, projectRenameCmd, projectArchiveCmd, projectDeleteCmd)
+	rootCmd.AddCommand(projectCmd)
+}
@@ -2,40 +2,74 @@ package cmd
 
 import (
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"strconv"
 )
 
 // removeCmd represents the remove command
 var removeCmd = &cobra.Command{
-	Use:     `remove <item id>`,
-	Example: `todo remove 1`,
-	Short:   "Delete a todo item.",
-	Long:    "Delete an existing todo item by specifying it's ID.",
-	Args:    cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	Use:     `remove <item id>...`,
+	Example: "todo remove 1\ntodo remove 3 5 8-12\ntodo remove --filter 'status:done and updated<-30d' --dry-run\ntodo remove 4 --hard",
+	Short:   "Move todo items to the trash.",
+	Long: "Move existing todo items to the trash by ID, by range of IDs such as 8-12 or by --filter. Subtasks go to " +
+		"the trash along with their parent.\n\n" +
+		"Items in the trash are hidden from every other command until they are restored with `todo restore` or " +
+		"deleted with `todo trash empty`. Use --hard to delete the items for good instead, whether they are in the " +
+		"trash or not.\n\n" +
+		"Several items are removed in a single transaction: when an item cannot be removed, no item is. " +
+		"Use --dry-run to print what would be removed.",
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		hard, _ := cmd.Flags().GetBool("hard")
+		if isBulkCommand(cmd, args) {
+			options, err := getBulkOptions(cmd, args, "Unable to remove todo items.")
+			if err != nil {
+				return err
+			}
+			removeItems, verbs := app.TodoUseCase.RemoveItems, removeVerbs
+			if hard {
+				removeItems, verbs = app.TodoUseCase.PurgeItems, purgeVerbs
+			}
+			summary, err := removeItems(cmd.Context(), options)
+			if err != nil {
+				return newItemError("Unable to remove todo items.", err)
+			}
+			return printBulkSummary(out, summary, options.DryRun, verbs, "Unable to remove todo items.")
+		}
+
 		idToDelete, err := strconv.ParseInt(args[0], 10, 64)
 		if err != nil {
-			fmt.Println("Unable to remove todo item.")
-			fmt.Printf("'%s' is not a valid ID.\n", args[0])
-			return
+			return newIdArgumentError("Unable to remove todo item.", args[0])
 		}
 
-		deletedItemId, err := app.TodoUseCase.Remove(idToDelete)
-		if err != nil {
-			log.Errorf("removeCmd: %v", err)
-			fmt.Println("An error occurred while removing the todo item")
-			return
+		if hard {
+			if err := app.TodoUseCase.Purge(cmd.Context(), idToDelete); err != nil {
+				return newItemError("Unable to delete todo item.", err)
+			}
+			fmt.Fprintln(out, "Deleted item")
+			return nil
 		}
-		if deletedItemId == -1 {
-			fmt.Printf("No todo item exists with ID %d", idToDelete)
-			return
+		if err := app.TodoUseCase.Remove(cmd.Context(), idToDelete); err != nil {
+			return newItemError("Unable to remove todo item.", err)
 		}
-		fmt.Println("Removed item")
+		fmt.Fprintln(out, "Moved item to the trash")
+		return nil
 	},
 }
 
+// removeVerbs godoc
+//
+// Words describing what `remove` does to each item.
+var removeVerbs = bulkVerbs{present: "remove", past: "Removed"}
+
+// purgeVerbs godoc
+//
+// Words describing what `remove --hard` does to each item.
+var purgeVerbs = bulkVerbs{present: "delete", past: "Deleted"}
+
 func init() {
+	addBulkFlags(removeCmd, removeVerbs)
+	removeCmd.Flags().Bool("hard", false, "Delete the items for good instead of moving them to the trash")
 	rootCmd.AddCommand(removeCmd)
 }
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"io"
+)
+
+// printItems godoc
+//
+// Writes the items to out in the output format, rendering tables with the options. When tree is true, subtasks are
+// written below their parent.
+//
+// Returns error on error, nil otherwise.
+func printItems(
+	out io.Writer, items []todo.Item, tree bool, format todo.OutputFormat, options todo.FormatOptions,
+) error {
+	formatter, err := todo.NewFormatterWithOptions(format, options)
+	if err != nil {
+		return fmt.Errorf("printItems: %v", err)
+	}
+	formattedList, err := formatter.FormatItems(items, tree)
+	if err != nil {
+		return fmt.Errorf("printItems: %v", err)
+	}
+	_, err = fmt.Fprint(out, formattedList)
+	return err
+}
+
+// printChangedItems godoc
+//
+// Writes the items changed by a command to out in an output format other than the table, which the commands
+// replace with a message.
+//
+// Returns a commandError on error writing the items, nil otherwise.
+func printChangedItems(out io.Writer, items []todo.Item, format todo.OutputFormat) error {
+	if err := printItems(out, items, false, format, getFormatOptions(out)); err != nil {
+		return newUnexpectedError("Unable to print todo items.", err)
+	}
+	return nil
+}
+
+// printItemDetails godoc
+//
+// Writes every field of an item, along with the items it is related to, to out in the output format.
+//
+// Returns error on error, nil otherwise.
+func printItemDetails(out io.Writer, details todo.ItemDetails, format todo.OutputFormat) error {
+	formatter, err := todo.NewFormatterWithOptions(format, getFormatOptions(out))
+	if err != nil {
+		return fmt.Errorf("printItemDetails: %v", err)
+	}
+	formattedItem, err := formatter.FormatItem(details)
+	if err != nil {
+		return fmt.Errorf("printItemDetails: %v", err)
+	}
+	_, err = fmt.Fprint(out, formattedItem)
+	return err
+}
@@ -1,83 +1,145 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"github.com/rykeroc/todo-cli/internal"
 	"github.com/rykeroc/todo-cli/internal/data"
+	"github.com/rykeroc/todo-cli/internal/modules/project"
 	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/rykeroc/todo-cli/internal/modules/view"
 	log "github.com/sirupsen/logrus"
 	"os"
+	"os/signal"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
 type appComponents struct {
-	TodoUseCase todo.UseCase
+	TodoUseCase    todo.UseCase
+	ProjectUseCase project.UseCase
+	ViewUseCase    view.UseCase
 }
 
 var helper data.SqlDatabaseHelper = nil
 var app *appComponents = nil
 
+// timeoutContext godoc
+//
+// Context of the running command when `--timeout` is set, nil otherwise. cancelTimeout releases it.
+var timeoutContext context.Context = nil
+var cancelTimeout context.CancelFunc = nil
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "todo",
 	Short: "A simple CLI app for todo items.",
 	Long:  "`todo` is appComponents simple CLI app for todo items.",
+	// Errors are printed by Execute, which also selects the exit code
+	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		if cmd.Name() == "help" || cmd.Name() == "version" {
 			return nil
 		}
+		// The arguments were parsed, errors from here on are not usage errors
+		cmd.SilenceUsage = true
+		if err := loadConfig(cmd); err != nil {
+			return err
+		}
 		log.Debugln("Running PersistentPreRunE")
 
-		// Connect to database
-		databaseFilename := fmt.Sprintf("%s.db", internal.AppName)
-		helper = data.NewSqliteDatabaseHelper(databaseFilename)
-		err := helper.Connect()
+		timeoutContext, cancelTimeout = nil, nil
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		if timeout < 0 {
+			return newUsageError("Invalid timeout.", "--timeout cannot be negative.")
+		}
+		// Start from the context of the execution, cobra keeps the context of a subcommand between executions
+		ctx := cmd.Root().Context()
+		if timeout > 0 {
+			timeoutContext, cancelTimeout = context.WithTimeout(ctx, timeout)
+			ctx = timeoutContext
+		}
+		// Changes of items are recorded as made by the command, e.g. "trash empty"
+		ctx = todo.WithOrigin(ctx, strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "))
+		cmd.SetContext(ctx)
+		if !needsDatabase(cmd) {
+			return nil
+		}
+
+		// Connect to the database of the selected profile
+		databasePath, err := resolveDatabasePath(cmd)
+		if err != nil {
+			return err
+		}
+		log.Debugf("rootCmd: PersistentPreRunE: Using database %s", databasePath)
+		helper = data.NewSqliteDatabaseHelper(databasePath)
+		err = helper.Connect(ctx)
 		if err != nil {
-			log.Errorf("rootCmd: PersistentPreRunE: %v", err)
-			return fmt.Errorf("an unexpected error occurred")
+			return newUnexpectedError("Unable to open the database.", fmt.Errorf("rootCmd: PersistentPreRunE: %v", err))
 		}
 
 		// Ensure database schema is initialized
-		err = helper.InitializeSchema()
+		err = helper.InitializeSchema(ctx)
 		if err != nil {
-			log.Errorf("rootCmd: PersistentPreRunE: %v", err)
-			return fmt.Errorf("an unexpected error occurred")
+			return newUnexpectedError("Unable to open the database.", fmt.Errorf("rootCmd: PersistentPreRunE: %v", err))
 		}
 
 		// Create the application structure
 		db := helper.GetDatabase()
 		if db == nil {
-			log.Errorf("rootCmd: PersistentPreRunE: `db` from `helper` is uninitialized")
-			return fmt.Errorf("an unexpected error occurred")
+			return newUnexpectedError(
+				"Unable to open the database.",
+				fmt.Errorf("rootCmd: PersistentPreRunE: `db` from `helper` is uninitialized"),
+			)
+		}
+		completionPolicy := todo.CompletionPolicyRefuse
+		if value, ok := os.LookupEnv(completionPolicyEnv); ok {
+			completionPolicy, err = todo.ParseCompletionPolicy(value)
+			if err != nil {
+				log.Errorf("rootCmd: PersistentPreRunE: %v", err)
+				return newUsageError("Invalid configuration.", "%s must be refuse or cascade.", completionPolicyEnv)
+			}
 		}
-		todoUseCase := todo.NewUseCase(
+		todoUseCase := todo.NewUseCaseWithCompletionPolicy(
 			todo.NewDomain(),
 			todo.NewSqliteRepository(db),
+			completionPolicy,
+		)
+		projectUseCase := project.NewUseCase(
+			project.NewDomain(),
+			project.NewSqliteRepository(db),
+			todoUseCase,
+		)
+		viewUseCase := view.NewUseCase(
+			view.NewDomainWithStaleDays(getStaleDays()),
+			view.NewSqliteRepository(db),
 		)
 		app = &appComponents{
 			todoUseCase,
+			projectUseCase,
+			viewUseCase,
 		}
 
 		log.Debugln("Completed PersistentPreRunE")
 		return nil
 	},
 	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
-		if cmd.Name() == "help" || cmd.Name() == "version" {
+		if !needsDatabase(cmd) {
 			return nil
 		}
 		log.Debugln("Running PersistentPostRunE")
 
 		// Return error if helper is not initialized
 		if helper == nil {
-			log.Errorf("rootCmd: PersistentPostRunE: `helper` is not initialized")
-			return fmt.Errorf("an unexpected error occurred")
+			return newUnexpectedError(
+				"Unable to close the database.",
+				fmt.Errorf("rootCmd: PersistentPostRunE: `helper` is not initialized"),
+			)
 		}
 
 		// Close database connection in database helper
 		if err := helper.Close(); err != nil {
-			log.Errorf("rootCmd: PersistentPostRunE: `helper` is not initialized")
-			return fmt.Errorf("an unexpected error occurred")
+			return newUnexpectedError("Unable to close the database.", fmt.Errorf("rootCmd: PersistentPostRunE: %v", err))
 		}
 
 		app = nil
@@ -89,11 +151,32 @@ var rootCmd = &cobra.Command{
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+//
+// The context of the commands is cancelled on interrupt, e.g. Ctrl+C, so that running queries are aborted.
+//
+// Errors are printed to stderr and the process exits with the code matching the error, see exitCode.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := withTimeout(timeoutContext, rootCmd.ExecuteContext(ctx))
+	stop()
 	if err != nil {
-		os.Exit(1)
+		fmt.Fprintf(rootCmd.ErrOrStderr(), "Error: %v\n", err)
+		os.Exit(exitCode(err))
 	}
 }
 
-func init() {}
+func init() {
+	rootCmd.PersistentFlags().Duration(
+		"timeout", 0, "Abort the command when it takes longer than this, e.g. 500ms or 5s, 0 waits indefinitely",
+	)
+	rootCmd.PersistentFlags().String(
+		"db", "", "Path of the database file to use instead of the database of the profile, overrides "+databaseEnv,
+	)
+	rootCmd.PersistentFlags().String("profile", "", "Name of the profile to use instead of the active profile, overrides "+databaseEnv)
+	rootCmd.MarkFlagsMutuallyExclusive("db", "profile")
+	cobra.OnFinalize(func() {
+		if cancelTimeout != nil {
+			cancelTimeout()
+		}
+	})
+}
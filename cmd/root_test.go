@@ -0,0 +1,699 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCommand godoc
+//
+// Runs the root command with the arguments against a database in a temporary configuration directory.
+//
+// Returns everything the command wrote to its output and the error returned by the command.
+func runCommand(args ...string) (string, error) {
+	var buffer bytes.Buffer
+	rootCmd.SetOut(&buffer)
+	rootCmd.SetErr(&buffer)
+	rootCmd.SetArgs(args)
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetErr(nil)
+	defer resetFlags(rootCmd)
+
+	err := rootCmd.Execute()
+	return buffer.String(), err
+}
+
+// executeCommand godoc
+//
+// Runs the root command with runCommand and asserts that it succeeds.
+//
+// Returns everything the command wrote to its output.
+func executeCommand(t *testing.T, args ...string) string {
+	output, err := runCommand(args...)
+	assert.NoError(t, err)
+	return output
+}
+
+// resetFlags godoc
+//
+// Restores the default value of the flags of a command and of its subcommands, flags keep their value between
+// executions.
+func resetFlags(cmd *cobra.Command) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if !flag.Changed {
+			return
+		}
+		if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+			_ = sliceValue.Replace(nil)
+		} else {
+			_ = flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	})
+	for _, subcommand := range cmd.Commands() {
+		resetFlags(subcommand)
+	}
+}
+
+func TestCommands_WriteToInjectedOutput(t *testing.T) {
+	configDir := t.TempDir()
+	// Keep the database out of the user's configuration directory on every platform
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+
+	t.Run("should print the created item", func(t *testing.T) {
+		assert.Equal(t, "Created new project: backend\n", executeCommand(t, "project", "create", "backend"))
+		assert.Equal(t, "Created new todo: ship it\n", executeCommand(t, "create", "ship it", "--project", "backend"))
+	})
+
+	t.Run("should print the listed items in the output format", func(t *testing.T) {
+		output := executeCommand(t, "list", "--output", "json")
+
+		var documents []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(output), &documents))
+		require.Len(t, documents, 1)
+		assert.Equal(t, "ship it", documents[0]["name"])
+		assert.Equal(t, "backend", documents[0]["project"])
+	})
+
+	t.Run("should print the item details", func(t *testing.T) {
+		assert.Contains(t, executeCommand(t, "show", "1"), "Name:          ship it\n")
+	})
+
+	t.Run("should print the items matching the filter", func(t *testing.T) {
+		assert.Contains(t, executeCommand(t, "list", "--filter", "project:backend and status:open"), "ship it")
+		assert.NotContains(t, executeCommand(t, "list", "--filter", "not name:ship"), "ship it")
+	})
+
+	t.Run("should print the selected columns in the sort order", func(t *testing.T) {
+		executeCommand(t, "create", "write notes")
+		output := executeCommand(t, "list", "--sort", "name:desc", "--columns", "name,id", "--relative")
+		assert.True(t, strings.HasPrefix(output, "Name           ID\n----           --\nwrite notes    2\nship it        1\n"))
+		executeCommand(t, "remove", "2")
+	})
+
+	t.Run("should print the items of the views", func(t *testing.T) {
+		assert.Equal(t, "Saved view: shipping\n", executeCommand(
+			t, "view", "save", "shipping", "--filter", "name:ship", "--columns", "id,name",
+		))
+		assert.Contains(t, executeCommand(t, "view", "list"), "shipping     name:ship")
+		viewOutput := executeCommand(t, "list", "--view", "shipping")
+		assert.Contains(t, viewOutput, "ship it")
+		assert.NotContains(t, viewOutput, "Priority")
+		assert.Contains(t, executeCommand(t, "list", "--view", "shipping", "--columns", "id,priority"), "Priority")
+		assert.NotContains(t, executeCommand(t, "list", "--view", "shipping", "--filter", "status:done"), "ship it")
+		assert.Contains(t, executeCommand(t, "today"), "No todo items...")
+		assert.Equal(t, "Deleted view\n", executeCommand(t, "view", "delete", "shipping"))
+	})
+
+	t.Run("should print the matching items", func(t *testing.T) {
+		assert.Contains(t, executeCommand(t, "search", "ship"), "[ship] it")
+		assert.Equal(t, "No matching todo items...\n", executeCommand(t, "search", "release", "notes"))
+	})
+
+	t.Run("should return errors with their exit code instead of printing them", func(t *testing.T) {
+		type testCase struct {
+			args            []string
+			expectedMessage string
+			expectedCode    int
+		}
+
+		testCases := []testCase{
+			{
+				args:            []string{"show", "2"},
+				expectedMessage: "Unable to show todo item.\nNo todo item exists with ID 2.",
+				expectedCode:    exitNotFound,
+			},
+			{
+				args:            []string{"remove", "abc"},
+				expectedMessage: "Unable to remove todo item.\n'abc' is not a valid ID.",
+				expectedCode:    exitInvalidId,
+			},
+			{
+				args:            []string{"complete", "0"},
+				expectedMessage: "Unable to complete todo item.\nIDs must be positive numbers.",
+				expectedCode:    exitInvalidId,
+			},
+			{
+				args:            []string{"tag", "add", "1", "not valid"},
+				expectedMessage: "Unable to tag todo item.\nInvalid tag: 'not valid' cannot contain whitespace or commas.",
+				expectedCode:    exitValidation,
+			},
+			{
+				args: []string{"list", "--filter", "status:open or"},
+				expectedMessage: "Unable to list todo items.\n" +
+					"Invalid filter at column 15: unexpected end of the filter, expected a comparison such as status:open.\n" +
+					"  status:open or\n" +
+					"                ^",
+				expectedCode: exitValidation,
+			},
+			{
+				args: []string{"list", "--sort", "due:up"},
+				expectedMessage: "Unable to list todo items.\n'due:up' is not a sort order, use fields of priority, due, " +
+					"created, updated, name, id each followed by :asc or :desc.",
+				expectedCode: exitUsage,
+			},
+			{
+				args: []string{"list", "--columns", "id,size"},
+				expectedMessage: "Unable to list todo items.\n'id,size' is not a list of columns of id, name, project, " +
+					"tags, priority, due, repeats, updated, created, status, blocked.",
+				expectedCode: exitUsage,
+			},
+			{
+				args:            []string{"list", "--view", "shipping"},
+				expectedMessage: "Unable to list todo items.\nNo view exists with name 'shipping'.",
+				expectedCode:    exitNotFound,
+			},
+			{
+				args:            []string{"view", "delete", "today"},
+				expectedMessage: "Unable to delete view.\n'today' is a built-in view, use another name.",
+				expectedCode:    exitConflict,
+			},
+			{
+				args:            []string{"depends", "1", "--on", "1"},
+				expectedMessage: "Unable to add the dependency.\nAn item cannot depend on itself.",
+				expectedCode:    exitConflict,
+			},
+			{
+				args:            []string{"project", "archive", "frontend"},
+				expectedMessage: "Unable to archive project.\nNo project exists with name 'frontend'.",
+				expectedCode:    exitNotFound,
+			},
+			{
+				args:            []string{"project", "create", "Backend"},
+				expectedMessage: "Unable to create project.\nA project named 'backend' already exists.",
+				expectedCode:    exitConflict,
+			},
+			{
+				args:            []string{"project", "rename", "backend", "none"},
+				expectedMessage: "Unable to rename project.\nThe project name cannot be 'none'.",
+				expectedCode:    exitValidation,
+			},
+			{
+				args:            []string{"project", "archive", "backend", "--undo"},
+				expectedMessage: "Unable to archive project.\nProject 'backend' is not archived.",
+				expectedCode:    exitConflict,
+			},
+		}
+
+		for _, test := range testCases {
+			output, err := runCommand(test.args...)
+			assert.Empty(t, output)
+			assert.EqualError(t, err, test.expectedMessage)
+			assert.Equal(t, test.expectedCode, exitCode(err))
+		}
+	})
+
+	t.Run("should print the tags", func(t *testing.T) {
+		assert.Equal(t, "No tags...\n", executeCommand(t, "tag", "list"))
+	})
+
+	t.Run("should abort the command when the timeout expires", func(t *testing.T) {
+		_, err := runCommand("list", "--timeout", "1ns")
+		err = withTimeout(timeoutContext, err)
+		assert.Equal(t, exitTimeout, exitCode(err))
+
+		assert.Contains(t, executeCommand(t, "list", "--timeout", "1m"), "ship it")
+	})
+
+	t.Run("should print the changed items in the output format", func(t *testing.T) {
+		var documents []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(executeCommand(t, "create", "write docs", "-o", "json")), &documents))
+		require.Len(t, documents, 1)
+		assert.Equal(t, "write docs", documents[0]["name"])
+		itemId := fmt.Sprint(documents[0]["id"])
+
+		assert.Contains(t, executeCommand(t, "start", itemId, "--output", "yaml"), "status: in-progress")
+		assert.Contains(t, executeCommand(t, "update", itemId, "--priority", "high", "-o", "csv"), "write docs")
+		require.NoError(t, json.Unmarshal([]byte(executeCommand(t, "complete", itemId, "-o", "json")), &documents))
+		require.Len(t, documents, 1)
+		assert.Equal(t, "done", documents[0]["status"])
+		assert.Equal(
+			t, "Reopened item "+itemId+": write docs\n", executeCommand(t, "reopen", itemId, "--output", "table"),
+		)
+
+		_, err := runCommand("complete", "1", itemId, "--output", "json")
+		assert.EqualError(
+			t, err,
+			"Unable to complete todo items.\n--output only applies to a single item, bulk operations print a summary.",
+		)
+		assert.Equal(t, exitUsage, exitCode(err))
+	})
+}
+
+func TestCommands_UseTheSelectedDatabase(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv(databaseEnv, "")
+
+	executeCommand(t, "create", "personal item")
+	assert.Contains(t, executeCommand(t, "profile", "create", "work"), "Created new profile: work")
+
+	t.Run("should keep the items of each profile apart", func(t *testing.T) {
+		executeCommand(t, "--profile", "work", "create", "work item")
+
+		assert.NotContains(t, executeCommand(t, "list"), "work item")
+
+		executeCommand(t, "profile", "use", "work")
+		output := executeCommand(t, "list")
+		assert.Contains(t, output, "work item")
+		assert.NotContains(t, output, "personal item")
+
+		assert.Contains(t, executeCommand(t, "--profile", "default", "list"), "personal item")
+		executeCommand(t, "profile", "use", "default")
+	})
+
+	t.Run("should use the database of --db or TODO_DB over the active profile", func(t *testing.T) {
+		database := filepath.Join(t.TempDir(), "other.db")
+		executeCommand(t, "--db", database, "create", "other item")
+
+		t.Setenv(databaseEnv, database)
+		output := executeCommand(t, "list")
+		assert.Contains(t, output, "other item")
+		assert.NotContains(t, output, "personal item")
+	})
+
+	t.Run("should return errors with their exit code", func(t *testing.T) {
+		_, err := runCommand("--profile", "missing", "list")
+		assert.EqualError(t, err, "Unable to open the database.\nNo profile exists with name 'missing'.")
+		assert.Equal(t, exitNotFound, exitCode(err))
+
+		_, err = runCommand("profile", "delete", "default")
+		assert.EqualError(t, err, "Unable to delete profile.\nThe default profile cannot be deleted.")
+		assert.Equal(t, exitConflict, exitCode(err))
+	})
+}
+
+func TestCommands_ApplyToSeveralItems(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv(databaseEnv, "")
+
+	for _, name := range []string{"first", "second", "third", "fourth"} {
+		executeCommand(t, "create", name)
+	}
+
+	t.Run("should preview the changes of a dry run", func(t *testing.T) {
+		assert.Equal(
+			t,
+			"Would complete item 1: first\nWould complete item 3: third\nWould complete item 4: fourth\n"+
+				"Dry run: would complete 3 items, no item was changed\n",
+			executeCommand(t, "complete", "1", "3-4", "--dry-run"),
+		)
+		assert.Contains(t, executeCommand(t, "list", "--filter", "status:done"), "No todo items...")
+	})
+
+	t.Run("should not change any item when an item fails", func(t *testing.T) {
+		output, err := runCommand("complete", "1", "9")
+		assert.Equal(t, "Rolled back item 1: first\nFailed item 9: No todo item exists with ID 9.\n", output)
+		assert.EqualError(t, err, "Unable to complete todo items.\n1 of 2 items failed, no item was changed.")
+		assert.Equal(t, exitNotFound, exitCode(err))
+	})
+
+	t.Run("should change the selected items", func(t *testing.T) {
+		assert.Contains(t, executeCommand(t, "complete", "1", "3-4"), "Completed 3 items\n")
+		assert.Equal(
+			t,
+			"Updated item 2: second\nUpdated 1 item\n",
+			executeCommand(t, "update", "--filter", "status:open", "--set", "priority=high"),
+		)
+		assert.Contains(t, executeCommand(t, "list", "--filter", "priority:high"), "second")
+		assert.Contains(t, executeCommand(t, "remove", "--filter", "status:done"), "Removed 3 items\n")
+		assert.Equal(t, "No todo items match\n", executeCommand(t, "remove", "--filter", "status:done"))
+	})
+
+	t.Run("should rename an item to a number without other changes", func(t *testing.T) {
+		assert.Equal(t, "Updated item\n", executeCommand(t, "update", "2", "2026"))
+		assert.Contains(t, executeCommand(t, "list", "--filter", "name:2026"), "2026")
+		assert.Equal(t, "Updated item\n", executeCommand(t, "update", "2", "second"))
+	})
+
+	t.Run("should apply to parents along with their subtasks", func(t *testing.T) {
+		executeCommand(t, "create", "release")
+		executeCommand(t, "create", "changelog", "--parent", "5")
+
+		assert.Equal(
+			t,
+			"Completed item 2: second\nCompleted item 6: changelog\nCompleted item 5: release\nCompleted 3 items\n",
+			executeCommand(t, "complete", "2-6"),
+		)
+		assert.Equal(
+			t,
+			"Removed item 6: changelog, with item 5\nRemoved item 5: release\nRemoved 2 items\n",
+			executeCommand(t, "remove", "5", "6"),
+		)
+		assert.Equal(
+			t, "Restored item 5: release\nRestored item 6: changelog\n", executeCommand(t, "restore", "5"),
+		)
+	})
+
+	t.Run("should return usage errors", func(t *testing.T) {
+		testCases := map[string][]string{
+			"Unable to remove todo items.\nProvide IDs, e.g. 3 5 8-12, or --filter.": {"remove"},
+			"Unable to remove todo items.\nProvide IDs or --filter, not both.":       {"remove", "2", "--filter", "id:2"},
+			"Unable to update todo items.\n'size=large' is not a field=value pair of " +
+				"name, due, priority, project, parent, repeat, status.": {"update", "2", "3", "--set", "size=large"},
+		}
+		for expectedMessage, args := range testCases {
+			_, err := runCommand(args...)
+			assert.EqualError(t, err, expectedMessage)
+			assert.Equal(t, exitUsage, exitCode(err))
+		}
+	})
+}
+
+func TestCommands_UndoAndRedo(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv(databaseEnv, "")
+
+	executeCommand(t, "create", "first")
+	executeCommand(t, "create", "second")
+
+	t.Run("should undo and redo the last operation", func(t *testing.T) {
+		executeCommand(t, "remove", "1-2")
+
+		assert.Equal(t, "Undid: remove items 1, 2\n", executeCommand(t, "undo"))
+		assert.Contains(t, executeCommand(t, "list"), "second")
+		assert.Equal(t, "Redid: remove items 1, 2\n", executeCommand(t, "redo"))
+		assert.Contains(t, executeCommand(t, "list"), "No todo items...")
+	})
+
+	t.Run("should list the operations", func(t *testing.T) {
+		executeCommand(t, "undo")
+
+		output := executeCommand(t, "history", "--limit", "2")
+
+		lines := strings.Split(output, "\n")
+		require.Len(t, lines, 5)
+		assert.Contains(t, lines[2], "remove items 1, 2")
+		assert.Contains(t, lines[2], "yes")
+		assert.Contains(t, lines[3], "create item 2")
+	})
+
+	t.Run("should return a conflict when there is nothing to redo or undo", func(t *testing.T) {
+		executeCommand(t, "create", "third")
+
+		_, err := runCommand("redo")
+		assert.EqualError(
+			t, err, "Unable to redo.\nThere is nothing to redo, or an operation was recorded since the last undo.",
+		)
+		assert.Equal(t, exitConflict, exitCode(err))
+
+		for range 3 {
+			executeCommand(t, "undo")
+		}
+		_, err = runCommand("undo")
+		assert.EqualError(t, err, "Unable to undo.\nThere is nothing to undo.")
+		assert.Equal(t, exitConflict, exitCode(err))
+	})
+}
+
+func TestCommands_DeleteProjects(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv(databaseEnv, "")
+
+	executeCommand(t, "project", "create", "backend")
+	executeCommand(t, "project", "create", "ops")
+	executeCommand(t, "create", "ship it", "--project", "backend")
+	executeCommand(t, "create", "write notes", "--parent", "1")
+	executeCommand(t, "create", "deploy", "--project", "ops")
+
+	t.Run("should move the items of a deleted project to the trash", func(t *testing.T) {
+		assert.Equal(t, "Deleted project\n", executeCommand(t, "project", "delete", "backend", "--cascade"))
+
+		assert.NotContains(t, executeCommand(t, "list"), "ship it")
+		trash := executeCommand(t, "trash", "list")
+		assert.Contains(t, trash, "ship it")
+		assert.Contains(t, trash, "write notes")
+		assert.Contains(t, executeCommand(t, "history", "--limit", "1"), "remove item 1")
+		log := executeCommand(t, "log", "1")
+		assert.Contains(t, log, "project delete")
+		assert.Contains(t, log, "- projectId: 1")
+	})
+
+	t.Run("should restore the items without a project on undo", func(t *testing.T) {
+		assert.Equal(
+			t,
+			"Undid: remove item 1\nItem 1 was not put back in its project, which was deleted.\n",
+			executeCommand(t, "undo"),
+		)
+
+		output := executeCommand(t, "list", "--output", "json")
+		var documents []map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(output), &documents))
+		assert.Len(t, documents, 3)
+		for _, document := range documents {
+			if document["name"] == "deploy" {
+				assert.Equal(t, "ops", document["project"])
+			} else {
+				assert.Empty(t, document["project"])
+			}
+		}
+	})
+
+	t.Run("should report the items which undo cannot put back in a deleted project", func(t *testing.T) {
+		assert.Equal(t, "Deleted project\n", executeCommand(t, "project", "delete", "ops"))
+		assert.Contains(t, executeCommand(t, "history", "--limit", "1"), "move item 3")
+		assert.Equal(
+			t,
+			"Undid: move item 3\nItem 3 was not put back in its project, which was deleted.\n",
+			executeCommand(t, "undo"),
+		)
+		assert.NotContains(t, executeCommand(t, "log", "3"), "undo")
+	})
+}
+
+func TestCommands_Trash(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv(databaseEnv, "")
+
+	executeCommand(t, "create", "first")
+	executeCommand(t, "create", "second")
+
+	t.Run("should move removed items to the trash", func(t *testing.T) {
+		assert.Equal(t, "Moved item to the trash\n", executeCommand(t, "remove", "1"))
+
+		assert.NotContains(t, executeCommand(t, "list"), "first")
+		lines := strings.Split(executeCommand(t, "trash", "list"), "\n")
+		require.Len(t, lines, 4)
+		assert.Contains(t, lines[0], "Deleted")
+		assert.Contains(t, lines[2], "first")
+	})
+
+	t.Run("should restore an item from the trash", func(t *testing.T) {
+		assert.Equal(t, "Restored item 1: first\n", executeCommand(t, "restore", "1"))
+		assert.Equal(t, "The trash is empty\n", executeCommand(t, "trash", "list"))
+
+		_, err := runCommand("restore", "1")
+		assert.EqualError(t, err, "Unable to restore todo item.\nNo todo item exists with ID 1.")
+		assert.Equal(t, exitNotFound, exitCode(err))
+	})
+
+	t.Run("should empty the trash", func(t *testing.T) {
+		executeCommand(t, "remove", "1-2")
+
+		assert.Equal(
+			t, "Deleted 0 items from the trash\n", executeCommand(t, "trash", "empty", "--older-than", "30d"),
+		)
+		assert.Equal(t, "Deleted 2 items from the trash\n", executeCommand(t, "trash", "empty"))
+		assert.Equal(t, "The trash is empty\n", executeCommand(t, "trash", "list"))
+
+		_, err := runCommand("trash", "empty", "--older-than", "soon")
+		assert.Equal(t, exitUsage, exitCode(err))
+	})
+
+	t.Run("should delete items for good with --hard", func(t *testing.T) {
+		executeCommand(t, "create", "third")
+
+		assert.Equal(t, "Deleted item\n", executeCommand(t, "remove", "3", "--hard"))
+		assert.Equal(t, "The trash is empty\n", executeCommand(t, "trash", "list"))
+		assert.Contains(t, executeCommand(t, "list"), "No todo items...")
+	})
+}
+
+func TestCommands_Status(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv(databaseEnv, "")
+
+	executeCommand(t, "create", "write report")
+	executeCommand(t, "create", "renew licence")
+
+	t.Run("should start and block items", func(t *testing.T) {
+		assert.Equal(t, "Started item 1: write report\n", executeCommand(t, "start", "1"))
+		assert.Equal(
+			t, "Blocked item 2: renew licence\n", executeCommand(t, "block", "2", "--reason", "waiting on finance"),
+		)
+
+		assert.Contains(t, executeCommand(t, "show", "2"), "blocked (waiting on finance)")
+		list := executeCommand(t, "list", "--columns", "id,status")
+		assert.Contains(t, list, "1     🔄 in-progress\n")
+		assert.Contains(t, list, "2     ⛔ blocked\n")
+		assert.NotContains(t, executeCommand(t, "list", "--ready"), "renew licence")
+	})
+
+	t.Run("should reopen completed items", func(t *testing.T) {
+		executeCommand(t, "complete", "1")
+
+		_, err := runCommand("start", "1")
+		assert.EqualError(
+			t, err, "Unable to start todo item.\nItem 1 is done and must be reopened before it becomes in-progress.",
+		)
+		assert.Equal(t, exitConflict, exitCode(err))
+		assert.Equal(t, "Reopened item 1: write report\n", executeCommand(t, "reopen", "1"))
+		assert.Contains(t, executeCommand(t, "list", "--filter", "status:todo"), "write report")
+	})
+
+	t.Run("should update the status", func(t *testing.T) {
+		executeCommand(t, "update", "2", "--status", "cancelled")
+		assert.Contains(t, executeCommand(t, "list", "--filter", "status:closed"), "renew licence")
+
+		_, err := runCommand("update", "2", "--status", "paused")
+		assert.Equal(t, exitUsage, exitCode(err))
+		_, err = runCommand("reopen", "two")
+		assert.Equal(t, exitInvalidId, exitCode(err))
+	})
+
+	t.Run("should complete items updated to done", func(t *testing.T) {
+		executeCommand(t, "create", "send invoice", "--parent", "1")
+
+		_, err := runCommand("update", "1", "--set", "status=done")
+		assert.Equal(t, exitConflict, exitCode(err))
+		assert.Contains(t, executeCommand(t, "list", "--filter", "status:open"), "write report")
+
+		executeCommand(t, "update", "3", "--status", "done")
+		executeCommand(t, "update", "1", "--set", "status=done")
+		assert.Contains(t, executeCommand(t, "list", "--filter", "status:done"), "write report")
+	})
+}
+
+func TestCommands_Log(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv(databaseEnv, "")
+
+	executeCommand(t, "create", "write report")
+	executeCommand(t, "create", "renew licence")
+	executeCommand(t, "update", "1", "--priority", "high")
+	executeCommand(t, "block", "2", "--reason", "waiting on finance")
+
+	t.Run("should show the changes of an item with their command", func(t *testing.T) {
+		lines := strings.Split(executeCommand(t, "log", "1"), "\n")
+
+		require.Len(t, lines, 6)
+		assert.True(t, strings.HasSuffix(lines[0], "  create"))
+		assert.Equal(t, "  + name: write report", lines[1])
+		assert.Equal(t, "  + status: todo", lines[2])
+		assert.True(t, strings.HasSuffix(lines[3], "  update"))
+		assert.Equal(t, "  + priority: high", lines[4])
+	})
+
+	t.Run("should show the activity of every item", func(t *testing.T) {
+		output := executeCommand(t, "log", "--since", "1d", "--relative")
+
+		assert.Contains(t, output, "now  block  item 2: renew licence\n  - status: todo\n  + status: blocked\n")
+		assert.Contains(t, output, "  + statusReason: waiting on finance\n")
+		assert.Equal(t, "No changes...\n", executeCommand(t, "log", "1", "--since", "2999-01-01"))
+	})
+
+	t.Run("should return error when the item or the age is invalid", func(t *testing.T) {
+		_, err := runCommand("log", "3")
+		assert.EqualError(t, err, "Unable to show the log.\nNo todo item exists with ID 3.")
+		assert.Equal(t, exitNotFound, exitCode(err))
+
+		_, err = runCommand("log", "one")
+		assert.Equal(t, exitInvalidId, exitCode(err))
+		_, err = runCommand("log", "--since", "soon")
+		assert.Equal(t, exitUsage, exitCode(err))
+	})
+}
+
+func TestCommands_UseTheConfiguration(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv(databaseEnv, "")
+	t.Setenv(logLevelEnv, "")
+
+	t.Run("should have defaults and validation for every key", func(t *testing.T) {
+		for _, key := range internal.ConfigKeys {
+			assert.Contains(t, configKeys, key)
+		}
+	})
+
+	t.Run("should apply the configured defaults", func(t *testing.T) {
+		executeCommand(t, "project", "create", "ops")
+		assert.Equal(t, "Set default_project to ops\n", executeCommand(t, "config", "set", "default_project", "ops"))
+		assert.Equal(t, "Set output to json\n", executeCommand(t, "config", "set", "output", "json"))
+		executeCommand(t, "create", "ship it")
+
+		output := executeCommand(t, "list")
+		var documents []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(output), &documents))
+		require.NotEmpty(t, documents)
+		assert.Equal(t, "ops", documents[0]["project"])
+
+		assert.Contains(t, executeCommand(t, "list", "--output", "table"), "ship it")
+		assert.Equal(t, "json\n", executeCommand(t, "config", "get", "output"))
+	})
+
+	t.Run("should restore the default of an unset key", func(t *testing.T) {
+		assert.Equal(t, "Unset output\n", executeCommand(t, "config", "set", "output", ""))
+		assert.Equal(t, "table\n", executeCommand(t, "config", "get", "output"))
+	})
+
+	t.Run("should return errors with their exit code", func(t *testing.T) {
+		_, err := runCommand("config", "set", "sort", "size")
+		assert.EqualError(
+			t, err,
+			"Unable to set the configuration.\nInvalid sort: 'size' is not a sort order, use fields of priority, due, created, updated, name, id.",
+		)
+		assert.Equal(t, exitValidation, exitCode(err))
+
+		_, err = runCommand("config", "get", "editor")
+		assert.Equal(t, exitUsage, exitCode(err))
+	})
+
+	t.Run("should refuse an invalid configuration file", func(t *testing.T) {
+		configPath := executeCommand(t, "config", "path")
+		err := os.WriteFile(strings.TrimSpace(configPath), []byte("color: sometimes\n"), 0644)
+		assert.NoError(t, err)
+
+		_, err = runCommand("list")
+		assert.ErrorContains(t, err, "Invalid color in ")
+		assert.Equal(t, exitUsage, exitCode(err))
+
+		// The configuration can still be fixed
+		executeCommand(t, "config", "set", "color", "never")
+		executeCommand(t, "list")
+	})
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use: `search "<query>"`,
+	Example: "todo search deploy\n" +
+		"todo search \"deploy AND staging\"\n" +
+		"todo search '\"release notes\" OR changelog'\n" +
+		"todo search \"migrat* NOT done\"\n" +
+		"todo search deploy --output json",
+	Short: "Search todo items by name and notes.",
+	Long: "Displays the todo items whose name or notes match a full-text query, the most relevant first, with the " +
+		"matches highlighted.\n\n" +
+		"Queries are made of words, \"phrases\" and prefix* terms combined with AND, OR, NOT and parentheses. " +
+		"Words without an operator must all match.",
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return newUsageError("Unable to search todo items.", "%v.", err)
+		}
+
+		results, err := app.TodoUseCase.Search(cmd.Context(), strings.Join(args, " "))
+		if err != nil {
+			return newItemError("Unable to search todo items.", err)
+		}
+		formatter, err := todo.NewFormatterWithOptions(format, getFormatOptions(out))
+		if err != nil {
+			return newUnexpectedError("Unable to print todo items.", err)
+		}
+		formattedResults, err := formatter.FormatSearchResults(results)
+		if err != nil {
+			return newUnexpectedError("Unable to print todo items.", err)
+		}
+		fmt.Fprint(out, formattedResults)
+		return nil
+	},
+}
+
+func init() {
+	addOutputFlag(searchCmd)
+	rootCmd.AddCommand(searchCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+// showCmd represents the show command
+var showCmd = &cobra.Command{
+	Use:     "show <item id>",
+	Example: "todo show 1\ntodo show 1 --json\ntodo show 1 --output yaml",
+	Short:   "Show a todo item.",
+	Long:    "Display every field of a todo item by ID, including its notes, subtasks and dependencies.",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		itemId, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return newIdArgumentError("Unable to show todo item.", args[0])
+		}
+
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return newUsageError("Unable to show todo item.", "%v.", err)
+		}
+		// `--json` is a shorthand for `--output json`
+		if asJson, _ := cmd.Flags().GetBool("json"); asJson {
+			format = todo.OutputFormatJson
+		}
+
+		details, err := app.TodoUseCase.Get(cmd.Context(), itemId)
+		if err != nil {
+			return newItemError("Unable to show todo item.", err)
+		}
+		if err := printItemDetails(out, *details, format); err != nil {
+			return newUnexpectedError("Unable to show todo item.", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+	addOutputFlag(showCmd)
+	showCmd.Flags().Bool("json", false, "Print the item as a JSON object, same as --output json")
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+	"strconv"
+)
+
+// reopenCmd represents the reopen command
+var reopenCmd = &cobra.Command{
+	Use:     "reopen <item id>",
+	Example: "todo complete 3\ntodo reopen 3",
+	Short:   "Move an item back to todo.",
+	Long: "Move a todo item back to the todo status, e.g. after it was completed or cancelled by mistake, or once " +
+		"it is no longer blocked or waiting.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStatusCommand(cmd, args[0], "reopen", "Reopened", app.TodoUseCase.Reopen)
+	},
+}
+
+// startCmd represents the start command
+var startCmd = &cobra.Command{
+	Use:     "start <item id>",
+	Example: "todo start 3",
+	Short:   "Mark an item as in progress.",
+	Long:    "Move a todo item to the in-progress status. A done or cancelled item must be reopened first.",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStatusCommand(cmd, args[0], "start", "Started", app.TodoUseCase.Start)
+	},
+}
+
+// blockCmd represents the block command
+var blockCmd = &cobra.Command{
+	Use:     "block <item id>",
+	Example: "todo block 3\ntodo block 3 --reason \"waiting on the API key\"",
+	Short:   "Mark an item as blocked.",
+	Long: "Move a todo item to the blocked status, with an optional reason which is shown by `todo show`. " +
+		"Blocked items are not listed by `todo list --ready` and `todo next`. Use `todo reopen` to unblock an item.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reason, _ := cmd.Flags().GetString("reason")
+		block := func(ctx context.Context, itemId int64) (todo.Item, error) {
+			return app.TodoUseCase.Block(ctx, itemId, reason)
+		}
+		return runStatusCommand(cmd, args[0], "block", "Blocked", block)
+	},
+}
+
+// runStatusCommand godoc
+//
+// Parses the ID argument of a status command, changes the status of the item and prints the item, e.g.
+// "Started item 3: Write report", or the item in the output format of the command.
+//
+// Returns a commandError when the ID is invalid or the status cannot change.
+func runStatusCommand(
+	cmd *cobra.Command, idArg string, verb string, pastVerb string,
+	changeStatus func(context.Context, int64) (todo.Item, error),
+) error {
+	summary := fmt.Sprintf("Unable to %s todo item.", verb)
+	itemId, err := strconv.ParseInt(idArg, 10, 64)
+	if err != nil {
+		return newIdArgumentError(summary, idArg)
+	}
+	format, err := getOutputFormat(cmd)
+	if err != nil {
+		return newUsageError(summary, "%v.", err)
+	}
+
+	item, err := changeStatus(cmd.Context(), itemId)
+	if err != nil {
+		return newItemError(summary, err)
+	}
+	if format != todo.OutputFormatTable {
+		return printChangedItems(cmd.OutOrStdout(), []todo.Item{item}, format)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s item %d: %s\n", pastVerb, item.GetId(), item.GetName())
+	return nil
+}
+
+func init() {
+	blockCmd.Flags().String("reason", "", "Why the item is blocked")
+	for _, command := range []*cobra.Command{reopenCmd, startCmd, blockCmd} {
+		addOutputFlag(command)
+	}
+
+	rootCmd.AddCommand(reopenCmd, startCmd, blockCmd)
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+	"strconv"
+	"strings"
+)
+
+// tagCmd represents the tag command
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage the tags of todo items.",
+	Long:  "Attach tags to todo items, detach them and list the tags in use.",
+}
+
+// tagAddCmd represents the tag add command
+var tagAddCmd = &cobra.Command{
+	Use:     "add <item id> <tag>",
+	Example: "todo tag add 1 backend",
+	Short:   "Attach a tag to a todo item.",
+	Long:    "Attach a tag to a todo item by ID. Tags are stored in lower case and cannot contain whitespace or commas.",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		itemId, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return newIdArgumentError("Unable to tag todo item.", args[0])
+		}
+
+		if err := app.TodoUseCase.Tag(cmd.Context(), itemId, args[1]); err != nil {
+			return newItemError("Unable to tag todo item.", err)
+		}
+		fmt.Fprintln(out, "Tagged item")
+		return nil
+	},
+}
+
+// tagRemoveCmd represents the tag rm command
+var tagRemoveCmd = &cobra.Command{
+	Use:     "rm <item id> <tag>",
+	Aliases: []string{"remove"},
+	Example: "todo tag rm 1 backend",
+	Short:   "Detach a tag from a todo item.",
+	Long:    "Detach a tag from a todo item by ID.",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		itemId, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return newIdArgumentError("Unable to untag todo item.", args[0])
+		}
+
+		err = app.TodoUseCase.Untag(cmd.Context(), itemId, args[1])
+		if errors.Is(err, todo.ErrNotFound) {
+			return newCommandError(
+				"Unable to untag todo item.", exitNotFound, "No todo item with ID %d has the tag '%s'.", itemId, args[1],
+			)
+		}
+		if err != nil {
+			return newItemError("Unable to untag todo item.", err)
+		}
+		fmt.Fprintln(out, "Untagged item")
+		return nil
+	},
+}
+
+// tagListCmd represents the tag list command
+var tagListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the tags in use.",
+	Long:  "Displays the tags which are attached to at least one todo item.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		tags, err := app.TodoUseCase.ListTags(cmd.Context())
+		if err != nil {
+			return newUnexpectedError("Unable to list tags.", err)
+		}
+		if len(tags) == 0 {
+			fmt.Fprintln(out, "No tags...")
+			return nil
+		}
+		fmt.Fprintln(out, strings.Join(tags, "\n"))
+		return nil
+	},
+}
+
+func init() {
+	tagCmd.AddCommand(tagAddCmd, tagRemoveCmd, tagListCmd)
+	rootCmd.AddCommand(tagCmd)
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package cmd
+
+import "io"
+
+// terminalWidth godoc
+//
+// Returns 0, the width of terminals is only read on Unix systems. Set COLUMNS to shorten long names elsewhere.
+func terminalWidth(out io.Writer) int {
+	return 0
+}
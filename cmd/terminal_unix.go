@@ -0,0 +1,24 @@
+//go:build unix
+
+package cmd
+
+import (
+	"golang.org/x/sys/unix"
+	"io"
+	"os"
+)
+
+// terminalWidth godoc
+//
+// Returns the number of columns of the terminal out, or 0 when out is not a terminal.
+func terminalWidth(out io.Writer) int {
+	file, ok := out.(*os.File)
+	if !ok {
+		return 0
+	}
+	size, err := unix.IoctlGetWinsize(int(file.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		return 0
+	}
+	return int(size.Col)
+}
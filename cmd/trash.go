@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/spf13/cobra"
+	"strconv"
+	"time"
+)
+
+// trashCmd represents the trash command
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage the items in the trash.",
+	Long: "List and delete the todo items moved to the trash by `todo remove`. Items in the trash are hidden from " +
+		"every other command until they are restored with `todo restore`.",
+}
+
+// trashListCmd represents the trash list command
+var trashListCmd = &cobra.Command{
+	Use:     "list",
+	Example: "todo trash list\ntodo trash list --output json",
+	Short:   "List the items in the trash.",
+	Long:    "Displays the todo items in the trash, most recently removed first.",
+	Args:    cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return newUsageError("Unable to list the trash.", "%v.", err)
+		}
+
+		items, err := app.TodoUseCase.ListTrash(cmd.Context())
+		if err != nil {
+			return newUnexpectedError("Unable to list the trash.", err)
+		}
+		if len(items) == 0 && format == todo.OutputFormatTable {
+			fmt.Fprintln(out, "The trash is empty")
+			return nil
+		}
+		options := getFormatOptions(out)
+		options.Columns = trashColumns
+		if err := printItems(out, items, false, format, options); err != nil {
+			return newUnexpectedError("Unable to print todo items.", err)
+		}
+		return nil
+	},
+}
+
+// trashColumns godoc
+//
+// Columns of the table printed by `trash list`.
+var trashColumns = []todo.ItemColumn{
+	todo.ItemColumnId, todo.ItemColumnName, todo.ItemColumnProject, todo.ItemColumnTags, todo.ItemColumnDeleted,
+}
+
+// trashEmptyCmd represents the trash empty command
+var trashEmptyCmd = &cobra.Command{
+	Use:     "empty",
+	Example: "todo trash empty\ntodo trash empty --older-than 30d",
+	Short:   "Delete the items in the trash for good.",
+	Long: "Delete for good every todo item in the trash, or only the items removed before --older-than. " +
+		"Use `todo undo` to bring them back.",
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		var before time.Time
+		if value, _ := cmd.Flags().GetString("older-than"); cmd.Flags().Changed("older-than") {
+			var err error
+			before, err = parsePastTime(value)
+			if err != nil {
+				return newUsageError(
+					"Unable to empty the trash.", "'%s' is not an age such as 30d or a date such as 2026-11-02.", value,
+				)
+			}
+		}
+
+		count, err := app.TodoUseCase.EmptyTrash(cmd.Context(), before)
+		if err != nil {
+			return newUnexpectedError("Unable to empty the trash.", err)
+		}
+		fmt.Fprintf(out, "Deleted %s from the trash\n", countItems(int(count)))
+		return nil
+	},
+}
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:     "restore <item id>",
+	Example: "todo remove 3\ntodo restore 3",
+	Short:   "Restore an item from the trash.",
+	Long: "Take a todo item out of the trash, along with the subtasks removed with it. Its parents in the trash are " +
+		"restored as well.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		itemId, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return newIdArgumentError("Unable to restore todo item.", args[0])
+		}
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return newUsageError("Unable to restore todo item.", "%v.", err)
+		}
+
+		restoredItems, err := app.TodoUseCase.Restore(cmd.Context(), itemId)
+		if err != nil {
+			return newItemError("Unable to restore todo item.", err)
+		}
+		if format != todo.OutputFormatTable {
+			return printChangedItems(out, restoredItems, format)
+		}
+		for _, item := range restoredItems {
+			fmt.Fprintf(out, "Restored item %d: %s\n", item.GetId(), item.GetName())
+		}
+		return nil
+	},
+}
+
+func init() {
+	addOutputFlag(trashListCmd)
+	addOutputFlag(restoreCmd)
+	trashEmptyCmd.Flags().String(
+		"older-than", "", "Only delete the items removed before this age or date, e.g. 30d, 2w or 2026-11-02",
+	)
+
+	trashCmd.AddCommand(trashListCmd, trashEmptyCmd)
+	rootCmd.AddCommand(trashCmd, restoreCmd)
+}
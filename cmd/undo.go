@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// undoCmd represents the undo command
+var undoCmd = &cobra.Command{
+	Use:     "undo",
+	Example: "todo remove 3\ntodo undo",
+	Short:   "Undo the last operation.",
+	Long: "Revert the last operation on todo items which is not undone, such as a create, update, complete or " +
+		"remove, including the bulk operations. The items are restored with their IDs and timestamps.\n\n" +
+		"Run undo again to revert earlier operations, see `todo history`.",
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		entry, err := app.TodoUseCase.Undo(cmd.Context())
+		if err != nil {
+			return newItemError("Unable to undo.", err)
+		}
+		fmt.Fprintf(out, "Undid: %s\n", entry.Summary)
+		printUnlinkedItems(out, entry.UnlinkedItemIds)
+		return nil
+	},
+}
+
+// redoCmd represents the redo command
+var redoCmd = &cobra.Command{
+	Use:     "redo",
+	Example: "todo undo\ntodo redo",
+	Short:   "Redo the last undone operation.",
+	Long: "Apply again the last operation reverted by `todo undo`. Operations can no longer be redone once another " +
+		"operation changes the todo items.",
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		entry, err := app.TodoUseCase.Redo(cmd.Context())
+		if err != nil {
+			return newItemError("Unable to redo.", err)
+		}
+		fmt.Fprintf(out, "Redid: %s\n", entry.Summary)
+		printUnlinkedItems(out, entry.UnlinkedItemIds)
+		return nil
+	},
+}
+
+// printUnlinkedItems godoc
+//
+// Prints the items which undo or redo could not put back in their project, as it was deleted, if any.
+func printUnlinkedItems(out io.Writer, itemIds []int64) {
+	if len(itemIds) == 0 {
+		return
+	}
+	if len(itemIds) == 1 {
+		fmt.Fprintf(out, "Item %d was not put back in its project, which was deleted.\n", itemIds[0])
+		return
+	}
+	ids := make([]string, 0, len(itemIds))
+	for _, itemId := range itemIds {
+		ids = append(ids, strconv.FormatInt(itemId, 10))
+	}
+	fmt.Fprintf(out, "Items %s were not put back in their project, which was deleted.\n", strings.Join(ids, ", "))
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd, redoCmd)
+}
@@ -2,41 +2,218 @@ package cmd
 
 import (
 	"fmt"
-	log "github.com/sirupsen/logrus"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
 	"github.com/spf13/cobra"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // updateCmd represents the update command
 var updateCmd = &cobra.Command{
-	Use:     `update <item id> "new name"`,
-	Example: `todo update 1 "new name"`,
-	Short:   "Update a todo item.",
-	Long:    `Update the name of a todo item.`,
-	Args:    cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		idToUpdate, err := strconv.ParseInt(args[0], 10, 64)
-		if err != nil {
-			fmt.Println("Unable to update todo item.")
-			fmt.Printf("'%s' is not a valid ID.\n", args[0])
-			return
+	Use:     `update <item id>... ["new name"]`,
+	Example: "todo update 1 \"new name\"\ntodo update 1 --due tomorrow\ntodo update 1 --due none\ntodo update 1 --priority urgent\ntodo update 1 --project none\ntodo update 1 --parent 12\ntodo update 1 --repeat none\ntodo update 1 --status cancelled\ntodo update 3 5 8-12 --priority high\ntodo update --filter 'tag:backend and status:open' --set priority=high --dry-run",
+	Short:   "Update todo items.",
+	Long: "Update the name, the due date, the priority, the project, the parent, the recurrence and/or the " +
+		"status of todo items, selected by ID, by range of IDs such as 8-12 or by --filter.\n\n" +
+		"Use `--due none` to remove the due date, `--project none` to remove the item from its project, " +
+		"`--parent none` to make a subtask a top level item and `--repeat none` to stop a recurring series. " +
+		"`--status` follows the status workflow: a done or cancelled item must be reopened before it moves on, " +
+		"and `--status done` completes the item as `todo complete` does.\n\n" +
+		"`--set field=value` is the same as `--field value` for the fields " + strings.Join(updateFields, ", ") +
+		". Without other changes the last argument is the new name, so `todo update 5 2026` renames item 5, while " +
+		"with them a new name which is a number is set with `--set name=<name>`.\n\n" +
+		"Several items are updated in a single transaction: when an item cannot be updated, no item is. " +
+		"Use --dry-run to print what would be updated.",
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		idArgs, name := args, ""
+		// The last argument is the new name unless it is an ID or a range of IDs and other fields are changed
+		last := len(args) - 1
+		if last >= 0 && (last > 0 || cmd.Flags().Changed("filter")) &&
+			(!isIdArgument(args[last]) || !hasUpdateFlags(cmd)) {
+			idArgs, name = args[:last], args[last]
+		}
+
+		if isBulkCommand(cmd, idArgs) {
+			options, err := getBulkOptions(cmd, idArgs, "Unable to update todo items.")
+			if err != nil {
+				return err
+			}
+			changes, err := getItemChanges(cmd, name, "Unable to update todo items.")
+			if err != nil {
+				return err
+			}
+			summary, err := app.TodoUseCase.UpdateItems(cmd.Context(), options, changes)
+			if err != nil {
+				return newItemError("Unable to update todo items.", err)
+			}
+			return printBulkSummary(out, summary, options.DryRun, updateVerbs, "Unable to update todo items.")
 		}
-		newName := args[1]
 
-		updatedItemId, err := app.TodoUseCase.Update(idToUpdate, newName)
+		idToUpdate, err := strconv.ParseInt(idArgs[0], 10, 64)
+		if err != nil {
+			return newIdArgumentError("Unable to update todo item.", idArgs[0])
+		}
+		format, err := getOutputFormat(cmd)
+		if err != nil {
+			return newUsageError("Unable to update todo item.", "%v.", err)
+		}
+		changes, err := getItemChanges(cmd, name, "Unable to update todo item.")
 		if err != nil {
-			log.Errorf("updateCmd: %v", err)
-			fmt.Println("An error occurred while updating the todo item")
-			return
+			return err
 		}
-		if updatedItemId == -1 {
-			fmt.Printf("No todo item exists with ID %d\n", idToUpdate)
-			return
+
+		if err := app.TodoUseCase.Update(cmd.Context(), idToUpdate, changes); err != nil {
+			return newItemError("Unable to update todo item.", err)
 		}
-		fmt.Println("Updated item")
+		if format != todo.OutputFormatTable {
+			details, err := app.TodoUseCase.Get(cmd.Context(), idToUpdate)
+			if err != nil {
+				return newItemError("Unable to print todo item.", err)
+			}
+			return printChangedItems(out, []todo.Item{details.Item}, format)
+		}
+		fmt.Fprintln(out, "Updated item")
+		return nil
 	},
 }
 
+// updateVerbs godoc
+//
+// Words describing what `update` does to each item.
+var updateVerbs = bulkVerbs{present: "update", past: "Updated"}
+
+// updateFields godoc
+//
+// Fields which `update --set field=value` accepts, each the name of a flag of update except name.
+var updateFields = []string{"name", "due", "priority", "project", "parent", "repeat", "status"}
+
+// hasUpdateFlags godoc
+//
+// Returns true when a field is changed with its flag or with `--set`.
+func hasUpdateFlags(cmd *cobra.Command) bool {
+	for _, field := range updateFields {
+		if field != "name" && cmd.Flags().Changed(field) {
+			return true
+		}
+	}
+	return cmd.Flags().Changed("set")
+}
+
+// getUpdateValues godoc
+//
+// Reads the values of the fields set with `--set field=value` and with the flag of each field. A field given with
+// both keeps the value of `--set`.
+//
+// Returns nil and error when a `--set` value is not a known field followed by = and a value.
+//
+// Returns the values by field and nil on success.
+func getUpdateValues(cmd *cobra.Command) (map[string]string, error) {
+	values := map[string]string{}
+	for _, field := range updateFields {
+		if field != "name" && cmd.Flags().Changed(field) {
+			values[field], _ = cmd.Flags().GetString(field)
+		}
+	}
+	sets, _ := cmd.Flags().GetStringArray("set")
+	for _, set := range sets {
+		field, value, ok := strings.Cut(set, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !ok || !slices.Contains(updateFields, field) {
+			return nil, fmt.Errorf("'%s' is not a field=value pair of %s", set, strings.Join(updateFields, ", "))
+		}
+		values[field] = value
+	}
+	return values, nil
+}
+
+// getItemChanges godoc
+//
+// Reads the changes of `update` from its flags and from the new name, which is empty when it is not changed.
+//
+// Returns a commandError when a value is invalid or there are no changes.
+//
+// Returns the changes and nil on success.
+func getItemChanges(cmd *cobra.Command, name string, summary string) (todo.ItemChanges, error) {
+	var changes todo.ItemChanges
+	values, err := getUpdateValues(cmd)
+	if err != nil {
+		return changes, newUsageError(summary, "%v.", err)
+	}
+	if name != "" {
+		changes.Name = &name
+	}
+	if newName, ok := values["name"]; ok {
+		changes.Name = &newName
+	}
+	if dueValue, ok := values["due"]; ok {
+		var dueAt time.Time
+		if dueValue != noDueDate {
+			dueAt, err = dateParser.ParseDeadline(dueValue)
+			if err != nil {
+				return changes, newUsageError(summary, "%v", err)
+			}
+		}
+		changes.DueAt = &dueAt
+	}
+	if priorityValue, ok := values["priority"]; ok {
+		priority, err := todo.ParsePriority(priorityValue)
+		if err != nil {
+			return changes, newUsageError(
+				summary, "'%s' is not a valid priority, expected %s.", priorityValue, priorityFlagValues,
+			)
+		}
+		changes.Priority = &priority
+	}
+	if projectName, ok := values["project"]; ok {
+		projectId, err := resolveProjectId(cmd.Context(), summary, projectName, false)
+		if err != nil {
+			return changes, err
+		}
+		changes.ProjectId = &projectId
+	}
+	if parentValue, ok := values["parent"]; ok {
+		parentId, err := parseParentId(parentValue)
+		if err != nil {
+			return changes, newCommandError(summary, exitInvalidId, "%v.", err)
+		}
+		changes.ParentId = &parentId
+	}
+	if repeatValue, ok := values["repeat"]; ok {
+		if repeatValue == noRecurrence {
+			repeatValue = ""
+		}
+		changes.Recurrence = &repeatValue
+	}
+	if statusValue, ok := values["status"]; ok {
+		status, err := todo.ParseStatus(statusValue)
+		if err != nil {
+			return changes, newUsageError(
+				summary, "'%s' is not a valid status, expected %s.", statusValue, statusFlagValues,
+			)
+		}
+		changes.Status = &status
+	}
+	if changes.IsEmpty() {
+		return changes, newUsageError(
+			summary, "Provide a new name, a due date, a priority, a project, a parent, a recurrence and/or a status.",
+		)
+	}
+	return changes, nil
+}
+
 func init() {
+	updateCmd.Flags().String("due", "", "New due date of the item, "+dateFlagUsage+", or \"none\" to remove it")
+	updateCmd.Flags().String("priority", "", "New priority of the item: "+priorityFlagValues)
+	updateCmd.Flags().String("project", "", "Name of the project which owns the item, or \"none\"")
+	updateCmd.Flags().String("parent", "", "ID of the item which the item is a subtask of, or \"none\"")
+	updateCmd.Flags().String("repeat", "", "Recurrence of the item, "+recurrenceFlagUsage+", or \"none\" to stop it")
+	updateCmd.Flags().String("status", "", "New status of the item: "+statusFlagValues)
+	updateCmd.Flags().StringArray("set", nil, "Set a field, e.g. priority=high, can be repeated")
+	addBulkFlags(updateCmd, updateVerbs)
+	addOutputFlag(updateCmd)
 	rootCmd.AddCommand(updateCmd)
 }
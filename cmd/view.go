@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/rykeroc/todo-cli/internal/modules/view"
+	"github.com/spf13/cobra"
+)
+
+// viewCmd represents the view command
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Manage saved views.",
+	Long: "Save, list and delete views, named filter expressions, sort orders and columns which `todo list --view` " +
+		"shows.\n\n" +
+		"The built-in views today, overdue, upcoming, completed and stale are also commands, e.g. `todo today`. " +
+		"The number of days of the stale view is set with `todo config set stale_days <days>`.",
+}
+
+// viewSaveCmd represents the view save command
+var viewSaveCmd = &cobra.Command{
+	Use: "save <name>",
+	Example: "todo view save mine --filter 'status:open and tag:backend' --sort due --columns id,name,due\n" +
+		"todo list --view mine",
+	Short: "Save a view.",
+	Long: "Save a view with a filter expression, a sort order and the columns of the table, see `todo list --help`. " +
+		"Saving a view with the name of a saved view replaces it.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		filter, _ := cmd.Flags().GetString("filter")
+		sort, _ := cmd.Flags().GetString("sort")
+		columns, _ := cmd.Flags().GetString("columns")
+		savedView, err := app.ViewUseCase.Save(cmd.Context(), args[0], filter, sort, columns)
+		if err != nil {
+			return newViewError("Unable to save view.", args[0], err)
+		}
+		fmt.Fprintf(out, "Saved view: %s\n", savedView.GetName())
+		return nil
+	},
+}
+
+// viewListCmd represents the view list command
+var viewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List views.",
+	Long:  "Displays the built-in views followed by the saved views.",
+	Args:  cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		views, err := app.ViewUseCase.List(cmd.Context())
+		if err != nil {
+			return newUnexpectedError("Unable to list views.", err)
+		}
+		tabularList, err := view.FormatViewTable(views)
+		if err != nil {
+			return newUnexpectedError("Unable to print views.", err)
+		}
+		fmt.Fprintln(out, tabularList)
+		return nil
+	},
+}
+
+// viewDeleteCmd represents the view delete command
+var viewDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Example: "todo view delete mine",
+	Short:   "Delete a saved view.",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		if err := app.ViewUseCase.Delete(cmd.Context(), args[0]); err != nil {
+			return newViewError("Unable to delete view.", args[0], err)
+		}
+		fmt.Fprintln(out, "Deleted view")
+		return nil
+	},
+}
+
+// newViewError godoc
+//
+// Maps an error returned by the view use case for the view name to a commandError with a user message and an exit
+// code.
+func newViewError(summary string, name string, err error) error {
+	commandErr := &commandError{summary: summary, err: err}
+	var filterErr *todo.FilterError
+	var validationErr *todo.ValidationError
+	switch {
+	case errors.Is(err, view.ErrNotFound):
+		commandErr.code = exitNotFound
+		commandErr.reason = fmt.Sprintf("No view exists with name '%s'.", name)
+	case errors.Is(err, view.ErrInvalidName):
+		commandErr.code = exitValidation
+		commandErr.reason = fmt.Sprintf(
+			"Invalid view name '%s', use lower case letters, digits, dashes and underscores.", name,
+		)
+	case errors.Is(err, view.ErrBuiltIn):
+		commandErr.code = exitConflict
+		commandErr.reason = fmt.Sprintf("'%s' is a built-in view, use another name.", name)
+	case errors.As(err, &filterErr):
+		commandErr.code = exitValidation
+		commandErr.reason = describeFilterError(filterErr)
+	case errors.As(err, &validationErr):
+		commandErr.code = exitValidation
+		commandErr.reason = sentence(validationErr.Error())
+	default:
+		return newUnexpectedError(summary, err)
+	}
+	return commandErr
+}
+
+// newBuiltInViewCmd godoc
+//
+// Returns the command which lists the items of a built-in view, e.g. `todo today`.
+func newBuiltInViewCmd(builtIn view.View) *cobra.Command {
+	command := &cobra.Command{
+		Use:     builtIn.GetName(),
+		Example: fmt.Sprintf("todo %s\ntodo %s --filter tag:backend", builtIn.GetName(), builtIn.GetName()),
+		Short:   builtIn.GetDescription() + ".",
+		Long: fmt.Sprintf(
+			"%s, the same as `todo list --view %s`.\n\nUse --filter to narrow the items down further.",
+			builtIn.GetDescription(), builtIn.GetName(),
+		),
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(cmd, builtIn.GetName())
+		},
+	}
+	command.Flags().String("filter", "", "Only show items also matching a filter expression")
+	command.Flags().Bool("tree", false, "List subtasks below their parent")
+	addTableFlags(command)
+	addOutputFlag(command)
+	return command
+}
+
+func init() {
+	viewSaveCmd.Flags().String("filter", "", "Filter expression of the view, see 'todo list --help'")
+	viewSaveCmd.Flags().String(
+		"sort", "", "Sort order of the view, fields of "+joinValues(todo.SortOrders)+" each followed by :asc or :desc",
+	)
+	viewSaveCmd.Flags().String("columns", "", "Columns of the table of the view: "+joinValues(todo.ItemColumns))
+
+	viewCmd.AddCommand(viewSaveCmd, viewListCmd, viewDeleteCmd)
+	rootCmd.AddCommand(viewCmd)
+
+	// Only the names and descriptions of the built-in views are used here, they do not depend on the configuration
+	for _, builtIn := range view.NewDomain().BuiltInViews() {
+		rootCmd.AddCommand(newBuiltInViewCmd(builtIn))
+	}
+}
@@ -0,0 +1,52 @@
+package clock
+
+import "time"
+
+// Clock godoc
+//
+// An interface that provides the current time, so that time dependent behaviour can be tested deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock godoc
+//
+// A Clock which returns the current system time.
+type systemClock struct{}
+
+// NewSystemClock godoc
+//
+// Creates a new Clock which returns the current system time.
+func NewSystemClock() Clock {
+	return &systemClock{}
+}
+
+// Now godoc
+//
+// Returns the current system time.
+func (c *systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// fixedClock godoc
+//
+// A Clock which always returns the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+// NewFixedClock godoc
+//
+// Creates a new Clock which always returns the passed in time.
+func NewFixedClock(now time.Time) Clock {
+	return &fixedClock{
+		now: now,
+	}
+}
+
+// Now godoc
+//
+// Returns the fixed time of the clock.
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
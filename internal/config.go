@@ -2,8 +2,11 @@ package internal
 
 import (
 	"fmt"
+	log "github.com/sirupsen/logrus"
 	"os"
 	"path"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -47,3 +50,27 @@ func GetAppConfigDir() (string, error) {
 	// A different error occurred
 	return "", fmt.Errorf("GetAppConfigDir: %v", err)
 }
+
+// ParseLogLevel godoc
+//
+// Converts a log level name, e.g. debug, or a log level number into a log level.
+//
+// Returns DefaultLogLevel and error when the value is not a log level.
+//
+// Returns the log level and nil on success.
+func ParseLogLevel(value string) (log.Level, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if number, err := strconv.ParseInt(normalized, 0, 64); err == nil {
+		for _, level := range log.AllLevels {
+			if log.Level(number) == level {
+				return level, nil
+			}
+		}
+		return DefaultLogLevel, fmt.Errorf("ParseLogLevel: '%s' is not a log level", value)
+	}
+	level, err := log.ParseLevel(normalized)
+	if err != nil {
+		return DefaultLogLevel, fmt.Errorf("ParseLogLevel: %v", err)
+	}
+	return level, nil
+}
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path"
+)
+
+// ConfigFilename godoc
+//
+// Name of the configuration file in the app configuration directory.
+const ConfigFilename = "config.yaml"
+
+// EnvFilename godoc
+//
+// Name of the file, in the app configuration directory, which sets environment variables such as TODO_DB.
+const EnvFilename = ".env"
+
+// ErrUnknownConfigKey godoc
+//
+// Returned when a configuration key is not one of ConfigKeys.
+var ErrUnknownConfigKey = errors.New("unknown config key")
+
+// ConfigKeys godoc
+//
+// Lists the keys of the configuration file in the order they are documented.
+var ConfigKeys = []string{"output", "sort", "date_format", "color", "default_project", "log_level", "stale_days"}
+
+// Config godoc
+//
+// Defines the content of the configuration file. Empty values are unset and fall back to the defaults of the app.
+type Config struct {
+	// Output is the output format of the commands which print items, such as `list` and `show`.
+	Output string `yaml:"output,omitempty"`
+	// Sort is the order of the items of `list`.
+	Sort string `yaml:"sort,omitempty"`
+	// DateFormat is the Go time layout of the dates in tables.
+	DateFormat string `yaml:"date_format,omitempty"`
+	// Color is when tables are colored: auto, always or never.
+	Color string `yaml:"color,omitempty"`
+	// DefaultProject is the project of the items created without `--project`.
+	DefaultProject string `yaml:"default_project,omitempty"`
+	// LogLevel is the name of the log level, overridden by TODO_LOG_LEVEL.
+	LogLevel string `yaml:"log_level,omitempty"`
+	// StaleDays is the number of days without an update after which the stale view lists an open item.
+	StaleDays string `yaml:"stale_days,omitempty"`
+}
+
+// Get godoc
+//
+// Get the value of a configuration key.
+//
+// Returns empty string and error wrapping ErrUnknownConfigKey when the key is not one of ConfigKeys.
+//
+// Returns the value, empty when unset, and nil on success.
+func (c *Config) Get(key string) (string, error) {
+	field, err := c.field(key)
+	if err != nil {
+		return "", fmt.Errorf("Get: %w", err)
+	}
+	return *field, nil
+}
+
+// Set godoc
+//
+// Set the value of a configuration key. An empty value unsets the key.
+//
+// Returns error wrapping ErrUnknownConfigKey when the key is not one of ConfigKeys, nil otherwise.
+func (c *Config) Set(key string, value string) error {
+	field, err := c.field(key)
+	if err != nil {
+		return fmt.Errorf("Set: %w", err)
+	}
+	*field = value
+	return nil
+}
+
+// field godoc
+//
+// Returns a pointer to the field of a configuration key.
+func (c *Config) field(key string) (*string, error) {
+	fields := map[string]*string{
+		"output":          &c.Output,
+		"sort":            &c.Sort,
+		"date_format":     &c.DateFormat,
+		"color":           &c.Color,
+		"default_project": &c.DefaultProject,
+		"log_level":       &c.LogLevel,
+		"stale_days":      &c.StaleDays,
+	}
+	field, ok := fields[key]
+	if !ok {
+		return nil, fmt.Errorf("%w '%s'", ErrUnknownConfigKey, key)
+	}
+	return field, nil
+}
+
+// GetConfigPath godoc
+//
+// Get the path of the configuration file in the app configuration directory.
+//
+// Returns empty string and error on error.
+//
+// Returns the path of the configuration file and nil on success.
+func GetConfigPath() (string, error) {
+	appConfDir, err := GetAppConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("GetConfigPath: %v", err)
+	}
+	return path.Join(appConfDir, ConfigFilename), nil
+}
+
+// LoadConfig godoc
+//
+// Reads the configuration file at configPath. A missing file has every key unset.
+//
+// Returns an empty Config and error when the file cannot be read or is not valid YAML.
+//
+// Returns the configuration and nil on success.
+func LoadConfig(configPath string) (Config, error) {
+	var config Config
+	content, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("LoadConfig: %v", err)
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return Config{}, fmt.Errorf("LoadConfig: %s is invalid: %v", configPath, err)
+	}
+	return config, nil
+}
+
+// SaveConfig godoc
+//
+// Replaces the configuration file at configPath with the configuration.
+//
+// Returns error on error, nil otherwise.
+func SaveConfig(configPath string, config Config) error {
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("SaveConfig: %v", err)
+	}
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		return fmt.Errorf("SaveConfig: %v", err)
+	}
+	return nil
+}
+
+// LoadEnvFile godoc
+//
+// Sets the environment variables of the .env file in the app configuration directory. Variables which are already
+// set keep their value, and a missing file is ignored.
+//
+// Returns error on error, nil otherwise.
+func LoadEnvFile() error {
+	appConfDir, err := GetAppConfigDir()
+	if err != nil {
+		return fmt.Errorf("LoadEnvFile: %v", err)
+	}
+	envPath := path.Join(appConfDir, EnvFilename)
+	if _, err := os.Stat(envPath); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err := godotenv.Load(envPath); err != nil {
+		return fmt.Errorf("LoadEnvFile: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfig_GetSet(t *testing.T) {
+	t.Run("should get and set every key", func(t *testing.T) {
+		var config Config
+		for _, key := range ConfigKeys {
+			assert.NoError(t, config.Set(key, "value of "+key))
+
+			value, err := config.Get(key)
+			assert.NoError(t, err)
+			assert.Equal(t, "value of "+key, value)
+		}
+	})
+
+	t.Run("should return ErrUnknownConfigKey for an unknown key", func(t *testing.T) {
+		var config Config
+
+		_, err := config.Get("editor")
+		assert.ErrorIs(t, err, ErrUnknownConfigKey)
+		assert.ErrorIs(t, config.Set("editor", "vim"), ErrUnknownConfigKey)
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("should read the values saved with SaveConfig", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), ConfigFilename)
+		config := Config{Output: "json", DateFormat: "02 Jan 2006", LogLevel: "debug"}
+
+		assert.NoError(t, SaveConfig(configPath, config))
+		result, err := LoadConfig(configPath)
+
+		assert.NoError(t, err)
+		assert.Equal(t, config, result)
+	})
+
+	t.Run("should leave every key unset when the file does not exist", func(t *testing.T) {
+		result, err := LoadConfig(filepath.Join(t.TempDir(), ConfigFilename))
+
+		assert.NoError(t, err)
+		assert.Equal(t, Config{}, result)
+	})
+
+	t.Run("should return error when the file is not valid YAML", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), ConfigFilename)
+		assert.NoError(t, os.WriteFile(configPath, []byte("output: [json"), 0644))
+
+		_, err := LoadConfig(configPath)
+
+		assert.ErrorContains(t, err, "is invalid")
+	})
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+	t.Setenv("AppData", configDir)
+	t.Setenv("TODO_TEST_SET", "from environment")
+	appConfDir, err := GetAppConfigDir()
+	assert.NoError(t, err)
+
+	t.Run("should ignore a missing file", func(t *testing.T) {
+		assert.NoError(t, LoadEnvFile())
+	})
+
+	t.Run("should set the variables which are not set yet", func(t *testing.T) {
+		content := "TODO_TEST_UNSET=from file\nTODO_TEST_SET=from file\n"
+		assert.NoError(t, os.WriteFile(filepath.Join(appConfDir, EnvFilename), []byte(content), 0644))
+		t.Cleanup(func() { _ = os.Unsetenv("TODO_TEST_UNSET") })
+
+		assert.NoError(t, LoadEnvFile())
+
+		assert.Equal(t, "from file", os.Getenv("TODO_TEST_UNSET"))
+		assert.Equal(t, "from environment", os.Getenv("TODO_TEST_SET"))
+	})
+}
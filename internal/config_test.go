@@ -0,0 +1,28 @@
+package internal
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	testCases := map[string]log.Level{
+		"debug":   log.DebugLevel,
+		" INFO ":  log.InfoLevel,
+		"warning": log.WarnLevel,
+		"1":       log.FatalLevel,
+		"5":       log.DebugLevel,
+	}
+	for value, expected := range testCases {
+		level, err := ParseLogLevel(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, level, value)
+	}
+
+	for _, value := range []string{"verbose", "42", ""} {
+		level, err := ParseLogLevel(value)
+		assert.Error(t, err, value)
+		assert.Equal(t, log.Level(DefaultLogLevel), level, value)
+	}
+}
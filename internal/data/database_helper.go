@@ -1,6 +1,7 @@
 package data
 
 import (
+	"context"
 	"database/sql"
 	"embed"
 	"errors"
@@ -12,15 +13,16 @@ import (
 	"github.com/rykeroc/todo-cli/internal"
 	log "github.com/sirupsen/logrus"
 	"os"
+	"path/filepath"
 )
 
 //go:embed migrations/*.sql
 var migrationsFs embed.FS
 
 type SqlDatabaseHelper interface {
-	Connect() error
+	Connect(context.Context) error
 	Close() error
-	InitializeSchema() error
+	InitializeSchema(context.Context) error
 	GetDatabase() *sql.DB
 }
 
@@ -38,7 +40,7 @@ func NewSqliteDatabaseHelper(databaseFilename string) SqlDatabaseHelper {
 	}
 }
 
-func (s *SqliteDatabaseHelper) Connect() error {
+func (s *SqliteDatabaseHelper) Connect(ctx context.Context) error {
 	if len(s.DriverName) == 0 {
 		return fmt.Errorf("Connect: invalid database config: Missing driver name")
 	}
@@ -68,7 +70,7 @@ func (s *SqliteDatabaseHelper) Connect() error {
 		)
 	}
 
-	err := s.db.Ping()
+	err := s.db.PingContext(ctx)
 	if err != nil {
 		return fmt.Errorf("Connect: %v", err)
 	}
@@ -89,38 +91,72 @@ func (s *SqliteDatabaseHelper) Close() error {
 	return nil
 }
 
-func (s *SqliteDatabaseHelper) InitializeSchema() error {
+func (s *SqliteDatabaseHelper) InitializeSchema(ctx context.Context) error {
 	if s.db == nil {
 		return fmt.Errorf("InitializeSchema: db is not initialized")
 	}
+	if err := RunMigrations(ctx, s.db, s.DatabaseFilename); err != nil {
+		return fmt.Errorf("InitializeSchema: %v", err)
+	}
+
+	return nil
+}
+
+// RunMigrations godoc
+//
+// Runs all embedded `up` migrations against the passed in SQLite database.
+//
+// When the context is done, the migration which is running is finished and the remaining ones are skipped.
+//
+// Returns error on error, including the error of the context when it is done before every migration ran.
+//
+// Returns nil otherwise.
+func RunMigrations(ctx context.Context, db *sql.DB, databaseName string) error {
 	migrationEntries, err := iofs.New(migrationsFs, "migrations")
 	if err != nil {
-		return fmt.Errorf("InitializeSchema: %v", err)
+		return fmt.Errorf("RunMigrations: %v", err)
 	}
 
-	driver, err := sqlite.WithInstance(s.db, &sqlite.Config{})
+	driver, err := sqlite.WithInstance(db, &sqlite.Config{})
 	if err != nil {
-		return fmt.Errorf("initializeDb: Failed to get sqlite driver instance: %v", err)
+		return fmt.Errorf("RunMigrations: Failed to get sqlite driver instance: %v", err)
 	}
 
 	m, err := migrate.NewWithInstance(
 		"iofs",
 		migrationEntries,
-		s.DatabaseFilename,
+		databaseName,
 		driver,
 	)
 	if err != nil {
-		return fmt.Errorf("InitializeSchema: Failed to get migrate instance: %v", err)
+		return fmt.Errorf("RunMigrations: Failed to get migrate instance: %v", err)
 	}
 
-	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return fmt.Errorf("InitializeSchema: Failed run migrate up: %v", err)
+	// migrate does not accept a context, stop it gracefully instead when the context is done
+	upErr := make(chan error, 1)
+	go func() {
+		upErr <- m.Up()
+	}()
+	select {
+	case err = <-upErr:
+	case <-ctx.Done():
+		m.GracefulStop <- true
+		if err = <-upErr; err == nil || errors.Is(err, migrate.ErrNoChange) {
+			err = ctx.Err()
+		}
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("RunMigrations: Failed run migrate up: %v", err)
 	}
 
 	return nil
 }
 
 func getDatabasePath(databaseName string) (string, error) {
+	// Absolute paths come from `--db`, `TODO_DB` or a profile and are used as they are
+	if filepath.IsAbs(databaseName) {
+		return databaseName, nil
+	}
 	confDir, err := internal.GetAppConfigDir()
 	if err != nil {
 		return "", fmt.Errorf("getDatabasePath: %v", err)
@@ -129,7 +165,8 @@ func getDatabasePath(databaseName string) (string, error) {
 }
 
 func getDataSourceName(path string) string {
-	return fmt.Sprintf("file://%s", path)
+	// Foreign keys are disabled by default in SQLite and are required for cascading deletes
+	return fmt.Sprintf("file://%s?_foreign_keys=on", path)
 }
 
 func ensureDbIsCreated(dataSourceName string) error {
@@ -138,6 +175,9 @@ func ensureDbIsCreated(dataSourceName string) error {
 		return nil
 	}
 	if os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(dataSourceName), 0755); err != nil {
+			return fmt.Errorf("ensureDbIsCreated: %v", err)
+		}
 		_, err := os.Create(dataSourceName)
 		if err != nil {
 			return fmt.Errorf("ensureDbIsCreated: %v", err)
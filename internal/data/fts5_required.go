@@ -0,0 +1,7 @@
+//go:build !sqlite_fts5
+
+package data
+
+// The todos_fts index of the migrations uses the FTS5 module of SQLite, which go-sqlite3 only compiles with the
+// sqlite_fts5 build tag. Without it every command would fail to open the database, so the build fails instead.
+var _ = build_with_tags_sqlite_fts5
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS tags (
+                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                         name TEXT NOT NULL UNIQUE
+);
+CREATE TABLE IF NOT EXISTS todo_tags (
+                         todoId INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+                         tagId INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+                         PRIMARY KEY (todoId, tagId)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE todos
+DROP COLUMN projectId;
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS projects (
+                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                         name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+                         archivedAt INTEGER NULL,
+                         updatedAt INTEGER NOT NULL,
+                         createdAt INTEGER NOT NULL
+);
+ALTER TABLE todos
+ADD COLUMN projectId INTEGER NULL REFERENCES projects (id) ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE todos
+DROP COLUMN parentId;
@@ -0,0 +1,2 @@
+ALTER TABLE todos
+ADD COLUMN parentId INTEGER NULL REFERENCES todos (id) ON DELETE CASCADE;
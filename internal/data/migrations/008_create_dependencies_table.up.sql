@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS todo_dependencies (
+                         todoId INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+                         dependsOnId INTEGER NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+                         PRIMARY KEY (todoId, dependsOnId),
+                         CHECK (todoId != dependsOnId)
+);
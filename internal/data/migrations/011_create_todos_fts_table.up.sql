@@ -0,0 +1,27 @@
+-- FTS5 ranks matches with bm25(), it requires the sqlite_fts5 build tag of go-sqlite3
+CREATE VIRTUAL TABLE IF NOT EXISTS todos_fts USING fts5(
+    displayName,
+    description,
+    content='todos',
+    content_rowid='id',
+    tokenize='unicode61'
+);
+
+-- Keep the index of the names and notes of the items in sync with the todos table
+CREATE TRIGGER IF NOT EXISTS todos_fts_after_insert AFTER INSERT ON todos BEGIN
+    INSERT INTO todos_fts (rowid, displayName, description) VALUES (new.id, new.displayName, new.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS todos_fts_after_update AFTER UPDATE OF displayName, description ON todos BEGIN
+    INSERT INTO todos_fts (todos_fts, rowid, displayName, description)
+    VALUES ('delete', old.id, old.displayName, old.description);
+    INSERT INTO todos_fts (rowid, displayName, description) VALUES (new.id, new.displayName, new.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS todos_fts_after_delete AFTER DELETE ON todos BEGIN
+    INSERT INTO todos_fts (todos_fts, rowid, displayName, description)
+    VALUES ('delete', old.id, old.displayName, old.description);
+END;
+
+-- Index the existing items
+INSERT INTO todos_fts (todos_fts) VALUES ('rebuild');
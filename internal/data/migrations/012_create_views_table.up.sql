@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS views (
+                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                         name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+                         filterExpression TEXT NOT NULL DEFAULT '',
+                         sortOrder TEXT NOT NULL DEFAULT '',
+                         itemColumns TEXT NOT NULL DEFAULT '',
+                         updatedAt INTEGER NOT NULL,
+                         createdAt INTEGER NOT NULL
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS journal (
+                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                         summary TEXT NOT NULL,
+                         changes TEXT NOT NULL,
+                         isUndone INTEGER NOT NULL DEFAULT 0,
+                         createdAt INTEGER NOT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE todos
+ADD COLUMN deletedAt INTEGER NULL;
@@ -0,0 +1,11 @@
+ALTER TABLE todos
+ADD COLUMN isCompleted INTEGER NOT NULL DEFAULT 0;
+
+-- Cancelled items are closed as well
+UPDATE todos SET isCompleted = 1 WHERE status IN ('done', 'cancelled');
+
+ALTER TABLE todos
+DROP COLUMN statusReason;
+
+ALTER TABLE todos
+DROP COLUMN status;
@@ -0,0 +1,12 @@
+-- Completed items are done, every other item is still to do
+ALTER TABLE todos
+ADD COLUMN status TEXT NOT NULL DEFAULT 'todo';
+
+UPDATE todos SET status = 'done' WHERE isCompleted = 1;
+
+-- Why an item is blocked or waiting
+ALTER TABLE todos
+ADD COLUMN statusReason TEXT NULL;
+
+ALTER TABLE todos
+DROP COLUMN isCompleted;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS item_events (
+                         id INTEGER PRIMARY KEY AUTOINCREMENT,
+                         itemId INTEGER NOT NULL,
+                         field TEXT NOT NULL,
+                         oldValue TEXT NOT NULL DEFAULT '',
+                         newValue TEXT NOT NULL DEFAULT '',
+                         origin TEXT NOT NULL DEFAULT '',
+                         createdAt INTEGER NOT NULL
+);
+
+-- Events outlive the items they belong to, so deleted items keep their history
+CREATE INDEX IF NOT EXISTS item_events_itemId ON item_events (itemId, createdAt);
+CREATE INDEX IF NOT EXISTS item_events_createdAt ON item_events (createdAt);
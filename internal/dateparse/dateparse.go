@@ -0,0 +1,375 @@
+package dateparse
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parser godoc
+//
+// Parses absolute, relative and natural-language date expressions relative to the time of a clock.Clock.
+//
+// Supported expressions (case-insensitive):
+//
+//   - Keywords: `now`, `today`, `tomorrow`, `yesterday`
+//   - Weekdays: `fri`, `friday`, `this fri` (the next occurrence, including today), `next fri` (strictly after today)
+//   - Periods: `next week`, `next month`, `next year`, `this week` (the Monday starting the current week)
+//   - Month days: `nov 2`, `november 2 2026`
+//   - Dates: `2026-11-02`, `2026-11-02 15:04`, `2026-11-02T15:04:05Z07:00`
+//   - Offsets: `+3d`, `-2w`, `+4h`, `+30m`, `+1mo`, `+1y`, `in 3 days`, `2 weeks ago`
+//
+// Day expressions accept an optional time of day, e.g. `tomorrow 5pm`, `next fri at 17:30`, `today noon`.
+type Parser struct {
+	clock clock.Clock
+}
+
+// NewParser godoc
+//
+// Creates a new Parser which resolves expressions relative to the passed in clock.
+func NewParser(c clock.Clock) *Parser {
+	return &Parser{
+		clock: c,
+	}
+}
+
+// Parse godoc
+//
+// Parses a date expression.
+//
+// Expressions without a time of day resolve to the start of the day.
+//
+// Returns the zero time and error when the expression is not supported.
+//
+// Returns the resolved time and nil on success.
+func (p *Parser) Parse(expr string) (time.Time, error) {
+	result, _, err := p.parse(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return result, nil
+}
+
+// ParseDeadline godoc
+//
+// Parses a date expression used as a deadline.
+//
+// Expressions without a time of day resolve to the end of the day, so that `--due today` is not already overdue.
+//
+// Returns the zero time and error when the expression is not supported.
+//
+// Returns the resolved time and nil on success.
+func (p *Parser) ParseDeadline(expr string) (time.Time, error) {
+	result, dateOnly, err := p.parse(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if dateOnly {
+		result = result.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	return result, nil
+}
+
+// absoluteLayouts godoc
+//
+// Layouts which are matched against the whole expression.
+var absoluteLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+}
+
+// offsetPattern godoc
+//
+// Matches shorthand offsets such as `+3d` or `-2w`.
+var offsetPattern = regexp.MustCompile(`^([+-])(\d+)\s*([a-z]+)$`)
+
+// clockPattern godoc
+//
+// Matches a time of day such as `5pm`, `5:30pm`, `17:00` or `17:00:30`.
+var clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+// parse godoc
+//
+// Parses a date expression.
+//
+// Returns the resolved time, whether the expression only specified a date, and nil on success.
+//
+// Returns the zero time, false and error when the expression is not supported.
+func (p *Parser) parse(expr string) (time.Time, bool, error) {
+	normalized := strings.ToLower(strings.TrimSpace(expr))
+	if normalized == "" {
+		return time.Time{}, false, fmt.Errorf("date expression is empty")
+	}
+	now := p.clock.Now()
+
+	for _, layout := range absoluteLayouts {
+		if result, err := time.ParseInLocation(layout, strings.TrimSpace(expr), now.Location()); err == nil {
+			return result, false, nil
+		}
+	}
+
+	if normalized == "now" {
+		return now, false, nil
+	}
+
+	if match := offsetPattern.FindStringSubmatch(normalized); match != nil {
+		amount, _ := strconv.Atoi(match[2])
+		if match[1] == "-" {
+			amount = -amount
+		}
+		result, err := addUnits(now, amount, match[3])
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("'%s' is not a valid date: %v", expr, err)
+		}
+		return result, false, nil
+	}
+
+	fields := strings.Fields(normalized)
+	if result, ok, err := parseSpelledOffset(now, fields); ok {
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("'%s' is not a valid date: %v", expr, err)
+		}
+		return result, false, nil
+	}
+
+	day, rest, ok := parseDay(now, fields)
+	if !ok {
+		// A bare time of day refers to today
+		day = startOfDay(now)
+		rest = fields
+	}
+	if len(rest) == 0 {
+		return day, true, nil
+	}
+	if rest[0] == "at" {
+		rest = rest[1:]
+	}
+	hour, minute, second, err := parseClock(strings.Join(rest, ""))
+	if err != nil && !ok {
+		return time.Time{}, false, fmt.Errorf("'%s' is not a valid date", expr)
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("'%s' is not a valid date: %v", expr, err)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, day.Location()), false, nil
+}
+
+// parseSpelledOffset godoc
+//
+// Parses offsets written out in words, e.g. `in 3 days` or `2 weeks ago`.
+//
+// Returns false when the fields are not a spelled offset.
+func parseSpelledOffset(now time.Time, fields []string) (time.Time, bool, error) {
+	if len(fields) != 3 {
+		return time.Time{}, false, nil
+	}
+
+	amountField, unitField, sign := "", "", 1
+	switch {
+	case fields[0] == "in":
+		amountField, unitField = fields[1], fields[2]
+	case fields[2] == "ago":
+		amountField, unitField, sign = fields[0], fields[1], -1
+	default:
+		return time.Time{}, false, nil
+	}
+
+	amount, err := strconv.Atoi(amountField)
+	if err != nil {
+		return time.Time{}, true, fmt.Errorf("'%s' is not a number", amountField)
+	}
+	result, err := addUnits(now, sign*amount, unitField)
+	return result, true, err
+}
+
+// parseDay godoc
+//
+// Parses the day part at the start of the fields.
+//
+// Returns the start of the day, the remaining fields and true when a day is found.
+func parseDay(now time.Time, fields []string) (time.Time, []string, bool) {
+	today := startOfDay(now)
+
+	switch fields[0] {
+	case "today":
+		return today, fields[1:], true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), fields[1:], true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), fields[1:], true
+	}
+
+	if weekday, ok := weekdays[fields[0]]; ok {
+		return nextWeekday(today, weekday, true), fields[1:], true
+	}
+
+	if len(fields) >= 2 && (fields[0] == "this" || fields[0] == "next") {
+		if weekday, ok := weekdays[fields[1]]; ok {
+			return nextWeekday(today, weekday, fields[0] == "this"), fields[2:], true
+		}
+		if fields[0] == "this" && fields[1] == "week" {
+			// Weeks run from Monday to Sunday
+			return today.AddDate(0, 0, -(int(today.Weekday())+6)%7), fields[2:], true
+		}
+		if fields[0] == "next" {
+			switch fields[1] {
+			case "week":
+				return today.AddDate(0, 0, 7), fields[2:], true
+			case "month":
+				return today.AddDate(0, 1, 0), fields[2:], true
+			case "year":
+				return today.AddDate(1, 0, 0), fields[2:], true
+			}
+		}
+	}
+
+	if month, ok := months[fields[0]]; ok && len(fields) >= 2 {
+		if dayOfMonth, err := strconv.Atoi(fields[1]); err == nil && dayOfMonth >= 1 && dayOfMonth <= 31 {
+			if len(fields) >= 3 {
+				if year, err := strconv.Atoi(fields[2]); err == nil && len(fields[2]) == 4 {
+					result, ok := monthDay(year, month, dayOfMonth, now.Location())
+					return result, fields[3:], ok
+				}
+			}
+			// Month days without a year refer to the next occurrence, which is up to 4 years away for February 29
+			for year := today.Year(); year <= today.Year()+4; year++ {
+				if result, ok := monthDay(year, month, dayOfMonth, now.Location()); ok && !result.Before(today) {
+					return result, fields[2:], true
+				}
+			}
+			return time.Time{}, nil, false
+		}
+	}
+
+	if result, err := time.ParseInLocation(time.DateOnly, fields[0], now.Location()); err == nil {
+		return result, fields[1:], true
+	}
+
+	return time.Time{}, nil, false
+}
+
+// monthDay godoc
+//
+// Returns the start of a day of a month and true, or false when the month has no such day, e.g. February 31, which
+// time.Date would move to March.
+func monthDay(year int, month time.Month, dayOfMonth int, loc *time.Location) (time.Time, bool) {
+	result := time.Date(year, month, dayOfMonth, 0, 0, 0, 0, loc)
+	return result, result.Day() == dayOfMonth
+}
+
+// parseClock godoc
+//
+// Parses a time of day.
+//
+// Returns the hour, minute, second and nil on success.
+//
+// Returns error when the value is not a time of day.
+func parseClock(value string) (int, int, int, error) {
+	switch value {
+	case "noon":
+		return 12, 0, 0, nil
+	case "midnight":
+		return 0, 0, 0, nil
+	}
+
+	match := clockPattern.FindStringSubmatch(value)
+	// Require minutes or a meridiem so that bare numbers are not mistaken for hours
+	if match == nil || (match[2] == "" && match[4] == "") {
+		return 0, 0, 0, fmt.Errorf("'%s' is not a time of day", value)
+	}
+
+	hour, _ := strconv.Atoi(match[1])
+	minute, second := 0, 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	if match[3] != "" {
+		second, _ = strconv.Atoi(match[3])
+	}
+
+	switch match[4] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, fmt.Errorf("'%s' is not a time of day", value)
+		}
+		hour %= 12
+		if match[4] == "pm" {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 || second > 59 {
+		return 0, 0, 0, fmt.Errorf("'%s' is not a time of day", value)
+	}
+	return hour, minute, second, nil
+}
+
+// addUnits godoc
+//
+// Adds an amount of calendar units to a time.
+//
+// Returns the zero time and error when the unit is unknown.
+func addUnits(t time.Time, amount int, unit string) (time.Time, error) {
+	switch unit {
+	case "m", "min", "mins", "minute", "minutes":
+		return t.Add(time.Duration(amount) * time.Minute), nil
+	case "h", "hr", "hrs", "hour", "hours":
+		return t.Add(time.Duration(amount) * time.Hour), nil
+	case "d", "day", "days":
+		return t.AddDate(0, 0, amount), nil
+	case "w", "wk", "wks", "week", "weeks":
+		return t.AddDate(0, 0, 7*amount), nil
+	case "mo", "month", "months":
+		return t.AddDate(0, amount, 0), nil
+	case "y", "yr", "yrs", "year", "years":
+		return t.AddDate(amount, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("'%s' is not a known unit", unit)
+}
+
+// nextWeekday godoc
+//
+// Returns the start of the next day falling on the weekday. Today is included when includeToday is true.
+func nextWeekday(today time.Time, weekday time.Weekday, includeToday bool) time.Time {
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 && !includeToday {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+// startOfDay godoc
+//
+// Returns midnight at the start of the day of t.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
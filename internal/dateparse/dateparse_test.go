@@ -0,0 +1,104 @@
+package dateparse
+
+import (
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// testNow is a Wednesday
+var testNow = time.Date(2026, time.October, 14, 10, 30, 0, 0, time.UTC)
+
+var parser = NewParser(clock.NewFixedClock(testNow))
+
+func TestParser_Parse(t *testing.T) {
+	type testCase struct {
+		expr     string
+		expected time.Time
+	}
+
+	testCases := []testCase{
+		{expr: "now", expected: testNow},
+		{expr: "today", expected: time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)},
+		{expr: "Tomorrow", expected: time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)},
+		{expr: "yesterday", expected: time.Date(2026, time.October, 13, 0, 0, 0, 0, time.UTC)},
+		{expr: "tomorrow 5pm", expected: time.Date(2026, time.October, 15, 17, 0, 0, 0, time.UTC)},
+		{expr: "fri", expected: time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)},
+		{expr: "wednesday", expected: time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)},
+		{expr: "this wed", expected: time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)},
+		{expr: "next wed", expected: time.Date(2026, time.October, 21, 0, 0, 0, 0, time.UTC)},
+		{expr: "next fri 5pm", expected: time.Date(2026, time.October, 16, 17, 0, 0, 0, time.UTC)},
+		{expr: "next fri at 5:30pm", expected: time.Date(2026, time.October, 16, 17, 30, 0, 0, time.UTC)},
+		{expr: "next week", expected: time.Date(2026, time.October, 21, 0, 0, 0, 0, time.UTC)},
+		{expr: "this week", expected: time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)},
+		{expr: "next month", expected: time.Date(2026, time.November, 14, 0, 0, 0, 0, time.UTC)},
+		{expr: "today noon", expected: time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)},
+		{expr: "12am", expected: time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)},
+		{expr: "17:45", expected: time.Date(2026, time.October, 14, 17, 45, 0, 0, time.UTC)},
+		{expr: "nov 2", expected: time.Date(2026, time.November, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "jan 5", expected: time.Date(2027, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		{expr: "march 3 2028 9am", expected: time.Date(2028, time.March, 3, 9, 0, 0, 0, time.UTC)},
+		{expr: "feb 29", expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "oct 14", expected: time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)},
+		{expr: "2026-11-02", expected: time.Date(2026, time.November, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "2026-11-02 15:04", expected: time.Date(2026, time.November, 2, 15, 4, 0, 0, time.UTC)},
+		{expr: "2026-11-02 15:04:05", expected: time.Date(2026, time.November, 2, 15, 4, 5, 0, time.UTC)},
+		{expr: "2026-11-02T15:04", expected: time.Date(2026, time.November, 2, 15, 4, 0, 0, time.UTC)},
+		{expr: "2026-11-02T15:04:05Z", expected: time.Date(2026, time.November, 2, 15, 4, 5, 0, time.UTC)},
+		{expr: "+3d", expected: testNow.AddDate(0, 0, 3)},
+		{expr: "-2w", expected: testNow.AddDate(0, 0, -14)},
+		{expr: "+4h", expected: testNow.Add(4 * time.Hour)},
+		{expr: "+30m", expected: testNow.Add(30 * time.Minute)},
+		{expr: "+1mo", expected: testNow.AddDate(0, 1, 0)},
+		{expr: "+1y", expected: testNow.AddDate(1, 0, 0)},
+		{expr: "in 3 days", expected: testNow.AddDate(0, 0, 3)},
+		{expr: "2 weeks ago", expected: testNow.AddDate(0, 0, -14)},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.expr, func(t *testing.T) {
+			result, err := parser.Parse(test.expr)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestParser_Parse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"", "someday", "+3x", "in three days", "13pm", "25:00", "17", "tomorrow later", "feb 31", "apr 31 2027",
+		"feb 29 2027",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			result, err := parser.Parse(expr)
+
+			assert.Error(t, err)
+			assert.True(t, result.IsZero())
+		})
+	}
+}
+
+func TestParser_ParseDeadline(t *testing.T) {
+	t.Run("should resolve dates to the end of the day", func(t *testing.T) {
+		result, err := parser.ParseDeadline("tomorrow")
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, time.October, 15, 23, 59, 59, 0, time.UTC), result)
+	})
+
+	t.Run("should keep an explicit time of day", func(t *testing.T) {
+		result, err := parser.ParseDeadline("tomorrow 9am")
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, time.October, 15, 9, 0, 0, 0, time.UTC), result)
+	})
+
+	t.Run("should keep offsets exact", func(t *testing.T) {
+		result, err := parser.ParseDeadline("+3d")
+
+		assert.NoError(t, err)
+		assert.Equal(t, testNow.AddDate(0, 0, 3), result)
+	})
+}
@@ -0,0 +1,145 @@
+package project
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"strings"
+	"time"
+)
+
+// NoProjectName godoc
+//
+// Reserved name which refers to todo items without a project.
+const NoProjectName = "none"
+
+// Domain godoc
+//
+// An interface that defines the behaviour for a project domain service struct.
+type Domain interface {
+	CreateProject(string) (Project, error)
+	RenameProject(string, Project) (Project, error)
+	ArchiveProject(Project) (Project, error)
+	UnarchiveProject(Project) (Project, error)
+}
+
+// defaultDomain godoc
+//
+// A structure which adheres to the Domain interface.
+type defaultDomain struct {
+	clock clock.Clock
+}
+
+// NewDomain godoc
+//
+// Creates a new project Domain instance which uses the system clock.
+func NewDomain() Domain {
+	return NewDomainWithClock(clock.NewSystemClock())
+}
+
+// NewDomainWithClock godoc
+//
+// Creates a new project Domain instance which reads the current time from the passed in clock.
+func NewDomainWithClock(c clock.Clock) Domain {
+	return &defaultDomain{
+		clock: c,
+	}
+}
+
+// CreateProject godoc
+//
+// Creates a new active Project instance and returns it.
+//
+// Returns nil and a *ValidationError if the name is invalid.
+//
+// Returns a new Project and nil on success.
+func (d *defaultDomain) CreateProject(name string) (Project, error) {
+	normalizedName, err := normalizeName(name)
+	if err != nil {
+		return nil, fmt.Errorf("CreateProject: %w", err)
+	}
+	nowTime := d.clock.Now()
+	return NewProject(
+		0,
+		normalizedName,
+		time.Time{},
+		nowTime,
+		nowTime,
+	), nil
+}
+
+// RenameProject godoc
+//
+// Updates the project name.
+//
+// Returns nil and a *ValidationError when the name is invalid, or error when the project is nil.
+//
+// Returns the updated project and nil on success.
+func (d *defaultDomain) RenameProject(name string, project Project) (Project, error) {
+	normalizedName, err := normalizeName(name)
+	if err != nil {
+		return nil, fmt.Errorf("RenameProject: %w", err)
+	}
+	if project == nil {
+		return nil, fmt.Errorf("RenameProject: project is nil")
+	}
+	project.SetName(normalizedName)
+	project.SetUpdatedAt(d.clock.Now())
+	return project, nil
+}
+
+// ArchiveProject godoc
+//
+// Archives the project, hiding its items from the default list.
+//
+// Returns nil and error when the project is nil, or a *ConflictError when it is already archived.
+//
+// Returns the updated project and nil on success.
+func (d *defaultDomain) ArchiveProject(project Project) (Project, error) {
+	if project == nil {
+		return nil, fmt.Errorf("ArchiveProject: project is nil")
+	}
+	if project.IsArchived() {
+		return nil, newConflictError("project '%s' is already archived", project.GetName())
+	}
+	nowTime := d.clock.Now()
+	project.SetArchivedAt(nowTime)
+	project.SetUpdatedAt(nowTime)
+	return project, nil
+}
+
+// UnarchiveProject godoc
+//
+// Makes an archived project active again.
+//
+// Returns nil and error when the project is nil, or a *ConflictError when it is not archived.
+//
+// Returns the updated project and nil on success.
+func (d *defaultDomain) UnarchiveProject(project Project) (Project, error) {
+	if project == nil {
+		return nil, fmt.Errorf("UnarchiveProject: project is nil")
+	}
+	if !project.IsArchived() {
+		return nil, newConflictError("project '%s' is not archived", project.GetName())
+	}
+	project.SetArchivedAt(time.Time{})
+	project.SetUpdatedAt(d.clock.Now())
+	return project, nil
+}
+
+// normalizeName godoc
+//
+// Trims the project name and checks that it can be used.
+//
+// Returns empty string and a *ValidationError when the name is empty or reserved.
+//
+// Returns the normalized name and nil on success.
+func normalizeName(name string) (string, error) {
+	normalizedName := strings.TrimSpace(name)
+	if len(normalizedName) == 0 {
+		return "", &ValidationError{Reason: "the project name cannot be empty"}
+	}
+	if strings.EqualFold(normalizedName, NoProjectName) {
+		return "", &ValidationError{Reason: fmt.Sprintf("the project name cannot be '%s'", NoProjectName)}
+	}
+	return normalizedName, nil
+}
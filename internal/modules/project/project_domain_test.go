@@ -0,0 +1,78 @@
+package project
+
+import (
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, time.October, 14, 10, 30, 0, 0, time.Local)
+
+var domain = NewDomainWithClock(clock.NewFixedClock(testNow))
+
+func TestDefaultDomain_CreateProject(t *testing.T) {
+	t.Run("should create new project", func(t *testing.T) {
+		project, err := domain.CreateProject("  backend ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", project.GetName())
+		assert.False(t, project.IsArchived())
+		assert.Equal(t, testNow, project.GetCreatedAt())
+	})
+
+	t.Run("should return error because of invalid name", func(t *testing.T) {
+		for _, name := range []string{"", "  ", "none", "None"} {
+			project, err := domain.CreateProject(name)
+
+			assert.Error(t, err)
+			assert.Nil(t, project)
+		}
+	})
+}
+
+func TestDefaultDomain_RenameProject(t *testing.T) {
+	t.Run("should rename project", func(t *testing.T) {
+		project := NewProject(1, "backend", time.Time{}, testNow.Add(-time.Hour), testNow.Add(-time.Hour))
+
+		project, err := domain.RenameProject("api", project)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "api", project.GetName())
+		assert.Equal(t, testNow, project.GetUpdatedAt())
+	})
+
+	t.Run("should return error when name is empty or project is nil", func(t *testing.T) {
+		project, err := domain.RenameProject("", NewProject(1, "backend", time.Time{}, testNow, testNow))
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.Nil(t, project)
+
+		project, err = domain.RenameProject("api", nil)
+		assert.Error(t, err)
+		assert.Nil(t, project)
+	})
+}
+
+func TestDefaultDomain_ArchiveProject(t *testing.T) {
+	t.Run("should archive and unarchive project", func(t *testing.T) {
+		project := NewProject(1, "backend", time.Time{}, testNow, testNow)
+
+		project, err := domain.ArchiveProject(project)
+		assert.NoError(t, err)
+		assert.Equal(t, testNow, project.GetArchivedAt())
+
+		project, err = domain.UnarchiveProject(project)
+		assert.NoError(t, err)
+		assert.False(t, project.IsArchived())
+	})
+
+	t.Run("should return error when already in the requested state", func(t *testing.T) {
+		archived, err := domain.ArchiveProject(NewProject(1, "backend", testNow, testNow, testNow))
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Nil(t, archived)
+
+		unarchived, err := domain.UnarchiveProject(NewProject(1, "backend", time.Time{}, testNow, testNow))
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Nil(t, unarchived)
+	})
+}
@@ -0,0 +1,100 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound godoc
+//
+// Returned when a project does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrValidation godoc
+//
+// Returned when a value provided for a project is invalid, e.g. an empty name. The returned error is a
+// *ValidationError, which explains why the value is invalid.
+var ErrValidation = errors.New("validation failed")
+
+// ErrConflict godoc
+//
+// Returned when a change conflicts with the current state of the projects. The returned error is a *ConflictError,
+// which explains the conflict.
+var ErrConflict = errors.New("conflict")
+
+// NotFoundError godoc
+//
+// Defines the error returned when no project has the name.
+//
+// Matches ErrNotFound with errors.Is.
+type NotFoundError struct {
+	Name string
+}
+
+// Error godoc
+//
+// Returns a message naming the missing project.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("no project exists with name '%s'", e.Name)
+}
+
+// Is godoc
+//
+// Returns true when target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// ValidationError godoc
+//
+// Defines the error returned when a value provided for a project is invalid.
+//
+// Matches ErrValidation with errors.Is.
+type ValidationError struct {
+	Reason string
+}
+
+// Error godoc
+//
+// Returns the reason the value is invalid.
+func (e *ValidationError) Error() string {
+	return e.Reason
+}
+
+// Is godoc
+//
+// Returns true when target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ConflictError godoc
+//
+// Defines the error returned when a change conflicts with the current state of the projects, e.g. a name which is
+// already taken.
+//
+// Matches ErrConflict with errors.Is.
+type ConflictError struct {
+	Reason string
+}
+
+// Error godoc
+//
+// Returns the reason of the conflict.
+func (e *ConflictError) Error() string {
+	return e.Reason
+}
+
+// Is godoc
+//
+// Returns true when target is ErrConflict.
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// newConflictError godoc
+//
+// Returns a *ConflictError with a formatted reason.
+func newConflictError(format string, args ...any) error {
+	return &ConflictError{Reason: fmt.Sprintf(format, args...)}
+}
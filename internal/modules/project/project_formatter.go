@@ -0,0 +1,90 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// FormatProjectTable godoc
+//
+// Returns a string representation of a tabular list of the project summaries that are passed in. Dates are written
+// with the Go time layout dateFormat, time.DateTime when empty.
+//
+// Returns empty string and error on error writing the list with the tab writer.
+//
+// Returns "No projects..." and nil when summaries is an empty slice.
+//
+// Returns projects in a tabular format and nil on success.
+func FormatProjectTable(summaries []Summary, dateFormat string) (string, error) {
+	if len(summaries) == 0 {
+		return fmt.Sprintf("No projects...\n"), nil
+	}
+
+	if dateFormat == "" {
+		dateFormat = time.DateTime
+	}
+
+	var buffer bytes.Buffer
+
+	padding := 4
+	tabWidth := 4
+	tw := tabwriter.NewWriter(&buffer, 0, tabWidth, padding, ' ', 0)
+
+	// Write header to the tabWriter
+	_, err := fmt.Fprintln(tw, "ID\tName\tOpen\tCompleted\tArchived")
+	if err != nil {
+		return "", fmt.Errorf("FormatProjectTable: Error writing table header to tabWriter: %v", err)
+	}
+
+	// Write separator
+	_, err = fmt.Fprintln(tw, "--\t----\t----\t---------\t--------")
+	if err != nil {
+		return "", fmt.Errorf("FormatProjectTable: Error writing table header to tabWriter: %v", err)
+	}
+
+	for _, summary := range summaries {
+		archived := "-"
+		if summary.Project.IsArchived() {
+			archived = summary.Project.GetArchivedAt().Format(dateFormat)
+		}
+		_, err := fmt.Fprintf(
+			tw,
+			"%d\t%s\t%d\t%d\t%s\n",
+			summary.Project.GetId(),
+			summary.Project.GetName(),
+			summary.OpenCount,
+			summary.CompletedCount,
+			archived,
+		)
+		if err != nil {
+			return "", fmt.Errorf(
+				"FormatProjectTable: Error writing project %d: %v",
+				summary.Project.GetId(), err,
+			)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return "", fmt.Errorf(
+			"FormatProjectTable: Failed to flush tabWriter: %v", err,
+		)
+	}
+	return buffer.String(), nil
+}
+
+// FormatSummaryLines godoc
+//
+// Returns one line per project summary with its open and completed item counts.
+func FormatSummaryLines(summaries []Summary) string {
+	var builder strings.Builder
+	for _, summary := range summaries {
+		builder.WriteString(fmt.Sprintf(
+			"Project %s: %d open, %d completed\n",
+			summary.Project.GetName(), summary.OpenCount, summary.CompletedCount,
+		))
+	}
+	return builder.String()
+}
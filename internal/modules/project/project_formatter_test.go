@@ -0,0 +1,48 @@
+package project
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFormatProjectTable(t *testing.T) {
+	t.Run("should return tabular list", func(t *testing.T) {
+		summaries := []Summary{
+			{Project: NewProject(1, "backend", time.Time{}, testNow, testNow), OpenCount: 3, CompletedCount: 2},
+		}
+
+		result, err := FormatProjectTable(summaries, "")
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "backend")
+		assert.Contains(t, result, "Completed")
+	})
+
+	t.Run("should write the archive date with the date format", func(t *testing.T) {
+		summaries := []Summary{{Project: NewProject(1, "backend", testNow, testNow, testNow)}}
+
+		result, err := FormatProjectTable(summaries, time.DateOnly)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, testNow.Format(time.DateOnly)+"\n")
+	})
+
+	t.Run("should return 'No projects...' when summaries is empty", func(t *testing.T) {
+		result, err := FormatProjectTable(nil, "")
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "No projects...")
+	})
+}
+
+func TestFormatSummaryLines(t *testing.T) {
+	summaries := []Summary{
+		{Project: NewProject(1, "backend", time.Time{}, testNow, testNow), OpenCount: 3, CompletedCount: 2},
+		{Project: NewProject(2, "ops", time.Time{}, testNow, testNow), OpenCount: 0, CompletedCount: 1},
+	}
+
+	result := FormatSummaryLines(summaries)
+
+	assert.Equal(t, "Project backend: 3 open, 2 completed\nProject ops: 0 open, 1 completed\n", result)
+}
@@ -0,0 +1,189 @@
+package project
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Project godoc
+//
+// Defines an interface for a project, a named list which owns todo items, with getters and setters for
+// encapsulation purposes.
+type Project interface {
+	GetId() int64
+	GetName() string
+	SetName(string)
+	GetArchivedAt() time.Time
+	SetArchivedAt(time.Time)
+	IsArchived() bool
+	GetUpdatedAt() time.Time
+	SetUpdatedAt(time.Time)
+	GetCreatedAt() time.Time
+}
+
+// project godoc
+//
+// Defines a project structure.
+//
+// Implements the Project interface.
+type project struct {
+	id         int64
+	name       string
+	archivedAt time.Time
+	updatedAt  time.Time
+	createdAt  time.Time
+}
+
+// Summary godoc
+//
+// Defines the number of open and completed todo items owned by a project.
+type Summary struct {
+	Project        Project
+	OpenCount      int64
+	CompletedCount int64
+}
+
+// NewProject godoc
+//
+// Create a new instance of project which adheres to the Project interface.
+//
+// A zero archivedAt means that the project is active.
+func NewProject(
+	id int64,
+	name string,
+	archivedAt time.Time,
+	updatedAt time.Time,
+	createdAt time.Time,
+) Project {
+	return &project{
+		id:         id,
+		name:       name,
+		archivedAt: archivedAt,
+		updatedAt:  updatedAt,
+		createdAt:  createdAt,
+	}
+}
+
+// NewProjectFromRow godoc
+//
+// Create a new instance of project by scanning a sql.Rows struct.
+//
+// Returns nil and error on error.
+//
+// Return a new Project and nil on success.
+func NewProjectFromRow(rows *sql.Rows) (Project, error) {
+	var project project
+	var updatedAtTimestamp, createdAtTimestamp int64
+	var archivedAtTimestamp sql.NullInt64
+
+	err := rows.Scan(&project.id, &project.name, &archivedAtTimestamp, &updatedAtTimestamp, &createdAtTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("NewProjectFromRow: %v", err)
+	}
+
+	if archivedAtTimestamp.Valid {
+		project.archivedAt = time.Unix(archivedAtTimestamp.Int64, 0)
+	}
+	project.updatedAt = time.Unix(updatedAtTimestamp, 0)
+	project.createdAt = time.Unix(createdAtTimestamp, 0)
+
+	return &project, nil
+}
+
+// NewSummaryFromRow godoc
+//
+// Create a new Summary by scanning a sql.Rows struct containing the project columns followed by the open and
+// completed item counts.
+//
+// Returns an empty Summary and error on error.
+//
+// Return a new Summary and nil on success.
+func NewSummaryFromRow(rows *sql.Rows) (Summary, error) {
+	var project project
+	var updatedAtTimestamp, createdAtTimestamp, openCount, completedCount int64
+	var archivedAtTimestamp sql.NullInt64
+
+	err := rows.Scan(
+		&project.id, &project.name, &archivedAtTimestamp, &updatedAtTimestamp, &createdAtTimestamp,
+		&openCount, &completedCount,
+	)
+	if err != nil {
+		return Summary{}, fmt.Errorf("NewSummaryFromRow: %v", err)
+	}
+
+	if archivedAtTimestamp.Valid {
+		project.archivedAt = time.Unix(archivedAtTimestamp.Int64, 0)
+	}
+	project.updatedAt = time.Unix(updatedAtTimestamp, 0)
+	project.createdAt = time.Unix(createdAtTimestamp, 0)
+
+	return Summary{
+		Project:        &project,
+		OpenCount:      openCount,
+		CompletedCount: completedCount,
+	}, nil
+}
+
+// GetId godoc
+//
+// Returns the project's ID.
+func (project *project) GetId() int64 {
+	return project.id
+}
+
+// GetName godoc
+//
+// Returns the project's name.
+func (project *project) GetName() string {
+	return project.name
+}
+
+// SetName godoc
+//
+// Sets the name of the project.
+func (project *project) SetName(name string) {
+	project.name = name
+}
+
+// GetArchivedAt godoc
+//
+// Returns the time that the project was archived. The zero time is returned when the project is active.
+func (project *project) GetArchivedAt() time.Time {
+	return project.archivedAt
+}
+
+// SetArchivedAt godoc
+//
+// Sets the archived at time of the project. Passing the zero time makes the project active again.
+func (project *project) SetArchivedAt(archivedAt time.Time) {
+	project.archivedAt = archivedAt
+}
+
+// IsArchived godoc
+//
+// Returns true when the project is archived.
+func (project *project) IsArchived() bool {
+	return !project.archivedAt.IsZero()
+}
+
+// GetUpdatedAt godoc
+//
+// Returns the time that the project was last updated.
+func (project *project) GetUpdatedAt() time.Time {
+	return project.updatedAt
+}
+
+// SetUpdatedAt godoc
+//
+// Sets the updated at time of the project.
+func (project *project) SetUpdatedAt(time time.Time) {
+	project.updatedAt = time
+}
+
+// GetCreatedAt godoc
+//
+// Returns the time that the project was created.
+func (project *project) GetCreatedAt() time.Time {
+	return project.createdAt
+}
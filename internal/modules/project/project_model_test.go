@@ -0,0 +1,26 @@
+package project
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewProject(t *testing.T) {
+	var id int64 = 1
+	name := "name"
+	updatedAt := time.Now()
+	createdAt := updatedAt
+
+	project := NewProject(id, name, time.Time{}, updatedAt, createdAt)
+
+	assert.Equal(t, id, project.GetId())
+	assert.Equal(t, name, project.GetName())
+	assert.False(t, project.IsArchived())
+	assert.Equal(t, updatedAt, project.GetUpdatedAt())
+	assert.Equal(t, createdAt, project.GetCreatedAt())
+
+	project.SetArchivedAt(updatedAt)
+	assert.True(t, project.IsArchived())
+	assert.Equal(t, updatedAt, project.GetArchivedAt())
+}
@@ -0,0 +1,268 @@
+package project
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// Repository godoc
+//
+// Define a repository for a collection of Project.
+type Repository interface {
+	PersistProject(context.Context, Project) (int64, error)
+	FindProjectSummaries(context.Context, bool) ([]Summary, error)
+	FindProjectByName(context.Context, string) (Project, error)
+	UpdateProjectById(context.Context, Project) (int64, error)
+	DeleteProjectById(context.Context, int64) (int64, error)
+}
+
+// sqliteRepository godoc
+//
+// Define a repository for a collection of Project that adheres to Repository.
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+// NewSqliteRepository godoc
+// Create a new instance of sqliteRepository that adheres to Repository.
+func NewSqliteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{
+		db: db,
+	}
+}
+
+// tableName godoc
+//
+// Name for the database table which hold the projects.
+const tableName = "projects"
+
+// itemsTableName godoc
+//
+// Name for the database table which hold the todo items owned by the projects.
+const itemsTableName = "todos"
+
+// projectColumns godoc
+//
+// Columns selected for a project, in the order expected by NewProjectFromRow.
+const projectColumns = "id, name, archivedAt, updatedAt, createdAt"
+
+// nullableUnix godoc
+//
+// Converts a time to a nullable Unix timestamp. The zero time is stored as NULL.
+func nullableUnix(t time.Time) sql.NullInt64 {
+	if t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
+
+// PersistProject godoc
+//
+// Adds a Project to the database.
+//
+// Returns -1 and an error on error.
+//
+// Returns ID (Greater than 0) of inserted project and nil on success.
+func (repo *sqliteRepository) PersistProject(ctx context.Context, projectToPersist Project) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("PersistProject: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (name, archivedAt, updatedAt, createdAt) VALUES (?, ?, ?, ?)",
+		tableName,
+	)
+	result, err := repo.db.ExecContext(
+		ctx,
+		query,
+		projectToPersist.GetName(),
+		nullableUnix(projectToPersist.GetArchivedAt()),
+		projectToPersist.GetUpdatedAt().Unix(),
+		projectToPersist.GetCreatedAt().Unix(),
+	)
+	if err != nil {
+		return -1, fmt.Errorf("PersistProject: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("PersistProject: %v", err)
+	}
+
+	return id, nil
+}
+
+// FindProjectSummaries godoc
+//
+// Retrieves the projects with their open and completed item counts, sorted by name.
+//
+// Archived projects are only included when includeArchived is true.
+//
+// Returns nil and error on error.
+//
+// Returns a slice containing Summary instances and nil on success.
+func (repo *sqliteRepository) FindProjectSummaries(ctx context.Context, includeArchived bool) (result []Summary, err error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindProjectSummaries: database connection is nil")
+	}
+
+	whereClause := "WHERE p.archivedAt IS NULL"
+	if includeArchived {
+		whereClause = ""
+	}
+	query := fmt.Sprintf(
+		"SELECT p.id, p.name, p.archivedAt, p.updatedAt, p.createdAt, "+
+			"COALESCE(SUM(t.status NOT IN ('done', 'cancelled')), 0), COALESCE(SUM(t.status = 'done'), 0) "+
+			"FROM %s p LEFT JOIN %s t ON t.projectId = p.id AND t.deletedAt IS NULL %s GROUP BY p.id ORDER BY p.name",
+		tableName, itemsTableName, whereClause,
+	)
+	rows, err := repo.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindProjectSummaries: %v", err)
+	}
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("FindProjectSummaries failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: FindProjectSummaries failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	result = []Summary{}
+	for rows.Next() {
+		summary, err := NewSummaryFromRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("FindProjectSummaries: %v", err)
+		}
+		result = append(result, summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("FindProjectSummaries: %v", err)
+	}
+
+	return result, nil
+}
+
+// FindProjectByName godoc
+//
+// Get a persisted project by its name, ignoring case.
+//
+// Returns nil and nil when no project is found.
+//
+// Returns nil and error on error.
+//
+// Returns the found Project and nil on success.
+func (repo *sqliteRepository) FindProjectByName(ctx context.Context, name string) (found Project, err error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindProjectByName: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE name = ?",
+		projectColumns, tableName,
+	)
+	rows, err := repo.db.QueryContext(ctx, query, name)
+	if err != nil {
+		return nil, fmt.Errorf("FindProjectByName: %v", err)
+	}
+	// Close rows on exit
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("FindProjectByName: Failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: FindProjectByName: Failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	project, err := NewProjectFromRow(rows)
+	if err != nil {
+		return nil, fmt.Errorf("FindProjectByName: %v", err)
+	}
+	return project, nil
+}
+
+// UpdateProjectById godoc
+//
+// Update a Project in the database table using its ID.
+//
+// Returns -1 and error on error.
+//
+// Returns number of updated rows and nil on success. If a project is updated the number of updated rows will be 1,
+// else 0.
+func (repo *sqliteRepository) UpdateProjectById(ctx context.Context, projectToUpdate Project) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("UpdateProjectById: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET name = ?, archivedAt = ?, updatedAt = ? WHERE id = ?",
+		tableName,
+	)
+	result, err := repo.db.ExecContext(
+		ctx,
+		query,
+		projectToUpdate.GetName(),
+		nullableUnix(projectToUpdate.GetArchivedAt()),
+		projectToUpdate.GetUpdatedAt().Unix(),
+		projectToUpdate.GetId(),
+	)
+	if err != nil {
+		return -1, fmt.Errorf("UpdateProjectById: %v", err)
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("UpdateProjectById: %v", err)
+	}
+	return rowCount, nil
+}
+
+// DeleteProjectById godoc
+//
+// Delete a Project in the database table using its ID. The items of the project must be moved out of it first, the
+// database removes the remaining ones from the project.
+//
+// Returns -1 and error on error.
+//
+// Returns number of deleted rows and nil on success. If a project is deleted the number of deleted rows will be 1,
+// else 0.
+func (repo *sqliteRepository) DeleteProjectById(ctx context.Context, idToDelete int64) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("DeleteProjectById: database connection is nil")
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = ?", tableName)
+	result, err := repo.db.ExecContext(ctx, query, idToDelete)
+	if err != nil {
+		return -1, fmt.Errorf("DeleteProjectById: %v", err)
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("DeleteProjectById: %v", err)
+	}
+	if rowCount == 1 {
+		log.Infof("DeleteProjectById: Successfully deleted project with ID %d", idToDelete)
+	} else {
+		log.Infof("DeleteProjectById: No project with ID %d", idToDelete)
+	}
+	return rowCount, nil
+}
@@ -0,0 +1,177 @@
+package project
+
+import (
+	"github.com/rykeroc/todo-cli/internal/testutils"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// insertItem godoc
+// Inserts a todo item owned by a project directly, without depending on the todo module.
+func insertItem(t *testing.T, fixture *testutils.TestFixture, projectId int64, status string) {
+	_, err := fixture.Db.Exec(
+		"INSERT INTO todos (displayName, status, projectId, updatedAt, createdAt) VALUES (?, ?, ?, ?, ?)",
+		"item", status, projectId, time.Now().Unix(), time.Now().Unix(),
+	)
+	if err != nil {
+		t.Fatalf("insertItem: %v", err)
+	}
+}
+
+// countItems godoc
+// Counts the todo items owned by a project.
+func countItems(t *testing.T, fixture *testutils.TestFixture, projectId int64) int64 {
+	var count int64
+	err := fixture.Db.QueryRow("SELECT COUNT(*) FROM todos WHERE projectId = ?", projectId).Scan(&count)
+	if err != nil {
+		t.Fatalf("countItems: %v", err)
+	}
+	return count
+}
+
+func setupRepository(t *testing.T) (*testutils.TestFixture, Repository) {
+	fixture := testutils.SetupTestFixture(t)
+	repository := NewSqliteRepository(fixture.Db)
+	for _, name := range []string{"backend", "ops"} {
+		if _, err := repository.PersistProject(ctx, NewProject(0, name, time.Time{}, time.Now(), time.Now())); err != nil {
+			t.Fatalf("setupRepository: %v", err)
+		}
+	}
+	return fixture, repository
+}
+
+func cleanupRepository(fixture *testutils.TestFixture) {
+	err := fixture.CleanupTestFixture()
+	if err != nil {
+		log.Fatalf("cleanupRepository: Error on cleanup: %v", err)
+	}
+}
+
+func TestPersistProject(t *testing.T) {
+	fixture, repository := setupRepository(t)
+	defer cleanupRepository(fixture)
+
+	t.Run("should persist project successfully", func(t *testing.T) {
+		id, err := repository.PersistProject(ctx, NewProject(0, "planning", time.Time{}, time.Now(), time.Now()))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), id)
+	})
+
+	t.Run("should return error when name is taken, ignoring case", func(t *testing.T) {
+		id, err := repository.PersistProject(ctx, NewProject(0, "Backend", time.Time{}, time.Now(), time.Now()))
+		assert.Error(t, err)
+		assert.Equal(t, int64(-1), id)
+	})
+}
+
+func TestFindProjectByName(t *testing.T) {
+	fixture, repository := setupRepository(t)
+	defer cleanupRepository(fixture)
+
+	t.Run("should find project ignoring case", func(t *testing.T) {
+		project, err := repository.FindProjectByName(ctx, "OPS")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), project.GetId())
+		assert.Equal(t, "ops", project.GetName())
+	})
+
+	t.Run("should return nil when project does not exist", func(t *testing.T) {
+		project, err := repository.FindProjectByName(ctx, "unknown")
+		assert.NoError(t, err)
+		assert.Nil(t, project)
+	})
+}
+
+func TestFindProjectSummaries(t *testing.T) {
+	fixture, repository := setupRepository(t)
+	defer cleanupRepository(fixture)
+
+	insertItem(t, fixture, 1, "todo")
+	insertItem(t, fixture, 1, "todo")
+	insertItem(t, fixture, 1, "done")
+	insertItem(t, fixture, 1, "in-progress")
+	insertItem(t, fixture, 1, "cancelled")
+
+	t.Run("should count open and completed items", func(t *testing.T) {
+		summaries, err := repository.FindProjectSummaries(ctx, false)
+		assert.NoError(t, err)
+		assert.Len(t, summaries, 2)
+		assert.Equal(t, "backend", summaries[0].Project.GetName())
+		assert.Equal(t, int64(3), summaries[0].OpenCount)
+		assert.Equal(t, int64(1), summaries[0].CompletedCount)
+		assert.Equal(t, int64(0), summaries[1].OpenCount)
+	})
+
+	t.Run("should not count items in the trash", func(t *testing.T) {
+		_, err := fixture.Db.Exec("UPDATE todos SET deletedAt = ? WHERE id = 1", time.Now().Unix())
+		assert.NoError(t, err)
+
+		summaries, err := repository.FindProjectSummaries(ctx, false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), summaries[0].OpenCount)
+	})
+
+	t.Run("should only include archived projects when requested", func(t *testing.T) {
+		project, _ := repository.FindProjectByName(ctx, "ops")
+		project.SetArchivedAt(time.Now())
+		affectedRows, err := repository.UpdateProjectById(ctx, project)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), affectedRows)
+
+		summaries, err := repository.FindProjectSummaries(ctx, false)
+		assert.NoError(t, err)
+		assert.Len(t, summaries, 1)
+
+		summaries, err = repository.FindProjectSummaries(ctx, true)
+		assert.NoError(t, err)
+		assert.Len(t, summaries, 2)
+		assert.True(t, summaries[1].Project.IsArchived())
+	})
+}
+
+func TestDeleteProjectById(t *testing.T) {
+	fixture, repository := setupRepository(t)
+	defer cleanupRepository(fixture)
+
+	insertItem(t, fixture, 1, "todo")
+	insertItem(t, fixture, 2, "todo")
+
+	t.Run("should delete the project and keep its items without a project", func(t *testing.T) {
+		affectedRows, err := repository.DeleteProjectById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), affectedRows)
+		assert.Equal(t, int64(0), countItems(t, fixture, 1))
+		assert.Equal(t, int64(1), countItems(t, fixture, 2))
+
+		var count int64
+		err = fixture.Db.QueryRow("SELECT COUNT(*) FROM todos WHERE projectId IS NULL").Scan(&count)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should affect 0 rows when project does not exist", func(t *testing.T) {
+		affectedRows, err := repository.DeleteProjectById(ctx, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), affectedRows)
+	})
+}
+
+func TestProjectRepository_NoDatabase(t *testing.T) {
+	repository := NewSqliteRepository(nil)
+
+	t.Run("should return error on no database", func(t *testing.T) {
+		id, err := repository.PersistProject(ctx, NewProject(0, "name", time.Time{}, time.Now(), time.Now()))
+		assert.Error(t, err)
+		assert.Equal(t, int64(-1), id)
+
+		summaries, err := repository.FindProjectSummaries(ctx, true)
+		assert.Error(t, err)
+		assert.Nil(t, summaries)
+
+		affectedRows, err := repository.DeleteProjectById(ctx, 1)
+		assert.Error(t, err)
+		assert.Equal(t, int64(-1), affectedRows)
+	})
+}
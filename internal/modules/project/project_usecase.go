@@ -0,0 +1,343 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// UseCase godoc
+//
+// An interface that defines the behaviour for a project use case struct.
+type UseCase interface {
+	Create(context.Context, string) (Project, error)
+	List(context.Context, bool) ([]Summary, error)
+	Find(context.Context, string) (Project, error)
+	Summarize(context.Context, int64) ([]Summary, error)
+	Rename(context.Context, string, string) (int64, error)
+	Archive(context.Context, string) (int64, error)
+	Unarchive(context.Context, string) (int64, error)
+	Delete(context.Context, string, DeleteOptions) (int64, error)
+}
+
+// ItemUseCase godoc
+//
+// An interface that defines the behaviour of the todo item use case which the project use case relies on to move
+// the items of a deleted project, so that the items are journaled as any other change.
+type ItemUseCase interface {
+	MoveProjectItems(context.Context, int64, int64) error
+	RemoveProjectItems(context.Context, int64) error
+}
+
+// DeleteOptions godoc
+//
+// Defines what happens to the todo items owned by a deleted project.
+type DeleteOptions struct {
+	// Cascade moves the items, along with their subtasks, to the trash together with the project.
+	Cascade bool
+	// MoveTo is the name of the project which receives the items. Items are moved out of any project when empty.
+	MoveTo string
+}
+
+// defaultUseCase godoc
+//
+// A structure which takes a project domain and repository, and the use case of the items owned by the projects.
+//
+// Adheres to the project UseCase interface.
+type defaultUseCase struct {
+	domain     Domain
+	repository Repository
+	items      ItemUseCase
+}
+
+// NewUseCase godoc
+//
+// Creates a new UseCase with the passed in Domain, Repository and ItemUseCase instances.
+func NewUseCase(domain Domain, repository Repository, items ItemUseCase) UseCase {
+	return &defaultUseCase{
+		domain:     domain,
+		repository: repository,
+		items:      items,
+	}
+}
+
+// Create godoc
+//
+// Construct a new project using the passed in name and persist it locally.
+//
+// Returns nil and a *ValidationError when the name is invalid, or a *ConflictError when a project has the name.
+//
+// Returns nil and error on error.
+//
+// Returns the created project, as read back from the database, and nil on success.
+func (uc *defaultUseCase) Create(ctx context.Context, name string) (Project, error) {
+	project, err := uc.domain.CreateProject(name)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Create: %w", err)
+	}
+	if err := uc.checkNameAvailable(ctx, project.GetName(), 0); err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Create: %w", err)
+	}
+	_, err = uc.repository.PersistProject(ctx, project)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Create: %v", err)
+	}
+	createdProject, err := uc.repository.FindProjectByName(ctx, project.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Create: %v", err)
+	}
+	return createdProject, nil
+}
+
+// List godoc
+//
+// Get the persisted projects with their item counts.
+//
+// Archived projects are only listed when includeArchived is true.
+//
+// Returns nil and error on error.
+//
+// Returns the project summaries and nil on success.
+func (uc *defaultUseCase) List(ctx context.Context, includeArchived bool) ([]Summary, error) {
+	summaries, err := uc.repository.FindProjectSummaries(ctx, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.List: %v", err)
+	}
+	return summaries, nil
+}
+
+// Find godoc
+//
+// Find a project by name.
+//
+// Returns nil and nil when the project does not exist.
+//
+// Returns nil and error on error.
+//
+// Returns the project and nil on success.
+func (uc *defaultUseCase) Find(ctx context.Context, name string) (Project, error) {
+	project, err := uc.repository.FindProjectByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Find: %v", err)
+	}
+	return project, nil
+}
+
+// Summarize godoc
+//
+// Get the open and completed item counts of a project by ID.
+//
+// When projectId is 0 the counts of every active project which owns items are returned. Nothing is returned for a
+// negative projectId.
+//
+// Returns nil and error on error.
+//
+// Returns the project summaries and nil on success.
+func (uc *defaultUseCase) Summarize(ctx context.Context, projectId int64) ([]Summary, error) {
+	if projectId < 0 {
+		return nil, nil
+	}
+	summaries, err := uc.repository.FindProjectSummaries(ctx, projectId != 0)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Summarize: %v", err)
+	}
+
+	var selected []Summary
+	for _, summary := range summaries {
+		if projectId == 0 && summary.OpenCount+summary.CompletedCount > 0 {
+			selected = append(selected, summary)
+		}
+		if projectId != 0 && summary.Project.GetId() == projectId {
+			selected = append(selected, summary)
+		}
+	}
+	return selected, nil
+}
+
+// Rename godoc
+//
+// Rename a project by name.
+//
+// Returns -1 and a *NotFoundError if the project does not exist, a *ValidationError when the new name is invalid,
+// or a *ConflictError when another project has the new name.
+//
+// Returns -1 and error on error.
+//
+// Returns the renamed project id and nil on success.
+func (uc *defaultUseCase) Rename(ctx context.Context, name string, newName string) (int64, error) {
+	foundProject, err := uc.repository.FindProjectByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Rename: Failed to find project '%s': %v", name, err)
+	}
+	if foundProject == nil {
+		return -1, &NotFoundError{Name: strings.TrimSpace(name)}
+	}
+
+	renamedProject, err := uc.domain.RenameProject(newName, foundProject)
+	if err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Rename: Failed to rename project '%s': %w", name, err)
+	}
+	if err := uc.checkNameAvailable(ctx, renamedProject.GetName(), renamedProject.GetId()); err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Rename: %w", err)
+	}
+
+	return uc.persistUpdate(ctx, "Rename", renamedProject)
+}
+
+// Archive godoc
+//
+// Archive a project by name.
+//
+// Returns -1 and a *NotFoundError if the project does not exist, or a *ConflictError when it is already archived.
+//
+// Returns -1 and error on error.
+//
+// Returns the archived project id and nil on success.
+func (uc *defaultUseCase) Archive(ctx context.Context, name string) (int64, error) {
+	foundProject, err := uc.repository.FindProjectByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Archive: Failed to find project '%s': %v", name, err)
+	}
+	if foundProject == nil {
+		return -1, &NotFoundError{Name: strings.TrimSpace(name)}
+	}
+
+	archivedProject, err := uc.domain.ArchiveProject(foundProject)
+	if err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Archive: Failed to archive project '%s': %w", name, err)
+	}
+
+	return uc.persistUpdate(ctx, "Archive", archivedProject)
+}
+
+// Unarchive godoc
+//
+// Make an archived project active again by name.
+//
+// Returns -1 and a *NotFoundError if the project does not exist, or a *ConflictError when it is not archived.
+//
+// Returns -1 and error on error.
+//
+// Returns the unarchived project id and nil on success.
+func (uc *defaultUseCase) Unarchive(ctx context.Context, name string) (int64, error) {
+	foundProject, err := uc.repository.FindProjectByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Unarchive: Failed to find project '%s': %v", name, err)
+	}
+	if foundProject == nil {
+		return -1, &NotFoundError{Name: strings.TrimSpace(name)}
+	}
+
+	unarchivedProject, err := uc.domain.UnarchiveProject(foundProject)
+	if err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Unarchive: Failed to unarchive project '%s': %w", name, err)
+	}
+
+	return uc.persistUpdate(ctx, "Unarchive", unarchivedProject)
+}
+
+// Delete godoc
+//
+// Delete a project by name, either moving its items to the trash or moving them to another project. The items are
+// changed through the ItemUseCase, so the change can be undone, before the project is deleted.
+//
+// Returns -1 and a *NotFoundError if the project, or the project to move the items to, does not exist.
+//
+// Returns -1 and a *ValidationError when the items are both deleted and moved, or a *ConflictError when they are
+// moved to the deleted project.
+//
+// Returns -1 and error on error.
+//
+// Returns the deleted project id and nil on success.
+func (uc *defaultUseCase) Delete(ctx context.Context, name string, options DeleteOptions) (int64, error) {
+	if options.Cascade && options.MoveTo != "" {
+		return -1, &ValidationError{Reason: "items cannot be both deleted and moved"}
+	}
+
+	foundProject, err := uc.repository.FindProjectByName(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Delete: Failed to find project '%s': %v", name, err)
+	}
+	if foundProject == nil {
+		return -1, &NotFoundError{Name: strings.TrimSpace(name)}
+	}
+
+	if options.Cascade {
+		err = uc.items.RemoveProjectItems(ctx, foundProject.GetId())
+	} else {
+		var moveItemsToId int64
+		if options.MoveTo != "" {
+			targetProject, err := uc.repository.FindProjectByName(ctx, strings.TrimSpace(options.MoveTo))
+			if err != nil {
+				return -1, fmt.Errorf("defaultUseCase.Delete: Failed to find project '%s': %v", options.MoveTo, err)
+			}
+			if targetProject == nil {
+				return -1, &NotFoundError{Name: strings.TrimSpace(options.MoveTo)}
+			}
+			if targetProject.GetId() == foundProject.GetId() {
+				return -1, newConflictError("items cannot be moved to the deleted project")
+			}
+			moveItemsToId = targetProject.GetId()
+		}
+		err = uc.items.MoveProjectItems(ctx, foundProject.GetId(), moveItemsToId)
+	}
+	if err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Delete: Failed to move items of project '%s': %v", name, err)
+	}
+
+	affectedRows, err := uc.repository.DeleteProjectById(ctx, foundProject.GetId())
+	if err != nil {
+		return -1, fmt.Errorf("defaultUseCase.Delete: Failed to delete project '%s': %v", name, err)
+	}
+	// Project deleted since it was found
+	if affectedRows == 0 {
+		return -1, &NotFoundError{Name: foundProject.GetName()}
+	}
+
+	return foundProject.GetId(), nil
+}
+
+// persistUpdate godoc
+//
+// Persist an updated project.
+//
+// Returns -1 and a *NotFoundError if the project does not exist.
+//
+// Returns -1 and error on error.
+//
+// Returns the updated project id and nil on success.
+func (uc *defaultUseCase) persistUpdate(ctx context.Context, operation string, updatedProject Project) (int64, error) {
+	affectedRows, err := uc.repository.UpdateProjectById(ctx, updatedProject)
+	if err != nil {
+		return -1, fmt.Errorf(
+			"defaultUseCase.%s: Failed to persist update for project with ID %d: %v",
+			operation, updatedProject.GetId(), err,
+		)
+	}
+	// Project deleted since it was found
+	if affectedRows == 0 {
+		return -1, &NotFoundError{Name: updatedProject.GetName()}
+	}
+	return updatedProject.GetId(), nil
+}
+
+// checkNameAvailable godoc
+//
+// Check that no project other than the one with projectId has the name. Names are compared case-insensitively, as the
+// database does.
+//
+// Returns a *ConflictError when another project has the name.
+//
+// Returns error on error.
+//
+// Returns nil when the name is available.
+func (uc *defaultUseCase) checkNameAvailable(ctx context.Context, name string, projectId int64) error {
+	existingProject, err := uc.repository.FindProjectByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("Failed to find project '%s': %v", name, err)
+	}
+	if existingProject != nil && existingProject.GetId() != projectId {
+		return newConflictError("a project named '%s' already exists", existingProject.GetName())
+	}
+	return nil
+}
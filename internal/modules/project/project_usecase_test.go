@@ -0,0 +1,199 @@
+package project
+
+import (
+	"context"
+	"github.com/rykeroc/todo-cli/internal/testutils"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// ctx is passed to every repository and use case call of the tests.
+var ctx = context.Background()
+
+// itemUseCaseStub godoc
+// Records the changes of the items of deleted projects, without depending on the todo module.
+type itemUseCaseStub struct {
+	// moves holds the project ID and the ID of the project receiving the items of each MoveProjectItems call.
+	moves [][2]int64
+	// removedProjectIds holds the project ID of each RemoveProjectItems call.
+	removedProjectIds []int64
+}
+
+func (s *itemUseCaseStub) MoveProjectItems(_ context.Context, projectId int64, toProjectId int64) error {
+	s.moves = append(s.moves, [2]int64{projectId, toProjectId})
+	return nil
+}
+
+func (s *itemUseCaseStub) RemoveProjectItems(_ context.Context, projectId int64) error {
+	s.removedProjectIds = append(s.removedProjectIds, projectId)
+	return nil
+}
+
+func beforeEach(t *testing.T) (*testutils.TestFixture, UseCase) {
+	fixture, useCase, _ := beforeEachWithItems(t)
+	return fixture, useCase
+}
+
+func beforeEachWithItems(t *testing.T) (*testutils.TestFixture, UseCase, *itemUseCaseStub) {
+	fixture := testutils.SetupTestFixture(t)
+	repository := NewSqliteRepository(fixture.Db)
+	items := &itemUseCaseStub{}
+	return fixture, NewUseCase(NewDomain(), repository, items), items
+}
+
+func afterEach(fixture *testutils.TestFixture) {
+	err := fixture.CleanupTestFixture()
+	if err != nil {
+		log.Fatalf("afterEach: Error on cleanup: %v", err)
+	}
+}
+
+func TestDefaultUseCase_Create(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	t.Run("project use case create", func(t *testing.T) {
+		project, err := useCase.Create(ctx, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), project.GetId())
+		assert.Equal(t, "backend", project.GetName())
+
+		_, err = useCase.Create(ctx, "Backend")
+		assert.ErrorIs(t, err, ErrConflict)
+		_, err = useCase.Create(ctx, "")
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("project use case list", func(t *testing.T) {
+		summaries, err := useCase.List(ctx, true)
+		assert.NoError(t, err)
+		assert.Len(t, summaries, 1)
+
+		// The project does not own items yet
+		summaries, err = useCase.Summarize(ctx, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, summaries)
+
+		summaries, err = useCase.Summarize(ctx, 1)
+		assert.NoError(t, err)
+		assert.Len(t, summaries, 1)
+	})
+}
+
+func TestDefaultUseCase_Rename(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	if _, err := useCase.Create(ctx, "backend"); err != nil {
+		log.Fatalf("TestDefaultUseCase_Rename: Error inserting project: %v", err)
+	}
+
+	t.Run("project use case rename", func(t *testing.T) {
+		renamedId, err := useCase.Rename(ctx, "backend", "api")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), renamedId)
+
+		project, err := useCase.Find(ctx, "api")
+		assert.NoError(t, err)
+		assert.Equal(t, "api", project.GetName())
+
+		renamedId, err = useCase.Rename(ctx, "backend", "api")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.EqualError(t, err, "no project exists with name 'backend'")
+		assert.Equal(t, int64(-1), renamedId)
+	})
+
+	t.Run("should return error when another project has the name", func(t *testing.T) {
+		if _, err := useCase.Create(ctx, "ops"); err != nil {
+			t.Fatalf("TestDefaultUseCase_Rename: %v", err)
+		}
+
+		renamedId, err := useCase.Rename(ctx, "api", "OPS")
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Equal(t, int64(-1), renamedId)
+
+		renamedId, err = useCase.Rename(ctx, "api", "API")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), renamedId)
+	})
+}
+
+func TestDefaultUseCase_Archive(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	if _, err := useCase.Create(ctx, "backend"); err != nil {
+		log.Fatalf("TestDefaultUseCase_Archive: Error inserting project: %v", err)
+	}
+
+	t.Run("project use case archive", func(t *testing.T) {
+		archivedId, err := useCase.Archive(ctx, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), archivedId)
+
+		archivedId, err = useCase.Archive(ctx, "backend")
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Equal(t, int64(-1), archivedId)
+
+		unarchivedId, err := useCase.Unarchive(ctx, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), unarchivedId)
+
+		unarchivedId, err = useCase.Unarchive(ctx, "backend")
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Equal(t, int64(-1), unarchivedId)
+
+		archivedId, err = useCase.Archive(ctx, "unknown")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, int64(-1), archivedId)
+	})
+}
+
+func TestDefaultUseCase_Delete(t *testing.T) {
+	fixture, useCase, items := beforeEachWithItems(t)
+	defer afterEach(fixture)
+
+	for _, name := range []string{"backend", "ops", "planning"} {
+		if _, err := useCase.Create(ctx, name); err != nil {
+			log.Fatalf("TestDefaultUseCase_Delete: Error inserting project: %v", err)
+		}
+	}
+
+	type testCase struct {
+		name              string
+		options           DeleteOptions
+		expectedProjectId int64
+		expectedErr       error
+	}
+
+	testCases := []testCase{
+		{name: "backend", options: DeleteOptions{MoveTo: "unknown"}, expectedProjectId: -1, expectedErr: ErrNotFound},
+		{name: "backend", options: DeleteOptions{MoveTo: "backend"}, expectedProjectId: -1, expectedErr: ErrConflict},
+		{
+			name: "backend", options: DeleteOptions{Cascade: true, MoveTo: "ops"}, expectedProjectId: -1,
+			expectedErr: ErrValidation,
+		},
+		{name: "backend", options: DeleteOptions{MoveTo: "ops"}, expectedProjectId: 1},
+		{name: "ops", options: DeleteOptions{Cascade: true}, expectedProjectId: 2},
+		{name: "planning", options: DeleteOptions{}, expectedProjectId: 3},
+		{name: "planning", options: DeleteOptions{}, expectedProjectId: -1, expectedErr: ErrNotFound},
+	}
+
+	t.Run("project use case delete", func(t *testing.T) {
+		for _, test := range testCases {
+			deletedId, err := useCase.Delete(ctx, test.name, test.options)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedProjectId, deletedId)
+		}
+	})
+
+	t.Run("should move or remove the items before deleting the project", func(t *testing.T) {
+		assert.Equal(t, [][2]int64{{1, 2}, {3, 0}}, items.moves)
+		assert.Equal(t, []int64{2}, items.removedProjectIds)
+	})
+}
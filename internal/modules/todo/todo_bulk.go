@@ -0,0 +1,393 @@
+package todo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// IdRange godoc
+//
+// Defines a range of item IDs such as 8-12, which includes both ends.
+type IdRange struct {
+	Start int64
+	End   int64
+}
+
+// BulkOptions godoc
+//
+// Defines the items which a bulk operation applies to and whether its changes are saved.
+type BulkOptions struct {
+	// Ids selects items by ID, in the order they are listed. Repeated IDs are only processed once.
+	Ids []int64
+	// Ranges selects the items whose ID is within one of the ranges, after the items of Ids. Unlike Ids, the IDs of
+	// a range which have no item, e.g. because the item was removed, are skipped.
+	Ranges []IdRange
+	// Filter selects the items matching a filter expression, as `list --filter` does, when Ids and Ranges are empty.
+	Filter string
+	// DryRun runs the operation and reports its results without saving any change.
+	DryRun bool
+}
+
+// BulkResult godoc
+//
+// Defines the outcome of a bulk operation for one item.
+type BulkResult struct {
+	ItemId int64
+	// Name is the name of the item before the operation, empty when the item does not exist.
+	Name string
+	// Err is the reason the operation failed for the item, nil when it succeeded.
+	Err error
+	// CoveredBy is the ID of the selected item along with which the operation applied to the item, its parent at
+	// any depth, 0 when the operation applied to the item itself.
+	CoveredBy int64
+	// Completion holds the items completed along with the item by CompleteItems.
+	Completion *Completion
+}
+
+// BulkSummary godoc
+//
+// Defines the outcome of a bulk operation.
+type BulkSummary struct {
+	// Results holds the outcome for each selected item, in the order the items were processed.
+	Results []BulkResult
+	// Applied is true when the changes were saved. Changes are only saved when the operation succeeded for every
+	// item and it was not a dry run.
+	Applied bool
+}
+
+// Failed godoc
+//
+// Returns the results of the items for which the operation failed.
+func (s *BulkSummary) Failed() []BulkResult {
+	var failed []BulkResult
+	for _, result := range s.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// errBulkRollback godoc
+//
+// Returned to WithTransaction to roll back a bulk operation which is a dry run or failed for an item.
+var errBulkRollback = errors.New("bulk operation rolled back")
+
+// CompleteItems godoc
+//
+// Complete the selected items in a single transaction, see Complete.
+//
+// Returns nil and error wrapping ErrValidation when no item is selected or the filter expression is invalid.
+//
+// Returns nil and error on error.
+//
+// Returns the summary of the operation and nil otherwise. No change is saved when it failed for an item.
+func (uc *defaultUseCase) CompleteItems(ctx context.Context, options BulkOptions) (*BulkSummary, error) {
+	// Subtasks are completed before their parent, so the completion policy does not refuse the parent
+	scope := bulkScope{verb: "complete"}
+	summary, err := uc.runBulk(ctx, options, scope, func(txUseCase *defaultUseCase, result *BulkResult) error {
+		completion, err := txUseCase.Complete(ctx, result.ItemId)
+		result.Completion = completion
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.CompleteItems: %w", err)
+	}
+	return summary, nil
+}
+
+// RemoveItems godoc
+//
+// Remove the selected items in a single transaction, see Remove. The selected subtasks of a selected item are
+// removed along with it.
+//
+// Returns nil and error wrapping ErrValidation when no item is selected or the filter expression is invalid.
+//
+// Returns nil and error on error.
+//
+// Returns the summary of the operation and nil otherwise. No change is saved when it failed for an item.
+func (uc *defaultUseCase) RemoveItems(ctx context.Context, options BulkOptions) (*BulkSummary, error) {
+	scope := bulkScope{verb: "remove", coversSubtasks: true}
+	summary, err := uc.runBulk(ctx, options, scope, func(txUseCase *defaultUseCase, result *BulkResult) error {
+		return txUseCase.Remove(ctx, result.ItemId)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.RemoveItems: %w", err)
+	}
+	return summary, nil
+}
+
+// UpdateItems godoc
+//
+// Apply the changes to the selected items in a single transaction, see Update.
+//
+// Returns nil and error wrapping ErrValidation when no item is selected, the filter expression is invalid or there
+// are no changes.
+//
+// Returns nil and error on error.
+//
+// Returns the summary of the operation and nil otherwise. No change is saved when it failed for an item.
+func (uc *defaultUseCase) UpdateItems(
+	ctx context.Context, options BulkOptions, changes ItemChanges,
+) (*BulkSummary, error) {
+	if changes.IsEmpty() {
+		return nil, fmt.Errorf("defaultUseCase.UpdateItems: %w", newValidationError("changes", "no changes"))
+	}
+	scope := bulkScope{verb: "update"}
+	summary, err := uc.runBulk(ctx, options, scope, func(txUseCase *defaultUseCase, result *BulkResult) error {
+		return txUseCase.Update(ctx, result.ItemId, changes)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.UpdateItems: %w", err)
+	}
+	return summary, nil
+}
+
+// bulkScope godoc
+//
+// Defines how runBulk applies an operation to the selected items.
+type bulkScope struct {
+	// verb summarizes the operation in the journal, e.g. "remove".
+	verb string
+	// coversSubtasks is true when the operation applies to the subtasks of an item along with it, so that the
+	// selected subtasks of a selected item are left to their parent.
+	coversSubtasks bool
+	// includesTrash is true when the operation applies to the items in the trash as well.
+	includesTrash bool
+}
+
+// bulkItem godoc
+//
+// Defines an item selected by a bulk operation.
+type bulkItem struct {
+	id int64
+	// item is the item with the ID, in the trash or not, nil when there is none.
+	item Item
+	// optional is true when the ID is only selected by a range, so it is skipped when there is no item.
+	optional bool
+}
+
+// runBulk godoc
+//
+// Runs operation for each selected item in a single transaction, with a use case whose repository runs in the
+// transaction. The subtasks of an item, at any depth, are processed before it, and they are not passed to operation
+// when the scope covers subtasks. The transaction is rolled back when the options are a dry run or the operation
+// failed for an item. Otherwise the changes of every item are recorded as a single journal entry, summarized with
+// the verb of the scope.
+//
+// Returns nil and error wrapping ErrValidation when no item is selected or the filter expression is invalid.
+//
+// Returns nil and error on error.
+//
+// Returns the summary of the operation and nil otherwise.
+func (uc *defaultUseCase) runBulk(
+	ctx context.Context, options BulkOptions, scope bulkScope, operation func(*defaultUseCase, *BulkResult) error,
+) (*BulkSummary, error) {
+	if len(options.Ids) == 0 && len(options.Ranges) == 0 && options.Filter == "" {
+		return nil, fmt.Errorf("runBulk: %w", newValidationError("items", "provide IDs or a filter"))
+	}
+
+	summary := &BulkSummary{}
+	err := uc.repository.WithTransaction(ctx, func(repository Repository) error {
+		txUseCase := uc.inTransaction(repository)
+		selected, err := txUseCase.selectBulkItems(ctx, options, scope)
+		if err != nil {
+			return err
+		}
+		ancestors, err := txUseCase.findSelectedAncestors(ctx, selected)
+		if err != nil {
+			return err
+		}
+
+		failed := false
+		for _, selectedItem := range orderSubtasksFirst(selected, ancestors) {
+			result := BulkResult{ItemId: selectedItem.id}
+			if selectedItem.item != nil {
+				result.Name = selectedItem.item.GetName()
+			}
+			// The operation applies to the subtask along with its topmost selected parent
+			if scope.coversSubtasks && len(ancestors[selectedItem.id]) > 0 {
+				result.CoveredBy = ancestors[selectedItem.id][len(ancestors[selectedItem.id])-1]
+			} else {
+				result.Err = operation(txUseCase, &result)
+			}
+			failed = failed || result.Err != nil
+			summary.Results = append(summary.Results, result)
+		}
+
+		if failed || options.DryRun {
+			return errBulkRollback
+		}
+		if len(selected) == 0 {
+			return nil
+		}
+		ids := make([]int64, 0, len(selected))
+		for _, selectedItem := range selected {
+			ids = append(ids, selectedItem.id)
+		}
+		return txUseCase.saveJournalEntry(ctx, summarizeItems(scope.verb, ids))
+	})
+	if errors.Is(err, errBulkRollback) {
+		return summary, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("runBulk: %w", err)
+	}
+	summary.Applied = true
+	return summary, nil
+}
+
+// selectBulkItems godoc
+//
+// Returns the items selected by the options, in the order they are selected, without repeated IDs. The IDs of a
+// range are left out when they have no item the scope applies to.
+//
+// Returns nil and error wrapping ErrValidation when the filter expression is invalid.
+//
+// Returns nil and error on error.
+func (uc *defaultUseCase) selectBulkItems(
+	ctx context.Context, options BulkOptions, scope bulkScope,
+) ([]bulkItem, error) {
+	var candidates []bulkItem
+	for _, id := range options.Ids {
+		candidates = append(candidates, bulkItem{id: id})
+	}
+	for _, idRange := range options.Ranges {
+		for id := idRange.Start; id <= idRange.End; id++ {
+			candidates = append(candidates, bulkItem{id: id, optional: true})
+		}
+	}
+	if len(options.Ids) == 0 && len(options.Ranges) == 0 {
+		items, err := uc.List(ctx, ListOptions{Filters: []string{options.Filter}})
+		if err != nil {
+			return nil, fmt.Errorf("selectBulkItems: %w", err)
+		}
+		for _, item := range items {
+			candidates = append(candidates, bulkItem{id: item.GetId()})
+		}
+	}
+
+	// An ID listed on its own is required, even when a range selects it as well
+	required := map[int64]bool{}
+	for _, candidate := range candidates {
+		required[candidate.id] = required[candidate.id] || !candidate.optional
+	}
+	var selected []bulkItem
+	seen := map[int64]bool{}
+	for _, candidate := range candidates {
+		if seen[candidate.id] {
+			continue
+		}
+		seen[candidate.id] = true
+
+		item, trashed, err := uc.findAnyItem(ctx, candidate.id)
+		if err != nil {
+			return nil, fmt.Errorf("selectBulkItems: %v", err)
+		}
+		if !required[candidate.id] && (item == nil || trashed && !scope.includesTrash) {
+			continue
+		}
+		candidate.item = item
+		selected = append(selected, candidate)
+	}
+	return selected, nil
+}
+
+// findAnyItem godoc
+//
+// Returns the item with the ID, in the trash or not, whether it is in the trash, and nil. The item is nil when it
+// does not exist.
+//
+// Returns nil, false and error on error.
+func (uc *defaultUseCase) findAnyItem(ctx context.Context, id int64) (Item, bool, error) {
+	if id <= 0 {
+		return nil, false, nil
+	}
+	item, err := uc.repository.FindItemById(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("findAnyItem: Failed to find item with ID %d: %v", id, err)
+	}
+	if item != nil {
+		return item, false, nil
+	}
+	item, err = uc.repository.FindTrashedItemById(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("findAnyItem: Failed to find item with ID %d in the trash: %v", id, err)
+	}
+	return item, item != nil, nil
+}
+
+// findSelectedAncestors godoc
+//
+// Returns the IDs of the selected parents of each selected item, at any depth, nearest first, and nil. The parents
+// are looked up in the trash as well.
+//
+// Returns nil and error on error.
+func (uc *defaultUseCase) findSelectedAncestors(
+	ctx context.Context, selected []bulkItem,
+) (map[int64][]int64, error) {
+	isSelected := map[int64]bool{}
+	parentIds := map[int64]int64{}
+	for _, selectedItem := range selected {
+		isSelected[selectedItem.id] = true
+		if selectedItem.item != nil {
+			parentIds[selectedItem.id] = selectedItem.item.GetParentId()
+		}
+	}
+
+	ancestors := map[int64][]int64{}
+	for _, selectedItem := range selected {
+		visited := map[int64]bool{selectedItem.id: true}
+		for parentId := parentIds[selectedItem.id]; parentId != 0 && !visited[parentId]; {
+			visited[parentId] = true
+			if isSelected[parentId] {
+				ancestors[selectedItem.id] = append(ancestors[selectedItem.id], parentId)
+			}
+			nextId, found := parentIds[parentId]
+			if !found {
+				parent, _, err := uc.findAnyItem(ctx, parentId)
+				if err != nil {
+					return nil, fmt.Errorf("findSelectedAncestors: %v", err)
+				}
+				if parent != nil {
+					nextId = parent.GetParentId()
+				}
+				parentIds[parentId] = nextId
+			}
+			parentId = nextId
+		}
+	}
+	return ancestors, nil
+}
+
+// orderSubtasksFirst godoc
+//
+// Returns the selected items ordered so that the subtasks of an item, at any depth, come before it. The order of
+// the other items is kept.
+func orderSubtasksFirst(selected []bulkItem, ancestors map[int64][]int64) []bulkItem {
+	subtasks := map[int64][]bulkItem{}
+	for _, selectedItem := range selected {
+		for _, ancestorId := range ancestors[selectedItem.id] {
+			subtasks[ancestorId] = append(subtasks[ancestorId], selectedItem)
+		}
+	}
+
+	ordered := make([]bulkItem, 0, len(selected))
+	visited := map[int64]bool{}
+	var visit func(bulkItem)
+	visit = func(selectedItem bulkItem) {
+		if visited[selectedItem.id] {
+			return
+		}
+		visited[selectedItem.id] = true
+		for _, subtask := range subtasks[selectedItem.id] {
+			visit(subtask)
+		}
+		ordered = append(ordered, selectedItem)
+	}
+	for _, selectedItem := range selected {
+		visit(selectedItem)
+	}
+	return ordered
+}
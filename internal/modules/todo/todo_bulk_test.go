@@ -0,0 +1,208 @@
+package todo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDefaultUseCase_CompleteItems(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	for _, name := range []string{"first", "second", "third"} {
+		if _, err := useCase.Create(ctx, ItemDraft{Name: name}); err != nil {
+			t.Fatalf("TestDefaultUseCase_CompleteItems: %v", err)
+		}
+	}
+	completedCount := func() int {
+		items, err := useCase.List(ctx, ListOptions{Filters: []string{"status:done"}})
+		assert.NoError(t, err)
+		return len(items)
+	}
+
+	t.Run("should not save the changes of a dry run", func(t *testing.T) {
+		summary, err := useCase.CompleteItems(ctx, BulkOptions{Ids: []int64{1, 2}, DryRun: true})
+
+		assert.NoError(t, err)
+		assert.False(t, summary.Applied)
+		assert.Len(t, summary.Results, 2)
+		assert.Empty(t, summary.Failed())
+		assert.Equal(t, "first", summary.Results[0].Name)
+		assert.Len(t, summary.Results[0].Completion.Items, 1)
+		assert.Equal(t, 0, completedCount())
+	})
+
+	t.Run("should not save any change when an item fails", func(t *testing.T) {
+		summary, err := useCase.CompleteItems(ctx, BulkOptions{Ids: []int64{1, 100, 2}})
+
+		assert.NoError(t, err)
+		assert.False(t, summary.Applied)
+		failed := summary.Failed()
+		assert.Len(t, failed, 1)
+		assert.Equal(t, int64(100), failed[0].ItemId)
+		assert.Empty(t, failed[0].Name)
+		assert.ErrorIs(t, failed[0].Err, ErrNotFound)
+		assert.Equal(t, 0, completedCount())
+	})
+
+	t.Run("should complete every item once", func(t *testing.T) {
+		summary, err := useCase.CompleteItems(ctx, BulkOptions{Ids: []int64{1, 2, 1}})
+
+		assert.NoError(t, err)
+		assert.True(t, summary.Applied)
+		assert.Len(t, summary.Results, 2)
+		assert.Equal(t, 2, completedCount())
+	})
+
+	t.Run("should return error when no item is selected", func(t *testing.T) {
+		_, err := useCase.CompleteItems(ctx, BulkOptions{})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestDefaultUseCase_RemoveItems(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	for _, name := range []string{"old draft", "old notes", "keep"} {
+		if _, err := useCase.Create(ctx, ItemDraft{Name: name}); err != nil {
+			t.Fatalf("TestDefaultUseCase_RemoveItems: %v", err)
+		}
+	}
+
+	t.Run("should return error when the filter is invalid", func(t *testing.T) {
+		_, err := useCase.RemoveItems(ctx, BulkOptions{Filter: "name:"})
+		var filterErr *FilterError
+		assert.ErrorAs(t, err, &filterErr)
+	})
+
+	t.Run("should remove the items matching the filter", func(t *testing.T) {
+		summary, err := useCase.RemoveItems(ctx, BulkOptions{Filter: "name:old"})
+
+		assert.NoError(t, err)
+		assert.True(t, summary.Applied)
+		assert.Len(t, summary.Results, 2)
+
+		items, err := useCase.List(ctx, ListOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, "keep", items[0].GetName())
+	})
+
+	t.Run("should return an empty summary when no item matches the filter", func(t *testing.T) {
+		summary, err := useCase.RemoveItems(ctx, BulkOptions{Filter: "name:missing"})
+
+		assert.NoError(t, err)
+		assert.True(t, summary.Applied)
+		assert.Empty(t, summary.Results)
+	})
+}
+
+func TestDefaultUseCase_UpdateItems(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	for _, name := range []string{"first", "second"} {
+		if _, err := useCase.Create(ctx, ItemDraft{Name: name}); err != nil {
+			t.Fatalf("TestDefaultUseCase_UpdateItems: %v", err)
+		}
+	}
+
+	t.Run("should update every item", func(t *testing.T) {
+		priority := PriorityHigh
+		summary, err := useCase.UpdateItems(ctx, BulkOptions{Ids: []int64{1, 2}}, ItemChanges{Priority: &priority})
+
+		assert.NoError(t, err)
+		assert.True(t, summary.Applied)
+		items, err := useCase.List(ctx, ListOptions{Filters: []string{"priority:high"}})
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+	})
+
+	t.Run("should return error when there are no changes", func(t *testing.T) {
+		_, err := useCase.UpdateItems(ctx, BulkOptions{Ids: []int64{1}}, ItemChanges{})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestDefaultUseCase_BulkSubtasks(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	drafts := []ItemDraft{
+		{Name: "release"}, {Name: "changelog", ParentId: 1}, {Name: "tag", ParentId: 2}, {Name: "other"},
+	}
+	for _, draft := range drafts {
+		if _, err := useCase.Create(ctx, draft); err != nil {
+			t.Fatalf("TestDefaultUseCase_BulkSubtasks: %v", err)
+		}
+	}
+	listed := func(filter string) int {
+		items, err := useCase.List(ctx, ListOptions{Filters: []string{filter}})
+		assert.NoError(t, err)
+		return len(items)
+	}
+
+	t.Run("should complete subtasks before their parent", func(t *testing.T) {
+		summary, err := useCase.CompleteItems(ctx, BulkOptions{Filter: "status:open", DryRun: true})
+
+		assert.NoError(t, err)
+		assert.Empty(t, summary.Failed())
+		var ids []int64
+		for _, result := range summary.Results {
+			ids = append(ids, result.ItemId)
+		}
+		assert.Equal(t, []int64{3, 2, 1, 4}, ids)
+		assert.Equal(t, 4, listed("status:open"))
+	})
+
+	t.Run("should remove the selected subtasks along with their parent", func(t *testing.T) {
+		summary, err := useCase.RemoveItems(ctx, BulkOptions{Ids: []int64{1, 3}, Ranges: []IdRange{{Start: 2, End: 2}}})
+
+		assert.NoError(t, err)
+		assert.True(t, summary.Applied)
+		assert.Empty(t, summary.Failed())
+		assert.Len(t, summary.Results, 3)
+		assert.Equal(t, BulkResult{ItemId: 3, Name: "tag", CoveredBy: 1}, summary.Results[0])
+		assert.Equal(t, BulkResult{ItemId: 2, Name: "changelog", CoveredBy: 1}, summary.Results[1])
+		assert.Equal(t, int64(1), summary.Results[2].ItemId)
+		assert.Equal(t, 1, listed("status:open"))
+
+		// The subtasks are restored along with their parent
+		restored, err := useCase.Restore(ctx, 1)
+		assert.NoError(t, err)
+		assert.Len(t, restored, 3)
+	})
+
+	t.Run("should skip the IDs of a range which have no item", func(t *testing.T) {
+		assert.NoError(t, useCase.Remove(ctx, 4))
+
+		summary, err := useCase.RemoveItems(ctx, BulkOptions{Ranges: []IdRange{{Start: 3, End: 10}}})
+
+		assert.NoError(t, err)
+		assert.True(t, summary.Applied)
+		assert.Len(t, summary.Results, 1)
+		assert.Equal(t, int64(3), summary.Results[0].ItemId)
+
+		// IDs listed on their own still fail
+		summary, err = useCase.RemoveItems(ctx, BulkOptions{Ids: []int64{10}, Ranges: []IdRange{{Start: 9, End: 10}}})
+		assert.NoError(t, err)
+		assert.False(t, summary.Applied)
+		assert.Len(t, summary.Failed(), 1)
+		assert.ErrorIs(t, summary.Failed()[0].Err, ErrNotFound)
+	})
+
+	t.Run("should delete the selected subtasks along with their parent for good", func(t *testing.T) {
+		assert.NoError(t, useCase.Remove(ctx, 1))
+
+		summary, err := useCase.PurgeItems(ctx, BulkOptions{Ranges: []IdRange{{Start: 1, End: 4}}})
+
+		assert.NoError(t, err)
+		assert.True(t, summary.Applied)
+		assert.Empty(t, summary.Failed())
+		assert.Len(t, summary.Results, 4)
+		trash, err := useCase.ListTrash(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, trash)
+	})
+}
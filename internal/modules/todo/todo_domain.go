@@ -1,116 +1,423 @@
 package todo
 
 import (
-	"bytes"
 	"fmt"
-	"text/tabwriter"
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"github.com/rykeroc/todo-cli/internal/dateparse"
+	"github.com/rykeroc/todo-cli/internal/recurrence"
+	"slices"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // Domain godoc
 //
 // An interface that defines the behaviour for a todo item domain service struct.
 type Domain interface {
-	CreateItem(string) (Item, error)
-	GetTabularItemList([]Item) (string, error)
+	CreateItem(ItemDraft) (Item, error)
+	GetDueItemFilter(DueFilter) (ItemFilter, error)
+	NormalizeTag(string) (string, error)
+	NormalizeTags([]string) ([]string, error)
 	UpdateItemName(string, Item) (Item, error)
+	UpdateItemDueAt(time.Time, Item) (Item, error)
+	UpdateItemPriority(Priority, Item) (Item, error)
+	UpdateItemProject(int64, Item) (Item, error)
+	UpdateItemParent(int64, []int64, Item) (Item, error)
 	CompleteItem(Item) (Item, error)
+	UpdateItemStatus(Status, string, Item) (Item, error)
+	CompleteItemTree(Item, []Item, CompletionPolicy) ([]Item, error)
+	CreateDependency(int64, int64, []Dependency) (Dependency, error)
+	SortItemsByDependencies([]Item, []Dependency) []Item
+	UpdateItemRecurrence(string, Item) (Item, error)
+	CreateNextOccurrence(Item) (Item, error)
+	UpdateItemDescription(string, Item) (Item, error)
+	ParseFilter(string) (FilterExpression, error)
+	CreateJournalEntry(string, []JournalChange) JournalEntry
+	CreateItemEvents(string, []JournalChange) []ItemEvent
+	TrashItem(Item) (Item, error)
+	RestoreTrashedItem(Item) (Item, error)
+}
+
+// DueFilter godoc
+//
+// Defines a view over the due dates of todo items.
+type DueFilter string
+
+const (
+	// DueFilterNone does not filter on due dates.
+	DueFilterNone DueFilter = ""
+	// DueFilterOverdue keeps open items whose due date has passed.
+	DueFilterOverdue DueFilter = "overdue"
+	// DueFilterToday keeps open items which are due during the current day.
+	DueFilterToday DueFilter = "today"
+	// DueFilterWeek keeps open items which are due between the start of the current day and the end of the week.
+	DueFilterWeek DueFilter = "week"
+)
+
+// ParseDueFilter godoc
+//
+// Converts a string into a DueFilter.
+//
+// Returns DueFilterNone and error when the value is not a known filter.
+//
+// Returns the matching DueFilter and nil on success.
+func ParseDueFilter(value string) (DueFilter, error) {
+	switch dueFilter := DueFilter(value); dueFilter {
+	case DueFilterNone, DueFilterOverdue, DueFilterToday, DueFilterWeek:
+		return dueFilter, nil
+	}
+	return DueFilterNone, fmt.Errorf("ParseDueFilter: %w", newValidationError(
+		"due filter", "'%s' is not one of %s, %s or %s", value, DueFilterOverdue, DueFilterToday, DueFilterWeek,
+	))
+}
+
+// CompletionPolicy godoc
+//
+// Defines what happens to the open subtasks of an item when the item is completed.
+type CompletionPolicy string
+
+const (
+	// CompletionPolicyRefuse refuses to complete an item while it has open subtasks.
+	CompletionPolicyRefuse CompletionPolicy = "refuse"
+	// CompletionPolicyCascade completes the open subtasks of an item along with the item.
+	CompletionPolicyCascade CompletionPolicy = "cascade"
+)
+
+// ParseCompletionPolicy godoc
+//
+// Converts a string into a CompletionPolicy.
+//
+// Returns CompletionPolicyRefuse and error when the value is not a known policy.
+//
+// Returns the matching CompletionPolicy and nil on success.
+func ParseCompletionPolicy(value string) (CompletionPolicy, error) {
+	switch policy := CompletionPolicy(value); policy {
+	case CompletionPolicyRefuse, CompletionPolicyCascade:
+		return policy, nil
+	}
+	return CompletionPolicyRefuse, fmt.Errorf("ParseCompletionPolicy: %w", newValidationError(
+		"completion policy", "'%s' is not one of %s or %s", value, CompletionPolicyRefuse, CompletionPolicyCascade,
+	))
+}
+
+// SortOrder godoc
+//
+// Defines the order of listed items. Open items are always listed before completed items.
+type SortOrder string
+
+const (
+	// SortOrderPriority lists items by descending priority, then by the closest due date. This is the default.
+	SortOrderPriority SortOrder = "priority"
+	// SortOrderDue lists items by the closest due date, then by descending priority.
+	SortOrderDue SortOrder = "due"
+	// SortOrderCreated lists the most recently created items first.
+	SortOrderCreated SortOrder = "created"
+	// SortOrderUpdated lists the most recently updated items first.
+	SortOrderUpdated SortOrder = "updated"
+	// SortOrderName lists items by name, ignoring case.
+	SortOrderName SortOrder = "name"
+	// SortOrderId lists items in the order they were created, by ascending ID.
+	SortOrderId SortOrder = "id"
+)
+
+// SortOrders godoc
+//
+// Lists the supported sort orders in the order they are documented.
+var SortOrders = []SortOrder{
+	SortOrderPriority, SortOrderDue, SortOrderCreated, SortOrderUpdated, SortOrderName, SortOrderId,
+}
+
+// SortKey godoc
+//
+// Defines one field of a sort specification such as `due:desc,name`.
+type SortKey struct {
+	Order SortOrder
+	// Descending reverses the order of the field. Items without a due date are listed last in both directions.
+	Descending bool
+}
+
+// String godoc
+//
+// Returns the key as written in a sort specification, with its direction only when it is not the default one.
+func (k SortKey) String() string {
+	if k.Descending == k.Order.descendingByDefault() {
+		return string(k.Order)
+	}
+	if k.Descending {
+		return string(k.Order) + ":desc"
+	}
+	return string(k.Order) + ":asc"
+}
+
+// descendingByDefault godoc
+//
+// Returns true for the sort orders which list the highest priority or the most recent dates first when no direction
+// is given.
+func (o SortOrder) descendingByDefault() bool {
+	return o == SortOrderPriority || o == SortOrderCreated || o == SortOrderUpdated
+}
+
+// ParseSortOrder godoc
+//
+// Converts a string into a SortOrder. An empty string selects SortOrderPriority.
+//
+// Returns SortOrderPriority and error when the value is not a known sort order.
+//
+// Returns the matching SortOrder and nil on success.
+func ParseSortOrder(value string) (SortOrder, error) {
+	normalized := SortOrder(strings.ToLower(strings.TrimSpace(value)))
+	if normalized == "" {
+		return SortOrderPriority, nil
+	}
+	for _, order := range SortOrders {
+		if normalized == order {
+			return order, nil
+		}
+	}
+	var names []string
+	for _, order := range SortOrders {
+		names = append(names, string(order))
+	}
+	return SortOrderPriority, fmt.Errorf("ParseSortOrder: %w", newValidationError(
+		"sort order", "'%s' is not one of %s", value, strings.Join(names, ", "),
+	))
+}
+
+// ParseSortKeys godoc
+//
+// Converts a sort specification into its keys. The specification is a comma-separated list of sort orders, each
+// optionally followed by `:asc` or `:desc`, e.g. `due:desc,name`. An empty specification returns no keys, which
+// selects the default order.
+//
+// Returns nil and error wrapping ErrValidation when a field or a direction is unknown, or when a field is repeated.
+//
+// Returns the keys and nil on success.
+func ParseSortKeys(value string) ([]SortKey, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var keys []SortKey
+	seen := map[SortOrder]bool{}
+	for _, part := range strings.Split(value, ",") {
+		field, direction, hasDirection := strings.Cut(strings.TrimSpace(part), ":")
+		if strings.TrimSpace(field) == "" {
+			return nil, fmt.Errorf("ParseSortKeys: %w", newValidationError(
+				"sort order", "'%s' has an empty field", value,
+			))
+		}
+		order, err := ParseSortOrder(field)
+		if err != nil {
+			return nil, fmt.Errorf("ParseSortKeys: %w", err)
+		}
+		if seen[order] {
+			return nil, fmt.Errorf("ParseSortKeys: %w", newValidationError(
+				"sort order", "'%s' is listed more than once", order,
+			))
+		}
+		seen[order] = true
+
+		key := SortKey{Order: order, Descending: order.descendingByDefault()}
+		if hasDirection {
+			switch strings.ToLower(strings.TrimSpace(direction)) {
+			case "asc":
+				key.Descending = false
+			case "desc":
+				key.Descending = true
+			default:
+				return nil, fmt.Errorf("ParseSortKeys: %w", newValidationError(
+					"sort order", "'%s' is not a direction of %s, expected asc or desc", direction, order,
+				))
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// FormatSortKeys godoc
+//
+// Returns the sort specification of the keys in its shortest form, which ParseSortKeys converts back into the keys.
+func FormatSortKeys(keys []SortKey) string {
+	var parts []string
+	for _, key := range keys {
+		parts = append(parts, key.String())
+	}
+	return strings.Join(parts, ",")
 }
 
 // defaultDomain godoc
 //
 // A structure which adheres to the Domain interface.
-type defaultDomain struct{}
+type defaultDomain struct {
+	clock clock.Clock
+}
 
 // NewDomain godoc
 //
-// Creates a new todo Domain instance.
+// Creates a new todo Domain instance which uses the system clock.
 func NewDomain() Domain {
-	return &defaultDomain{}
+	return NewDomainWithClock(clock.NewSystemClock())
+}
+
+// NewDomainWithClock godoc
+//
+// Creates a new todo Domain instance which reads the current time from the passed in clock.
+func NewDomainWithClock(c clock.Clock) Domain {
+	return &defaultDomain{
+		clock: c,
+	}
 }
 
 // CreateItem godoc
 //
-// Creates a new todo Item instance and returns it.
+// Creates a new todo Item instance from the draft and returns it.
 //
-// Returns nil and error if name is an empty string.
+// Returns nil and error if the name is an empty string, the priority is unknown, a tag is invalid, the parent ID
+// is negative or the recurrence is not supported.
 //
 // Returns a new Item and nil on success.
-func (d *defaultDomain) CreateItem(name string) (Item, error) {
-	if len(name) == 0 {
-		return nil, fmt.Errorf("CreateItem: `name` cannot be empty")
+func (d *defaultDomain) CreateItem(draft ItemDraft) (Item, error) {
+	if len(draft.Name) == 0 {
+		return nil, fmt.Errorf("CreateItem: %w", newValidationError("name", "cannot be empty"))
 	}
-	nowTime := time.Now()
-	return NewItem(
-		0,
-		name,
+	if !isValidPriority(draft.Priority) {
+		return nil, fmt.Errorf("CreateItem: %w", newValidationError("priority", "unknown priority %d", draft.Priority))
+	}
+	tags, err := d.NormalizeTags(draft.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("CreateItem: %w", err)
+	}
+	if draft.ParentId < 0 {
+		return nil, fmt.Errorf("CreateItem: %w", newValidationError("parent", "invalid parent ID %d", draft.ParentId))
+	}
+	var rule recurrence.Rule
+	if draft.Recurrence != "" {
+		rule, err = recurrence.Parse(draft.Recurrence)
+		if err != nil {
+			return nil, fmt.Errorf("CreateItem: %w", newValidationError("recurrence", "%v", err))
+		}
+	}
+	nowTime := d.clock.Now()
+	item := NewItem(
 		0,
+		draft.Name,
+		StatusTodo,
+		draft.DueAt,
 		nowTime,
 		nowTime,
-	), nil
+	)
+	item.SetPriority(draft.Priority)
+	item.SetTags(tags)
+	item.SetProjectId(draft.ProjectId)
+	item.SetParentId(draft.ParentId)
+	item.SetRecurrence(rule.String())
+	item.SetDescription(normalizeDescription(draft.Description))
+	return item, nil
 }
 
-// GetTabularItemList godoc
-//
-// Returns a string representation of a tabular list of the items that are passed in.
-//
-// Returns empty string and error on error writing the list with the tab writer.
+// CreateJournalEntry godoc
 //
-// Returns "No todo items..." and nil when items is an empty slice.
+// Creates the journal entry of an operation which made the changes, recorded at the current time.
+func (d *defaultDomain) CreateJournalEntry(summary string, changes []JournalChange) JournalEntry {
+	return JournalEntry{Summary: summary, Changes: changes, CreatedAt: d.clock.Now()}
+}
+
+// CreateItemEvents godoc
 //
-// Returns items in a tabular format and nil on success.
-func (d *defaultDomain) GetTabularItemList(items []Item) (string, error) {
-	if items == nil || len(items) == 0 {
-		return fmt.Sprintf("No todo items...\n"), nil
+// Creates an event for each field changed by the changes of items, made by origin at the current time. The events
+// of an item follow the order of the changes, then the order of the fields.
+func (d *defaultDomain) CreateItemEvents(origin string, changes []JournalChange) []ItemEvent {
+	now := d.clock.Now()
+	var events []ItemEvent
+	for _, change := range changes {
+		for _, event := range diffItemSnapshots(change.ItemId, change.Before, change.After) {
+			event.Origin = origin
+			event.CreatedAt = now
+			events = append(events, event)
+		}
 	}
+	return events
+}
 
-	var buffer bytes.Buffer
-
-	padding := 4
-	tabWidth := 4
-	tw := tabwriter.NewWriter(&buffer, 0, tabWidth, padding, ' ', 0)
+// GetDueItemFilter godoc
+//
+// Returns the ItemFilter which selects the items matching the due date view, relative to the current time.
+//
+// Returns an empty ItemFilter and error when the due filter is unknown.
+//
+// Returns the ItemFilter and nil on success.
+func (d *defaultDomain) GetDueItemFilter(dueFilter DueFilter) (ItemFilter, error) {
+	nowTime := d.clock.Now()
+	startOfToday := time.Date(nowTime.Year(), nowTime.Month(), nowTime.Day(), 0, 0, 0, 0, nowTime.Location())
 
-	// Write header to the tabWriter
-	_, err := fmt.Fprintln(tw, "ID\tName\tLast Updated\tCreated\tIs Completed")
-	if err != nil {
-		return "", fmt.Errorf("GetTabularItemList: Error writing table header to tabWriter: %v", err)
+	switch dueFilter {
+	case DueFilterNone:
+		return ItemFilter{}, nil
+	case DueFilterOverdue:
+		return ItemFilter{OpenOnly: true, DueBefore: nowTime}, nil
+	case DueFilterToday:
+		return ItemFilter{OpenOnly: true, DueFrom: startOfToday, DueBefore: startOfToday.AddDate(0, 0, 1)}, nil
+	case DueFilterWeek:
+		// Weeks run from Monday to Sunday
+		daysUntilMonday := (8 - int(startOfToday.Weekday())) % 7
+		if daysUntilMonday == 0 {
+			daysUntilMonday = 7
+		}
+		return ItemFilter{
+			OpenOnly:  true,
+			DueFrom:   startOfToday,
+			DueBefore: startOfToday.AddDate(0, 0, daysUntilMonday),
+		}, nil
 	}
+	return ItemFilter{}, fmt.Errorf(
+		"GetDueItemFilter: %w", newValidationError("due filter", "unknown due filter '%s'", dueFilter),
+	)
+}
 
-	// Write separator
-	_, err = fmt.Fprintln(tw, "--\t----\t------------\t-------\t------------")
-	if err != nil {
-		return "", fmt.Errorf("GetTabularItemList: Error writing table header to tabWriter: %v", err)
+// NormalizeTag godoc
+//
+// Converts a tag name into its stored form: trimmed and lower case.
+//
+// Returns empty string and error when the tag is empty or contains whitespace or commas.
+//
+// Returns the normalized tag and nil on success.
+func (d *defaultDomain) NormalizeTag(tag string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(tag))
+	if len(normalized) == 0 {
+		return "", fmt.Errorf("NormalizeTag: %w", newValidationError("tag", "cannot be empty"))
 	}
-
-	for _, item := range items {
-		completedIcon := "❌"
-		if item.GetIsCompleted() == 1 {
-			completedIcon = "✅"
-		}
-		formatting := "%d\t%s\t%s\t%s\t%s\n"
-		_, err := fmt.Fprintf(
-			tw,
-			formatting,
-			item.GetId(),
-			item.GetName(),
-			item.GetUpdatedAt().Format(time.DateTime),
-			item.GetCreatedAt().Format(time.DateTime),
-			completedIcon,
+	if strings.ContainsFunc(normalized, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		return "", fmt.Errorf(
+			"NormalizeTag: %w", newValidationError("tag", "'%s' cannot contain whitespace or commas", tag),
 		)
-		if err != nil {
-			return "", fmt.Errorf(
-				"GetTabularItemList: Error writing item %d: %v",
-				item.GetId(), err,
-			)
-		}
 	}
+	return normalized, nil
+}
 
-	if err := tw.Flush(); err != nil {
-		return "", fmt.Errorf(
-			"GetTabularItemList: Failed to flush tabWriter: %d", err,
-		)
+// NormalizeTags godoc
+//
+// Normalizes each tag with NormalizeTag, removes duplicates and sorts the result.
+//
+// Returns nil and error when a tag is invalid.
+//
+// Returns the normalized tags and nil on success.
+func (d *defaultDomain) NormalizeTags(tags []string) ([]string, error) {
+	var normalizedTags []string
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		normalized, err := d.NormalizeTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("NormalizeTags: %w", err)
+		}
+		if !seen[normalized] {
+			seen[normalized] = true
+			normalizedTags = append(normalizedTags, normalized)
+		}
 	}
-	return buffer.String(), nil
+	sort.Strings(normalizedTags)
+	return normalizedTags, nil
 }
 
 // UpdateItemName godoc
@@ -122,28 +429,457 @@ func (d *defaultDomain) GetTabularItemList(items []Item) (string, error) {
 // Returns the updated item and nil on success.
 func (d *defaultDomain) UpdateItemName(name string, item Item) (Item, error) {
 	if len(name) == 0 {
-		return nil, fmt.Errorf("UpdateItemName: name is empty")
+		return nil, fmt.Errorf("UpdateItemName: %w", newValidationError("name", "cannot be empty"))
 	}
 	if item == nil {
 		return nil, fmt.Errorf("UpdateItemName: item is nil")
 	}
 	item.SetName(name)
-	item.SetUpdatedAt(time.Now())
+	item.SetUpdatedAt(d.clock.Now())
+	return item, nil
+}
+
+// UpdateItemDueAt godoc
+//
+// Updates the item due date. A zero dueAt removes the due date.
+//
+// Returns nil and error when the item is nil.
+//
+// Returns the updated item and nil on success.
+func (d *defaultDomain) UpdateItemDueAt(dueAt time.Time, item Item) (Item, error) {
+	if item == nil {
+		return nil, fmt.Errorf("UpdateItemDueAt: item is nil")
+	}
+	item.SetDueAt(dueAt)
+	item.SetUpdatedAt(d.clock.Now())
+	return item, nil
+}
+
+// UpdateItemPriority godoc
+//
+// Updates the item priority.
+//
+// Returns nil and error when the priority is unknown or when the item is nil.
+//
+// Returns the updated item and nil on success.
+func (d *defaultDomain) UpdateItemPriority(priority Priority, item Item) (Item, error) {
+	if !isValidPriority(priority) {
+		return nil, fmt.Errorf(
+			"UpdateItemPriority: %w", newValidationError("priority", "unknown priority %d", priority),
+		)
+	}
+	if item == nil {
+		return nil, fmt.Errorf("UpdateItemPriority: item is nil")
+	}
+	item.SetPriority(priority)
+	item.SetUpdatedAt(d.clock.Now())
+	return item, nil
+}
+
+// UpdateItemProject godoc
+//
+// Moves the item into the project with the passed in ID. A projectId of 0 removes the item from its project.
+//
+// Returns nil and error when the project ID is negative or when the item is nil.
+//
+// Returns the updated item and nil on success.
+func (d *defaultDomain) UpdateItemProject(projectId int64, item Item) (Item, error) {
+	if projectId < 0 {
+		return nil, fmt.Errorf(
+			"UpdateItemProject: %w", newValidationError("project", "invalid project ID %d", projectId),
+		)
+	}
+	if item == nil {
+		return nil, fmt.Errorf("UpdateItemProject: item is nil")
+	}
+	item.SetProjectId(projectId)
+	item.SetUpdatedAt(d.clock.Now())
+	return item, nil
+}
+
+// UpdateItemParent godoc
+//
+// Makes the item a subtask of the item with the passed in ID. A parentId of 0 makes the item a top level item.
+//
+// parentLineage holds the ID of the new parent followed by the IDs of its own parents, and is used to prevent
+// cycles: an item cannot become a subtask of itself or of one of its subtasks.
+//
+// Returns nil and error when the parent ID is negative, when the change would create a cycle or when the item is
+// nil.
+//
+// Returns the updated item and nil on success.
+func (d *defaultDomain) UpdateItemParent(parentId int64, parentLineage []int64, item Item) (Item, error) {
+	if parentId < 0 {
+		return nil, fmt.Errorf("UpdateItemParent: %w", newValidationError("parent", "invalid parent ID %d", parentId))
+	}
+	if item == nil {
+		return nil, fmt.Errorf("UpdateItemParent: item is nil")
+	}
+	if parentId == item.GetId() || slices.Contains(parentLineage, item.GetId()) {
+		return nil, fmt.Errorf("UpdateItemParent: %w", newValidationError(
+			"parent", "item %d cannot be a subtask of item %d as it would create a cycle", item.GetId(), parentId,
+		))
+	}
+	item.SetParentId(parentId)
+	item.SetUpdatedAt(d.clock.Now())
 	return item, nil
 }
 
 // CompleteItem godoc
 //
-// Updates isCompleted on the item to 1 (true).
+// Moves the item to StatusDone, see UpdateItemStatus.
+//
+// Returns nil and error wrapping a *TransitionError when the item is already closed.
 //
 // Returns nil and error when the item is nil.
 //
 // Returns the updated item and nil on success.
 func (d *defaultDomain) CompleteItem(item Item) (Item, error) {
+	item, err := d.UpdateItemStatus(StatusDone, "", item)
+	if err != nil {
+		return nil, fmt.Errorf("CompleteItem: %w", err)
+	}
+	return item, nil
+}
+
+// statusTransitions godoc
+//
+// Statuses which an item can move to from each status. Blocked and waiting items can stay so with another reason,
+// while closed items must be reopened before they move on.
+var statusTransitions = map[Status][]Status{
+	StatusTodo:       {StatusInProgress, StatusBlocked, StatusWaiting, StatusDone, StatusCancelled},
+	StatusInProgress: {StatusTodo, StatusBlocked, StatusWaiting, StatusDone, StatusCancelled},
+	StatusBlocked:    {StatusTodo, StatusInProgress, StatusBlocked, StatusWaiting, StatusDone, StatusCancelled},
+	StatusWaiting:    {StatusTodo, StatusInProgress, StatusBlocked, StatusWaiting, StatusDone, StatusCancelled},
+	StatusDone:       {StatusTodo},
+	StatusCancelled:  {StatusTodo},
+}
+
+// UpdateItemStatus godoc
+//
+// Moves the item to a status, following the transitions of the workflow. The reason explains why the item is
+// blocked or waiting, it is dropped for the other statuses.
+//
+// Returns nil and error wrapping ErrValidation when the status is unknown.
+//
+// Returns nil and error wrapping a *TransitionError when the item cannot move from its status to status.
+//
+// Returns nil and error when the item is nil.
+//
+// Returns the updated item and nil on success.
+func (d *defaultDomain) UpdateItemStatus(status Status, reason string, item Item) (Item, error) {
 	if item == nil {
-		return nil, fmt.Errorf("CompleteItem: item is nil")
+		return nil, fmt.Errorf("UpdateItemStatus: item is nil")
+	}
+	if _, ok := statusTransitions[status]; !ok {
+		return nil, fmt.Errorf("UpdateItemStatus: %w", newValidationError("status", "unknown status '%s'", status))
+	}
+	if !slices.Contains(statusTransitions[item.GetStatus()], status) {
+		return nil, fmt.Errorf(
+			"UpdateItemStatus: %w", &TransitionError{ItemId: item.GetId(), From: item.GetStatus(), To: status},
+		)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if status != StatusBlocked && status != StatusWaiting {
+		reason = ""
 	}
-	item.SetIsCompleted(1)
-	item.SetUpdatedAt(time.Now())
+	item.SetStatus(status)
+	item.SetStatusReason(reason)
+	item.SetUpdatedAt(d.clock.Now())
 	return item, nil
 }
+
+// CompleteItemTree godoc
+//
+// Completes an item along with its subtasks, following the completion policy.
+//
+// Returns nil and error wrapping ErrOpenSubtasks when the policy is CompletionPolicyRefuse and one of the
+// descendants is still open.
+//
+// Returns nil and error wrapping a *TransitionError when the item is already closed.
+//
+// Returns nil and error when the item is nil or the policy is unknown.
+//
+// Returns the items to persist, the item first, and nil on success.
+func (d *defaultDomain) CompleteItemTree(item Item, descendants []Item, policy CompletionPolicy) ([]Item, error) {
+	if item == nil {
+		return nil, fmt.Errorf("CompleteItemTree: item is nil")
+	}
+
+	var openDescendants []Item
+	for _, descendant := range descendants {
+		if !descendant.GetStatus().IsClosed() {
+			openDescendants = append(openDescendants, descendant)
+		}
+	}
+
+	switch policy {
+	case CompletionPolicyRefuse:
+		if len(openDescendants) > 0 {
+			return nil, fmt.Errorf("CompleteItemTree: %w: %d left", ErrOpenSubtasks, len(openDescendants))
+		}
+	case CompletionPolicyCascade:
+	default:
+		return nil, fmt.Errorf("CompleteItemTree: unknown completion policy '%s'", policy)
+	}
+
+	completedItems := []Item{item}
+	completedItems = append(completedItems, openDescendants...)
+	for _, completedItem := range completedItems {
+		if _, err := d.CompleteItem(completedItem); err != nil {
+			return nil, fmt.Errorf("CompleteItemTree: %w", err)
+		}
+	}
+	return completedItems, nil
+}
+
+// CreateDependency godoc
+//
+// Creates the dependency of the item with itemId on the item with dependsOnId.
+//
+// dependencies holds the existing dependencies and is used to prevent cycles: an item cannot depend, directly or
+// through other items, on itself.
+//
+// Returns an empty Dependency and error wrapping ErrDependencyCycle when the dependency would create a cycle.
+//
+// Returns an empty Dependency and error when an ID is not positive.
+//
+// Returns the new Dependency and nil on success.
+func (d *defaultDomain) CreateDependency(itemId int64, dependsOnId int64, dependencies []Dependency) (Dependency, error) {
+	if itemId <= 0 || dependsOnId <= 0 {
+		return Dependency{}, fmt.Errorf(
+			"CreateDependency: %w: item IDs %d and %d must be positive", ErrInvalidID, itemId, dependsOnId,
+		)
+	}
+
+	dependsOn := map[int64][]int64{}
+	for _, dependency := range dependencies {
+		dependsOn[dependency.ItemId] = append(dependsOn[dependency.ItemId], dependency.DependsOnId)
+	}
+
+	// Walk the items which dependsOnId waits on, a cycle exists when itemId is one of them
+	visited := map[int64]bool{}
+	pending := []int64{dependsOnId}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if current == itemId {
+			return Dependency{}, fmt.Errorf(
+				"CreateDependency: %w: item %d already waits on item %d", ErrDependencyCycle, dependsOnId, itemId,
+			)
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		pending = append(pending, dependsOn[current]...)
+	}
+
+	return Dependency{ItemId: itemId, DependsOnId: dependsOnId}, nil
+}
+
+// SortItemsByDependencies godoc
+//
+// Orders the items topologically so that an item is listed after the items it depends on. Dependencies on items
+// which are not passed in are ignored.
+//
+// Items which do not depend on each other keep their relative order. Items caught in a cycle are appended in
+// their original order.
+func (d *defaultDomain) SortItemsByDependencies(items []Item, dependencies []Dependency) []Item {
+	present := map[int64]bool{}
+	for _, item := range items {
+		present[item.GetId()] = true
+	}
+	waitingOn := map[int64]int{}
+	dependents := map[int64][]int64{}
+	for _, dependency := range dependencies {
+		if present[dependency.ItemId] && present[dependency.DependsOnId] {
+			waitingOn[dependency.ItemId]++
+			dependents[dependency.DependsOnId] = append(dependents[dependency.DependsOnId], dependency.ItemId)
+		}
+	}
+
+	// Repeatedly take the first item, in the original order, which no longer waits on another item
+	sorted := make([]Item, 0, len(items))
+	placed := map[int64]bool{}
+	for len(sorted) < len(items) {
+		next := -1
+		for index, item := range items {
+			if !placed[item.GetId()] && waitingOn[item.GetId()] == 0 {
+				next = index
+				break
+			}
+		}
+		if next == -1 {
+			break
+		}
+		item := items[next]
+		placed[item.GetId()] = true
+		sorted = append(sorted, item)
+		for _, dependentId := range dependents[item.GetId()] {
+			waitingOn[dependentId]--
+		}
+	}
+
+	for _, item := range items {
+		if !placed[item.GetId()] {
+			sorted = append(sorted, item)
+		}
+	}
+	return sorted
+}
+
+// UpdateItemRecurrence godoc
+//
+// Updates the recurrence rule of the item from a recurrence expression. An empty expression stops the item from
+// repeating.
+//
+// Returns nil and error when the expression is not supported or when the item is nil.
+//
+// Returns the updated item and nil on success.
+func (d *defaultDomain) UpdateItemRecurrence(expr string, item Item) (Item, error) {
+	if item == nil {
+		return nil, fmt.Errorf("UpdateItemRecurrence: item is nil")
+	}
+	var rule recurrence.Rule
+	if expr != "" {
+		var err error
+		rule, err = recurrence.Parse(expr)
+		if err != nil {
+			return nil, fmt.Errorf("UpdateItemRecurrence: %w", newValidationError("recurrence", "%v", err))
+		}
+	}
+	item.SetRecurrence(rule.String())
+	item.SetUpdatedAt(d.clock.Now())
+	return item, nil
+}
+
+// CreateNextOccurrence godoc
+//
+// Creates the next occurrence of a recurring item. The occurrence keeps the name, priority, tags, project, parent
+// and notes of the item, and the recurrence moves from the item to the occurrence so that only the latest occurrence
+// of a series repeats.
+//
+// The occurrence is due at the first date of the rule after the due date of the item which is still in the future.
+// When the item has no due date, the rule starts from the current time.
+//
+// Returns nil and nil when the item does not repeat.
+//
+// Returns nil and error when the item is nil or its recurrence is invalid.
+//
+// Returns the new Item and nil on success.
+func (d *defaultDomain) CreateNextOccurrence(item Item) (Item, error) {
+	if item == nil {
+		return nil, fmt.Errorf("CreateNextOccurrence: item is nil")
+	}
+	if item.GetRecurrence() == "" {
+		return nil, nil
+	}
+	rule, err := recurrence.Parse(item.GetRecurrence())
+	if err != nil {
+		return nil, fmt.Errorf("CreateNextOccurrence: %v", err)
+	}
+
+	nowTime := d.clock.Now()
+	from := item.GetDueAt()
+	if from.IsZero() {
+		from = nowTime
+	}
+	occurrence := NewItem(0, item.GetName(), StatusTodo, rule.NextAfter(from, nowTime), nowTime, nowTime)
+	occurrence.SetPriority(item.GetPriority())
+	occurrence.SetTags(item.GetTags())
+	occurrence.SetProjectId(item.GetProjectId())
+	occurrence.SetParentId(item.GetParentId())
+	occurrence.SetRecurrence(item.GetRecurrence())
+	occurrence.SetDescription(item.GetDescription())
+	item.SetRecurrence("")
+	return occurrence, nil
+}
+
+// UpdateItemDescription godoc
+//
+// Updates the notes of the item. Trailing whitespace is removed and an empty description removes the notes.
+//
+// Returns nil and error when the item is nil.
+//
+// Returns the updated item and nil on success.
+func (d *defaultDomain) UpdateItemDescription(description string, item Item) (Item, error) {
+	if item == nil {
+		return nil, fmt.Errorf("UpdateItemDescription: item is nil")
+	}
+	item.SetDescription(normalizeDescription(description))
+	item.SetUpdatedAt(d.clock.Now())
+	return item, nil
+}
+
+// TrashItem godoc
+//
+// Moves the item to the trash by setting deletedAt to the current time. updatedAt is kept, so the item is restored
+// as it was.
+//
+// Returns nil and error when the item is nil.
+//
+// Returns the updated item and nil on success.
+func (d *defaultDomain) TrashItem(item Item) (Item, error) {
+	if item == nil {
+		return nil, fmt.Errorf("TrashItem: item is nil")
+	}
+	item.SetDeletedAt(d.clock.Now())
+	return item, nil
+}
+
+// RestoreTrashedItem godoc
+//
+// Takes the item out of the trash by clearing deletedAt.
+//
+// Returns nil and error when the item is nil.
+//
+// Returns the updated item and nil on success.
+func (d *defaultDomain) RestoreTrashedItem(item Item) (Item, error) {
+	if item == nil {
+		return nil, fmt.Errorf("RestoreTrashedItem: item is nil")
+	}
+	item.SetDeletedAt(time.Time{})
+	return item, nil
+}
+
+// ParseFilter godoc
+//
+// Parse a filter expression, resolving relative dates such as today or +7d from the clock of the domain.
+//
+// Returns nil and error wrapping a *FilterError when the expression is invalid.
+//
+// Returns the parsed expression and nil on success.
+func (d *defaultDomain) ParseFilter(expression string) (FilterExpression, error) {
+	filter, err := ParseFilter(expression, dateparse.NewParser(d.clock))
+	if err != nil {
+		return nil, fmt.Errorf("ParseFilter: %w", err)
+	}
+	return filter, nil
+}
+
+// normalizeDescription godoc
+//
+// Removes the trailing whitespace of the notes, including the final newline added by most editors.
+func normalizeDescription(description string) string {
+	return strings.TrimRightFunc(description, unicode.IsSpace)
+}
+
+// describeRecurrence godoc
+//
+// Returns the human-readable description of a stored recurrence rule, or the rule itself when it cannot be parsed.
+func describeRecurrence(value string) string {
+	rule, err := recurrence.Parse(value)
+	if err != nil {
+		return value
+	}
+	return rule.Describe()
+}
+
+// isValidPriority godoc
+//
+// Returns true when the priority is one of the known priorities.
+func isValidPriority(priority Priority) bool {
+	return priority >= PriorityNone && priority <= PriorityUrgent
+}
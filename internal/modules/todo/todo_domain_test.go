@@ -1,81 +1,78 @@
 package todo
 
 import (
+	"github.com/rykeroc/todo-cli/internal/clock"
 	"github.com/stretchr/testify/assert"
-	"strconv"
 	"testing"
 	"time"
 )
 
-var domain = NewDomain()
+// testNow is a Wednesday
+var testNow = time.Date(2026, time.October, 14, 10, 30, 0, 0, time.Local)
+
+var domain = NewDomainWithClock(clock.NewFixedClock(testNow))
 
 func TestDefaultDomain_CreateItem(t *testing.T) {
 	t.Run("should create new item", func(t *testing.T) {
 		name := "new item"
 
-		item, err := domain.CreateItem(name)
+		item, err := domain.CreateItem(ItemDraft{Name: name})
 
 		assert.NoError(t, err)
 		assert.Equal(t, name, item.GetName())
+		assert.Equal(t, testNow, item.GetCreatedAt())
+		assert.Equal(t, testNow, item.GetUpdatedAt())
 	})
 
 	t.Run("should return error because of empty name", func(t *testing.T) {
 		name := ""
 
-		item, err := domain.CreateItem(name)
+		item, err := domain.CreateItem(ItemDraft{Name: name})
 
 		assert.Error(t, err)
 		assert.Nil(t, item)
 	})
-}
 
-func TestDefaultDomain_GetItemList(t *testing.T) {
-	t.Run("should return tabular list", func(t *testing.T) {
-		nowTime := time.Now()
-		items := []Item{
-			NewItem(1, "item 1", 0, nowTime, nowTime),
-		}
-		result, err := domain.GetTabularItemList(items)
+	t.Run("should create new item with due date and priority", func(t *testing.T) {
+		dueAt := testNow.AddDate(0, 0, 1)
+
+		item, err := domain.CreateItem(ItemDraft{Name: "name", DueAt: dueAt, Priority: PriorityUrgent})
+
 		assert.NoError(t, err)
-		assert.NotEmpty(t, result)
+		assert.Equal(t, dueAt, item.GetDueAt())
+		assert.Equal(t, PriorityUrgent, item.GetPriority())
+	})
 
-		assert.Contains(
-			t, result, strconv.FormatInt(items[0].GetId(), 10),
-		)
-		assert.Contains(
-			t, result, items[0].GetName(),
-		)
-		assert.Contains(
-			t, result, items[0].GetUpdatedAt().Format(time.DateOnly),
-		)
-		assert.Contains(
-			t, result, items[0].GetCreatedAt().Format(time.DateOnly),
-		)
+	t.Run("should return error because of unknown priority", func(t *testing.T) {
+		item, err := domain.CreateItem(ItemDraft{Name: "name", Priority: Priority(9)})
+
+		assert.Error(t, err)
+		assert.Nil(t, item)
 	})
 
-	t.Run("should return 'No todo items...' when no items is nil", func(t *testing.T) {
-		result, err := domain.GetTabularItemList(nil)
+	t.Run("should create new recurring item", func(t *testing.T) {
+		item, err := domain.CreateItem(ItemDraft{Name: "name", Recurrence: "every mon,thu"})
 
 		assert.NoError(t, err)
-		assert.Contains(t, result, "No todo items...")
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,TH", item.GetRecurrence())
 	})
 
-	t.Run("should return 'No todo items...' when no items is empty", func(t *testing.T) {
-		result, err := domain.GetTabularItemList([]Item{})
+	t.Run("should return error because of unsupported recurrence", func(t *testing.T) {
+		item, err := domain.CreateItem(ItemDraft{Name: "name", Recurrence: "sometimes"})
 
-		assert.NoError(t, err)
-		assert.Contains(t, result, "No todo items...")
+		assert.Error(t, err)
+		assert.Nil(t, item)
 	})
 }
 
 func TestDefaultDomain_UpdateItem(t *testing.T) {
 	t.Run("should update name and updated time in item", func(t *testing.T) {
 		initName := "init name"
-		initCreated := time.Now()
-		initUpdated := time.Now()
+		initCreated := testNow.Add(-time.Hour)
+		initUpdated := testNow.Add(-time.Hour)
 
 		item := NewItem(
-			0, initName, 0, initCreated, initUpdated,
+			0, initName, StatusTodo, time.Time{}, initCreated, initUpdated,
 		)
 
 		newName := "new name"
@@ -84,17 +81,17 @@ func TestDefaultDomain_UpdateItem(t *testing.T) {
 
 		assert.NoError(t, err)
 		assert.Equal(t, newName, item.GetName())
-		assert.Less(t, initUpdated, item.GetUpdatedAt())
+		assert.Equal(t, testNow, item.GetUpdatedAt())
 		assert.Equal(t, initCreated.Unix(), item.GetCreatedAt().Unix())
 	})
 
 	t.Run("should return error when empty name", func(t *testing.T) {
 		initName := "init name"
-		initCreated := time.Now()
-		initUpdated := time.Now()
+		initCreated := testNow.Add(-time.Hour)
+		initUpdated := testNow.Add(-time.Hour)
 
 		item := NewItem(
-			0, initName, 0, initCreated, initUpdated,
+			0, initName, StatusTodo, time.Time{}, initCreated, initUpdated,
 		)
 
 		newName := ""
@@ -114,3 +111,619 @@ func TestDefaultDomain_UpdateItem(t *testing.T) {
 		assert.Nil(t, item)
 	})
 }
+
+func TestDefaultDomain_UpdateItemDueAt(t *testing.T) {
+	t.Run("should update due date and updated time in item", func(t *testing.T) {
+		initUpdated := testNow.Add(-time.Hour)
+		item := NewItem(0, "name", StatusTodo, time.Time{}, initUpdated, initUpdated)
+
+		dueAt := testNow.Add(24 * time.Hour)
+		item, err := domain.UpdateItemDueAt(dueAt, item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dueAt, item.GetDueAt())
+		assert.Equal(t, testNow, item.GetUpdatedAt())
+	})
+
+	t.Run("should remove due date when zero", func(t *testing.T) {
+		item := NewItem(0, "name", StatusTodo, testNow, testNow, testNow)
+
+		item, err := domain.UpdateItemDueAt(time.Time{}, item)
+
+		assert.NoError(t, err)
+		assert.True(t, item.GetDueAt().IsZero())
+	})
+
+	t.Run("should return error when item is nil", func(t *testing.T) {
+		item, err := domain.UpdateItemDueAt(testNow, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+}
+
+func TestDefaultDomain_NormalizeTags(t *testing.T) {
+	t.Run("should lower case, deduplicate and sort tags", func(t *testing.T) {
+		tags, err := domain.NormalizeTags([]string{"Urgent", " backend ", "urgent"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"backend", "urgent"}, tags)
+	})
+
+	t.Run("should return error on invalid tag", func(t *testing.T) {
+		for _, tag := range []string{"", "  ", "two words", "a,b"} {
+			tags, err := domain.NormalizeTags([]string{tag})
+
+			assert.Error(t, err)
+			assert.Nil(t, tags)
+		}
+	})
+
+	t.Run("should create item with normalized tags", func(t *testing.T) {
+		item, err := domain.CreateItem(ItemDraft{Name: "name", Tags: []string{"Ops", "backend"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"backend", "ops"}, item.GetTags())
+	})
+}
+
+func TestDefaultDomain_UpdateItemPriority(t *testing.T) {
+	t.Run("should update priority and updated time in item", func(t *testing.T) {
+		item := NewItem(0, "name", StatusTodo, time.Time{}, testNow.Add(-time.Hour), testNow.Add(-time.Hour))
+
+		item, err := domain.UpdateItemPriority(PriorityHigh, item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, PriorityHigh, item.GetPriority())
+		assert.Equal(t, testNow, item.GetUpdatedAt())
+	})
+
+	t.Run("should return error when priority is unknown", func(t *testing.T) {
+		item := NewItem(0, "name", StatusTodo, time.Time{}, testNow, testNow)
+
+		item, err := domain.UpdateItemPriority(Priority(-1), item)
+
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+
+	t.Run("should return error when item is nil", func(t *testing.T) {
+		item, err := domain.UpdateItemPriority(PriorityLow, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+}
+
+func TestDefaultDomain_UpdateItemProject(t *testing.T) {
+	t.Run("should move item into project", func(t *testing.T) {
+		item := NewItem(0, "name", StatusTodo, time.Time{}, testNow.Add(-time.Hour), testNow.Add(-time.Hour))
+
+		item, err := domain.UpdateItemProject(2, item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), item.GetProjectId())
+		assert.Equal(t, testNow, item.GetUpdatedAt())
+	})
+
+	t.Run("should return error when project ID is negative or item is nil", func(t *testing.T) {
+		item, err := domain.UpdateItemProject(-1, NewItem(0, "name", StatusTodo, time.Time{}, testNow, testNow))
+		assert.Error(t, err)
+		assert.Nil(t, item)
+
+		item, err = domain.UpdateItemProject(1, nil)
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+}
+
+func TestDefaultDomain_GetDueItemFilter(t *testing.T) {
+	t.Run("should not filter when no due filter", func(t *testing.T) {
+		filter, err := domain.GetDueItemFilter(DueFilterNone)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ItemFilter{}, filter)
+	})
+
+	t.Run("should only keep open items due before now when overdue", func(t *testing.T) {
+		filter, err := domain.GetDueItemFilter(DueFilterOverdue)
+
+		assert.NoError(t, err)
+		assert.True(t, filter.OpenOnly)
+		assert.True(t, filter.DueFrom.IsZero())
+		assert.Equal(t, testNow, filter.DueBefore)
+	})
+
+	t.Run("should cover the current day when today", func(t *testing.T) {
+		filter, err := domain.GetDueItemFilter(DueFilterToday)
+
+		assert.NoError(t, err)
+		assert.True(t, filter.OpenOnly)
+		assert.Equal(t, time.Date(2026, time.October, 14, 0, 0, 0, 0, time.Local), filter.DueFrom)
+		assert.Equal(t, time.Date(2026, time.October, 15, 0, 0, 0, 0, time.Local), filter.DueBefore)
+	})
+
+	t.Run("should end on a monday when week", func(t *testing.T) {
+		filter, err := domain.GetDueItemFilter(DueFilterWeek)
+
+		assert.NoError(t, err)
+		assert.True(t, filter.OpenOnly)
+		assert.Equal(t, time.Date(2026, time.October, 14, 0, 0, 0, 0, time.Local), filter.DueFrom)
+		assert.Equal(t, time.Date(2026, time.October, 19, 0, 0, 0, 0, time.Local), filter.DueBefore)
+	})
+
+	t.Run("should return error on unknown due filter", func(t *testing.T) {
+		_, err := domain.GetDueItemFilter("someday")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultDomain_CompleteItem(t *testing.T) {
+	t.Run("should complete item and set updated time", func(t *testing.T) {
+		item := NewItem(0, "name", StatusTodo, time.Time{}, testNow.Add(-time.Hour), testNow.Add(-time.Hour))
+
+		item, err := domain.CompleteItem(item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusDone, item.GetStatus())
+		assert.Equal(t, testNow, item.GetUpdatedAt())
+	})
+
+	t.Run("should return conflict error when item is closed", func(t *testing.T) {
+		item, err := domain.CompleteItem(NewItem(1, "name", StatusCancelled, time.Time{}, testNow, testNow))
+
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Nil(t, item)
+	})
+
+	t.Run("should return error when item is nil", func(t *testing.T) {
+		item, err := domain.CompleteItem(nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+}
+
+func TestDefaultDomain_UpdateItemStatus(t *testing.T) {
+	t.Run("should move the item through the workflow and set updated time", func(t *testing.T) {
+		item := NewItem(1, "name", StatusTodo, time.Time{}, testNow.Add(-time.Hour), testNow.Add(-time.Hour))
+
+		for _, status := range []Status{StatusInProgress, StatusWaiting, StatusCancelled, StatusTodo, StatusDone} {
+			var err error
+			item, err = domain.UpdateItemStatus(status, "", item)
+			assert.NoError(t, err)
+			assert.Equal(t, status, item.GetStatus())
+		}
+		assert.Equal(t, testNow, item.GetUpdatedAt())
+	})
+
+	t.Run("should keep the reason of blocked and waiting items only", func(t *testing.T) {
+		item := NewItem(1, "name", StatusTodo, time.Time{}, testNow, testNow)
+
+		item, err := domain.UpdateItemStatus(StatusBlocked, "  waiting on the API key ", item)
+		assert.NoError(t, err)
+		assert.Equal(t, "waiting on the API key", item.GetStatusReason())
+
+		item, err = domain.UpdateItemStatus(StatusBlocked, "waiting on review", item)
+		assert.NoError(t, err)
+		assert.Equal(t, "waiting on review", item.GetStatusReason())
+
+		item, err = domain.UpdateItemStatus(StatusInProgress, "ignored", item)
+		assert.NoError(t, err)
+		assert.Equal(t, "", item.GetStatusReason())
+	})
+
+	t.Run("should refuse transitions outside of the workflow", func(t *testing.T) {
+		testCases := []struct {
+			from Status
+			to   Status
+		}{
+			{StatusTodo, StatusTodo},
+			{StatusInProgress, StatusInProgress},
+			{StatusDone, StatusDone},
+			{StatusDone, StatusInProgress},
+			{StatusCancelled, StatusDone},
+			{StatusCancelled, StatusBlocked},
+		}
+		for _, test := range testCases {
+			item, err := domain.UpdateItemStatus(test.to, "", NewItem(1, "name", test.from, time.Time{}, testNow, testNow))
+
+			var transitionErr *TransitionError
+			assert.ErrorAs(t, err, &transitionErr)
+			assert.ErrorIs(t, err, ErrConflict)
+			assert.Equal(t, TransitionError{ItemId: 1, From: test.from, To: test.to}, *transitionErr)
+			assert.Nil(t, item)
+		}
+	})
+
+	t.Run("should return validation error when the status is unknown", func(t *testing.T) {
+		item, err := domain.UpdateItemStatus("archived", "", NewItem(1, "name", StatusTodo, time.Time{}, testNow, testNow))
+
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.Nil(t, item)
+	})
+
+	t.Run("should return error when item is nil", func(t *testing.T) {
+		item, err := domain.UpdateItemStatus(StatusDone, "", nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+}
+
+func TestParseDueFilter(t *testing.T) {
+	for _, value := range []string{"", "overdue", "today", "week"} {
+		dueFilter, err := ParseDueFilter(value)
+		assert.NoError(t, err)
+		assert.Equal(t, DueFilter(value), dueFilter)
+	}
+
+	_, err := ParseDueFilter("someday")
+	assert.Error(t, err)
+}
+
+func TestDefaultDomain_UpdateItemParent(t *testing.T) {
+	t.Run("should make item a subtask", func(t *testing.T) {
+		item := NewItem(1, "name", StatusTodo, time.Time{}, testNow.Add(-time.Hour), testNow.Add(-time.Hour))
+
+		item, err := domain.UpdateItemParent(2, []int64{2, 3}, item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), item.GetParentId())
+		assert.Equal(t, testNow, item.GetUpdatedAt())
+	})
+
+	t.Run("should make item a top level item", func(t *testing.T) {
+		item := NewItem(1, "name", StatusTodo, time.Time{}, testNow, testNow)
+		item.SetParentId(2)
+
+		item, err := domain.UpdateItemParent(0, nil, item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), item.GetParentId())
+	})
+
+	t.Run("should return error when the change would create a cycle", func(t *testing.T) {
+		item, err := domain.UpdateItemParent(1, []int64{1}, NewItem(1, "name", StatusTodo, time.Time{}, testNow, testNow))
+		assert.Error(t, err)
+		assert.Nil(t, item)
+
+		// Item 3 is a subtask of item 2 which is a subtask of item 1
+		item, err = domain.UpdateItemParent(
+			3, []int64{3, 2, 1}, NewItem(1, "name", StatusTodo, time.Time{}, testNow, testNow),
+		)
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+
+	t.Run("should return error when parent ID is negative or item is nil", func(t *testing.T) {
+		item, err := domain.UpdateItemParent(-1, nil, NewItem(1, "name", StatusTodo, time.Time{}, testNow, testNow))
+		assert.Error(t, err)
+		assert.Nil(t, item)
+
+		item, err = domain.UpdateItemParent(2, []int64{2}, nil)
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+}
+
+func TestDefaultDomain_CompleteItemTree(t *testing.T) {
+	newTree := func() (Item, []Item) {
+		parent := NewItem(1, "parent", StatusTodo, time.Time{}, testNow, testNow)
+		descendants := []Item{
+			NewItem(2, "open child", StatusTodo, time.Time{}, testNow, testNow),
+			NewItem(3, "completed child", StatusDone, time.Time{}, testNow, testNow),
+		}
+		return parent, descendants
+	}
+
+	t.Run("should refuse to complete item with open subtasks", func(t *testing.T) {
+		parent, descendants := newTree()
+
+		items, err := domain.CompleteItemTree(parent, descendants, CompletionPolicyRefuse)
+
+		assert.ErrorIs(t, err, ErrOpenSubtasks)
+		assert.Nil(t, items)
+		assert.Equal(t, StatusTodo, parent.GetStatus())
+	})
+
+	t.Run("should complete item without open subtasks", func(t *testing.T) {
+		parent, descendants := newTree()
+
+		items, err := domain.CompleteItemTree(parent, descendants[1:], CompletionPolicyRefuse)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Item{parent}, items)
+		assert.Equal(t, StatusDone, parent.GetStatus())
+	})
+
+	t.Run("should complete open subtasks along with the item", func(t *testing.T) {
+		parent, descendants := newTree()
+
+		items, err := domain.CompleteItemTree(parent, descendants, CompletionPolicyCascade)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Item{parent, descendants[0]}, items)
+		assert.Equal(t, StatusDone, descendants[0].GetStatus())
+	})
+
+	t.Run("should return error on unknown policy or nil item", func(t *testing.T) {
+		parent, descendants := newTree()
+
+		items, err := domain.CompleteItemTree(parent, descendants, "sometimes")
+		assert.Error(t, err)
+		assert.Nil(t, items)
+
+		items, err = domain.CompleteItemTree(nil, descendants, CompletionPolicyCascade)
+		assert.Error(t, err)
+		assert.Nil(t, items)
+	})
+}
+
+func TestParseCompletionPolicy(t *testing.T) {
+	for _, value := range []string{"refuse", "cascade"} {
+		policy, err := ParseCompletionPolicy(value)
+		assert.NoError(t, err)
+		assert.Equal(t, CompletionPolicy(value), policy)
+	}
+
+	_, err := ParseCompletionPolicy("sometimes")
+	assert.Error(t, err)
+}
+
+func TestParseSortOrder(t *testing.T) {
+	for _, value := range []string{"priority", "due", "created", "updated", "name", "id"} {
+		order, err := ParseSortOrder(value)
+		assert.NoError(t, err)
+		assert.Equal(t, SortOrder(value), order)
+	}
+
+	order, err := ParseSortOrder("")
+	assert.NoError(t, err)
+	assert.Equal(t, SortOrderPriority, order)
+
+	_, err = ParseSortOrder("size")
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+func TestParseSortKeys(t *testing.T) {
+	t.Run("should parse the fields with their default direction", func(t *testing.T) {
+		keys, err := ParseSortKeys("priority, due,created,updated,name,id")
+		assert.NoError(t, err)
+		assert.Equal(t, []SortKey{
+			{Order: SortOrderPriority, Descending: true},
+			{Order: SortOrderDue},
+			{Order: SortOrderCreated, Descending: true},
+			{Order: SortOrderUpdated, Descending: true},
+			{Order: SortOrderName},
+			{Order: SortOrderId},
+		}, keys)
+	})
+
+	t.Run("should parse the directions", func(t *testing.T) {
+		keys, err := ParseSortKeys("Due:DESC,priority:asc")
+		assert.NoError(t, err)
+		assert.Equal(t, []SortKey{{Order: SortOrderDue, Descending: true}, {Order: SortOrderPriority}}, keys)
+		assert.Equal(t, "due:desc,priority:asc", FormatSortKeys(keys))
+	})
+
+	t.Run("should return no keys for an empty specification", func(t *testing.T) {
+		keys, err := ParseSortKeys(" ")
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+		assert.Equal(t, "", FormatSortKeys(keys))
+	})
+
+	t.Run("should format default directions without a suffix", func(t *testing.T) {
+		keys, err := ParseSortKeys("created:desc,name:asc")
+		assert.NoError(t, err)
+		assert.Equal(t, "created,name", FormatSortKeys(keys))
+	})
+
+	t.Run("should reject invalid specifications", func(t *testing.T) {
+		for _, value := range []string{"size", "due:up", "due,,name", "due,due:desc", ":asc"} {
+			_, err := ParseSortKeys(value)
+			assert.ErrorIs(t, err, ErrValidation, value)
+		}
+	})
+}
+
+func TestDefaultDomain_CreateDependency(t *testing.T) {
+	// Item 3 depends on item 2 which depends on item 1
+	dependencies := []Dependency{{ItemId: 3, DependsOnId: 2}, {ItemId: 2, DependsOnId: 1}}
+
+	t.Run("should create dependency", func(t *testing.T) {
+		dependency, err := domain.CreateDependency(4, 3, dependencies)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Dependency{ItemId: 4, DependsOnId: 3}, dependency)
+	})
+
+	t.Run("should return error when the dependency would create a cycle", func(t *testing.T) {
+		_, err := domain.CreateDependency(1, 3, dependencies)
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+
+		_, err = domain.CreateDependency(1, 1, nil)
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+	})
+
+	t.Run("should return error on invalid IDs", func(t *testing.T) {
+		_, err := domain.CreateDependency(0, 1, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestDefaultDomain_SortItemsByDependencies(t *testing.T) {
+	items := []Item{
+		NewItem(1, "deploy", StatusTodo, time.Time{}, testNow, testNow),
+		NewItem(2, "test", StatusTodo, time.Time{}, testNow, testNow),
+		NewItem(3, "build", StatusTodo, time.Time{}, testNow, testNow),
+		NewItem(4, "announce", StatusTodo, time.Time{}, testNow, testNow),
+	}
+	dependencies := []Dependency{
+		{ItemId: 1, DependsOnId: 2},
+		{ItemId: 2, DependsOnId: 3},
+		// Item 5 is not listed and is ignored
+		{ItemId: 4, DependsOnId: 5},
+	}
+
+	result := domain.SortItemsByDependencies(items, dependencies)
+
+	var ids []int64
+	for _, item := range result {
+		ids = append(ids, item.GetId())
+	}
+	assert.Equal(t, []int64{3, 2, 1, 4}, ids)
+}
+
+func TestDefaultDomain_UpdateItemRecurrence(t *testing.T) {
+	t.Run("should store the recurrence in its RRULE form", func(t *testing.T) {
+		item := NewItem(1, "name", StatusTodo, time.Time{}, testNow.Add(-time.Hour), testNow.Add(-time.Hour))
+
+		item, err := domain.UpdateItemRecurrence("every 2 weeks", item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2", item.GetRecurrence())
+		assert.Equal(t, testNow, item.GetUpdatedAt())
+
+		item, err = domain.UpdateItemRecurrence("", item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", item.GetRecurrence())
+	})
+
+	t.Run("should return error on unsupported recurrence or nil item", func(t *testing.T) {
+		item, err := domain.UpdateItemRecurrence("sometimes", NewItem(1, "name", StatusTodo, time.Time{}, testNow, testNow))
+		assert.Error(t, err)
+		assert.Nil(t, item)
+
+		item, err = domain.UpdateItemRecurrence("daily", nil)
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+}
+
+func TestDefaultDomain_CreateNextOccurrence(t *testing.T) {
+	t.Run("should shift the due date and move the recurrence", func(t *testing.T) {
+		dueAt := time.Date(2026, time.October, 13, 17, 0, 0, 0, time.Local)
+		item := NewItem(1, "Release notes", StatusDone, dueAt, testNow, testNow)
+		item.SetPriority(PriorityHigh)
+		item.SetTags([]string{"chores"})
+		item.SetProjectId(2)
+		item.SetRecurrence("FREQ=WEEKLY")
+
+		occurrence, err := domain.CreateNextOccurrence(item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), occurrence.GetId())
+		assert.Equal(t, "Release notes", occurrence.GetName())
+		assert.Equal(t, StatusTodo, occurrence.GetStatus())
+		assert.Equal(t, time.Date(2026, time.October, 20, 17, 0, 0, 0, time.Local), occurrence.GetDueAt())
+		assert.Equal(t, PriorityHigh, occurrence.GetPriority())
+		assert.Equal(t, []string{"chores"}, occurrence.GetTags())
+		assert.Equal(t, int64(2), occurrence.GetProjectId())
+		assert.Equal(t, "FREQ=WEEKLY", occurrence.GetRecurrence())
+		assert.Equal(t, "", item.GetRecurrence())
+	})
+
+	t.Run("should skip occurrences in the past", func(t *testing.T) {
+		item := NewItem(1, "name", StatusDone, time.Date(2026, time.October, 1, 9, 0, 0, 0, time.Local), testNow, testNow)
+		item.SetRecurrence("FREQ=DAILY")
+
+		occurrence, err := domain.CreateNextOccurrence(item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, time.October, 15, 9, 0, 0, 0, time.Local), occurrence.GetDueAt())
+	})
+
+	t.Run("should start from the current time without a due date", func(t *testing.T) {
+		item := NewItem(1, "name", StatusDone, time.Time{}, testNow, testNow)
+		item.SetRecurrence("FREQ=DAILY;INTERVAL=2")
+
+		occurrence, err := domain.CreateNextOccurrence(item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testNow.AddDate(0, 0, 2), occurrence.GetDueAt())
+	})
+
+	t.Run("should return nil when the item does not repeat", func(t *testing.T) {
+		occurrence, err := domain.CreateNextOccurrence(NewItem(1, "name", StatusDone, time.Time{}, testNow, testNow))
+
+		assert.NoError(t, err)
+		assert.Nil(t, occurrence)
+	})
+}
+
+func TestDefaultDomain_UpdateItemDescription(t *testing.T) {
+	t.Run("should update notes without trailing whitespace", func(t *testing.T) {
+		item := NewItem(1, "name", StatusTodo, time.Time{}, testNow.Add(-time.Hour), testNow.Add(-time.Hour))
+
+		item, err := domain.UpdateItemDescription("  first line\nsecond line\n\n", item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "  first line\nsecond line", item.GetDescription())
+		assert.Equal(t, testNow, item.GetUpdatedAt())
+	})
+
+	t.Run("should return error when item is nil", func(t *testing.T) {
+		item, err := domain.UpdateItemDescription("notes", nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+}
+
+func TestDefaultDomain_CreateJournalEntry(t *testing.T) {
+	changes := []JournalChange{{ItemId: 1, After: &ItemSnapshot{Id: 1, Name: "item"}}}
+
+	entry := domain.CreateJournalEntry("create item 1", changes)
+
+	assert.Equal(t, "create item 1", entry.Summary)
+	assert.Equal(t, changes, entry.Changes)
+	assert.False(t, entry.IsUndone)
+	assert.Equal(t, testNow, entry.CreatedAt)
+}
+
+func TestDefaultDomain_CreateItemEvents(t *testing.T) {
+	changes := []JournalChange{
+		{ItemId: 1, Before: &ItemSnapshot{Id: 1, Name: "item"}, After: &ItemSnapshot{Id: 1, Name: "renamed"}},
+		{ItemId: 2, After: &ItemSnapshot{Id: 2, Name: "new", Status: StatusTodo}},
+	}
+
+	events := domain.CreateItemEvents("update", changes)
+
+	assert.Equal(t, []ItemEvent{
+		{ItemId: 1, Field: ItemEventFieldName, OldValue: "item", NewValue: "renamed", Origin: "update", CreatedAt: testNow},
+		{ItemId: 2, Field: ItemEventFieldName, NewValue: "new", Origin: "update", CreatedAt: testNow},
+		{ItemId: 2, Field: ItemEventFieldStatus, NewValue: "todo", Origin: "update", CreatedAt: testNow},
+	}, events)
+}
+
+func TestDefaultDomain_TrashItem(t *testing.T) {
+	t.Run("should move the item to the trash without updating it", func(t *testing.T) {
+		updatedAt := testNow.Add(-time.Hour)
+		item := NewItem(1, "name", StatusTodo, time.Time{}, updatedAt, updatedAt)
+
+		item, err := domain.TrashItem(item)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testNow, item.GetDeletedAt())
+		assert.Equal(t, updatedAt, item.GetUpdatedAt())
+
+		item, err = domain.RestoreTrashedItem(item)
+
+		assert.NoError(t, err)
+		assert.True(t, item.GetDeletedAt().IsZero())
+	})
+
+	t.Run("should return error when item is nil", func(t *testing.T) {
+		item, err := domain.TrashItem(nil)
+		assert.Error(t, err)
+		assert.Nil(t, item)
+
+		item, err = domain.RestoreTrashedItem(nil)
+		assert.Error(t, err)
+		assert.Nil(t, item)
+	})
+}
@@ -0,0 +1,176 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound godoc
+//
+// Returned when a todo item, or a relation between todo items, does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrInvalidID godoc
+//
+// Returned when an ID cannot refer to a todo item, e.g. because it is not positive.
+var ErrInvalidID = errors.New("invalid ID")
+
+// ErrValidation godoc
+//
+// Returned when a value provided for a todo item is invalid. The returned error is a *ValidationError, which names
+// the invalid field.
+var ErrValidation = errors.New("validation failed")
+
+// ErrConflict godoc
+//
+// Returned when a change conflicts with the current state of the todo items.
+var ErrConflict = errors.New("conflict")
+
+// ErrOpenSubtasks godoc
+//
+// Returned when an item cannot be completed because it has open subtasks. Wraps ErrConflict.
+var ErrOpenSubtasks = fmt.Errorf("item has open subtasks: %w", ErrConflict)
+
+// ErrDependencyCycle godoc
+//
+// Returned when a dependency would make an item wait, directly or indirectly, on itself. Wraps ErrConflict.
+var ErrDependencyCycle = fmt.Errorf("dependency would create a cycle: %w", ErrConflict)
+
+// ErrNothingToUndo godoc
+//
+// Returned when undoing an operation while every operation of the journal is undone. Wraps ErrConflict.
+var ErrNothingToUndo = fmt.Errorf("nothing to undo: %w", ErrConflict)
+
+// ErrNothingToRedo godoc
+//
+// Returned when redoing an operation while no operation is undone. Wraps ErrConflict.
+var ErrNothingToRedo = fmt.Errorf("nothing to redo: %w", ErrConflict)
+
+// NotFoundError godoc
+//
+// Defines the error returned when a todo item does not exist.
+//
+// Matches ErrNotFound with errors.Is.
+type NotFoundError struct {
+	ItemId int64
+}
+
+// Error godoc
+//
+// Returns a message naming the missing item.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("no todo item exists with ID %d", e.ItemId)
+}
+
+// Is godoc
+//
+// Returns true when target is ErrNotFound.
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// TransitionError godoc
+//
+// Defines the error returned when an item cannot move from its status to another status.
+//
+// Matches ErrConflict with errors.Is.
+type TransitionError struct {
+	ItemId int64
+	From   Status
+	To     Status
+}
+
+// Error godoc
+//
+// Returns a message naming the item and both statuses.
+func (e *TransitionError) Error() string {
+	switch {
+	case e.From == e.To:
+		return fmt.Sprintf("item %d is already %s", e.ItemId, e.From)
+	case e.From.IsClosed():
+		return fmt.Sprintf("item %d is %s and must be reopened before it becomes %s", e.ItemId, e.From, e.To)
+	}
+	return fmt.Sprintf("item %d is %s and cannot become %s", e.ItemId, e.From, e.To)
+}
+
+// Is godoc
+//
+// Returns true when target is ErrConflict.
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// ValidationError godoc
+//
+// Defines the error returned when the value of a field of a todo item is invalid.
+//
+// Matches ErrValidation with errors.Is.
+type ValidationError struct {
+	// Field is the name of the invalid field, e.g. "name" or "priority".
+	Field string
+	// Reason describes why the value is invalid.
+	Reason string
+}
+
+// Error godoc
+//
+// Returns a message naming the invalid field and the reason.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// Is godoc
+//
+// Returns true when target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// newValidationError godoc
+//
+// Returns a *ValidationError for the field with a formatted reason.
+func newValidationError(field string, format string, args ...any) error {
+	return &ValidationError{Field: field, Reason: fmt.Sprintf(format, args...)}
+}
+
+// newInvalidIdError godoc
+//
+// Returns an error wrapping ErrInvalidID for the ID.
+func newInvalidIdError(id int64) error {
+	return fmt.Errorf("%w %d, IDs must be positive", ErrInvalidID, id)
+}
+
+// FilterError godoc
+//
+// Defines the error returned when a filter expression cannot be parsed.
+//
+// Matches ErrValidation with errors.Is.
+type FilterError struct {
+	// Expression is the filter expression as given.
+	Expression string
+	// Column is the 1-based column, in characters, of the offending part of the expression.
+	Column int
+	// Reason describes what is wrong at the column.
+	Reason string
+}
+
+// Error godoc
+//
+// Returns a message with the column and the reason.
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("invalid filter at column %d: %s", e.Column, e.Reason)
+}
+
+// Is godoc
+//
+// Returns true when target is ErrValidation.
+func (e *FilterError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// newFilterError godoc
+//
+// Returns a *FilterError for the column of the expression with a formatted reason.
+func newFilterError(expression string, column int, format string, args ...any) error {
+	return &FilterError{Expression: expression, Column: column, Reason: fmt.Sprintf(format, args...)}
+}
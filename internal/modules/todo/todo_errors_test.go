@@ -0,0 +1,66 @@
+package todo
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNotFoundError(t *testing.T) {
+	t.Run("should match ErrNotFound when wrapped", func(t *testing.T) {
+		err := fmt.Errorf("Remove: %w", &NotFoundError{ItemId: 7})
+
+		var notFoundErr *NotFoundError
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorAs(t, err, &notFoundErr)
+		assert.Equal(t, int64(7), notFoundErr.ItemId)
+		assert.Equal(t, "no todo item exists with ID 7", notFoundErr.Error())
+		assert.False(t, errors.Is(err, ErrValidation))
+	})
+}
+
+func TestValidationError(t *testing.T) {
+	t.Run("should match ErrValidation and name the field", func(t *testing.T) {
+		_, err := domain.CreateItem(ItemDraft{Name: ""})
+
+		var validationErr *ValidationError
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "name", validationErr.Field)
+		assert.Equal(t, "invalid name: cannot be empty", validationErr.Error())
+	})
+
+	t.Run("should report the recurrence field", func(t *testing.T) {
+		_, err := domain.CreateItem(ItemDraft{Name: "name", Recurrence: "sometimes"})
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "recurrence", validationErr.Field)
+	})
+}
+
+func TestConflictErrors(t *testing.T) {
+	t.Run("should wrap ErrConflict", func(t *testing.T) {
+		assert.ErrorIs(t, ErrOpenSubtasks, ErrConflict)
+		assert.ErrorIs(t, ErrDependencyCycle, ErrConflict)
+		assert.ErrorIs(t, &TransitionError{ItemId: 1, From: StatusDone, To: StatusDone}, ErrConflict)
+	})
+
+	t.Run("should name the item and both statuses of a transition", func(t *testing.T) {
+		assert.Equal(t, "item 1 is already done", (&TransitionError{ItemId: 1, From: StatusDone, To: StatusDone}).Error())
+		assert.Equal(
+			t, "item 2 is cancelled and must be reopened before it becomes in-progress",
+			(&TransitionError{ItemId: 2, From: StatusCancelled, To: StatusInProgress}).Error(),
+		)
+		assert.Equal(
+			t, "item 3 is waiting and cannot become todo",
+			(&TransitionError{ItemId: 3, From: StatusWaiting, To: StatusTodo}).Error(),
+		)
+	})
+
+	t.Run("should refuse invalid dependency IDs", func(t *testing.T) {
+		_, err := domain.CreateDependency(0, 1, nil)
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+}
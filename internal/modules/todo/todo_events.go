@@ -0,0 +1,227 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields of an item recorded by item events, named after the fields of the item schema.
+const (
+	ItemEventFieldName         = "name"
+	ItemEventFieldStatus       = "status"
+	ItemEventFieldStatusReason = "statusReason"
+	ItemEventFieldDueAt        = "dueAt"
+	ItemEventFieldPriority     = "priority"
+	ItemEventFieldProjectId    = "projectId"
+	ItemEventFieldParentId     = "parentId"
+	ItemEventFieldRecurrence   = "recurrence"
+	ItemEventFieldDescription  = "description"
+	ItemEventFieldTags         = "tags"
+	ItemEventFieldDependsOn    = "dependsOn"
+	ItemEventFieldDeletedAt    = "deletedAt"
+)
+
+// ItemEvent godoc
+//
+// Defines the change of a field of an item. Values are empty when the field is unset, so an item which is created
+// only has new values and an item which is deleted only has old values.
+type ItemEvent struct {
+	Id     int64
+	ItemId int64
+	// ItemName is the current name of the item, empty when the item was deleted for good.
+	ItemName string
+	Field    string
+	OldValue string
+	NewValue string
+	// Origin is the command which made the change, e.g. "update", empty when it is unknown.
+	Origin    string
+	CreatedAt time.Time
+}
+
+// NewItemEventFromRow godoc
+//
+// Create a new ItemEvent by scanning a sql.Rows struct selecting itemEventColumns.
+//
+// Returns nil and error on error.
+//
+// Return a new ItemEvent and nil on success.
+func NewItemEventFromRow(rows *sql.Rows) (*ItemEvent, error) {
+	var event ItemEvent
+	var createdAtTimestamp int64
+
+	err := rows.Scan(
+		&event.Id, &event.ItemId, &event.ItemName, &event.Field, &event.OldValue, &event.NewValue, &event.Origin,
+		&createdAtTimestamp,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("NewItemEventFromRow: %v", err)
+	}
+	event.CreatedAt = time.Unix(createdAtTimestamp, 0)
+	return &event, nil
+}
+
+// originKey godoc
+//
+// Key of the origin of the changes in a context, see WithOrigin.
+type originKey struct{}
+
+// WithOrigin godoc
+//
+// Returns a copy of ctx in which the use case records the changes of items as made by origin, e.g. the command
+// "update".
+func WithOrigin(ctx context.Context, origin string) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+// originOf godoc
+//
+// Returns the origin of the changes made with ctx, or an empty string when it is not set.
+func originOf(ctx context.Context) string {
+	origin, _ := ctx.Value(originKey{}).(string)
+	return origin
+}
+
+// snapshotFields godoc
+//
+// Returns the value of each field recorded by item events, in the order they are listed, for an item or for no item
+// when the snapshot is nil. Unset fields are empty and timestamps are formatted as RFC 3339.
+func snapshotFields(snapshot *ItemSnapshot) [][2]string {
+	var status string
+	if snapshot == nil {
+		snapshot = &ItemSnapshot{}
+	} else {
+		status = snapshot.status().String()
+	}
+	formatTimestamp := func(timestamp int64) string {
+		if timestamp == 0 {
+			return ""
+		}
+		return time.Unix(timestamp, 0).Format(time.RFC3339)
+	}
+	formatId := func(id int64) string {
+		if id == 0 {
+			return ""
+		}
+		return strconv.FormatInt(id, 10)
+	}
+
+	var priority string
+	if snapshot.Priority != PriorityNone {
+		priority = snapshot.Priority.String()
+	}
+	var dependsOn []string
+	for _, dependsOnId := range snapshot.DependsOn {
+		dependsOn = append(dependsOn, formatId(dependsOnId))
+	}
+	return [][2]string{
+		{ItemEventFieldName, snapshot.Name},
+		{ItemEventFieldStatus, status},
+		{ItemEventFieldStatusReason, snapshot.StatusReason},
+		{ItemEventFieldDueAt, formatTimestamp(snapshot.DueAt)},
+		{ItemEventFieldPriority, priority},
+		{ItemEventFieldProjectId, formatId(snapshot.ProjectId)},
+		{ItemEventFieldParentId, formatId(snapshot.ParentId)},
+		{ItemEventFieldRecurrence, snapshot.Recurrence},
+		{ItemEventFieldDescription, snapshot.Description},
+		{ItemEventFieldTags, strings.Join(snapshot.Tags, ",")},
+		{ItemEventFieldDependsOn, strings.Join(dependsOn, ",")},
+		{ItemEventFieldDeletedAt, formatTimestamp(snapshot.DeletedAt)},
+	}
+}
+
+// diffItemSnapshots godoc
+//
+// Returns the events of the fields which differ between the states of an item before and after a change, without
+// their origin and time. Either state is nil when the change created or deleted the item.
+func diffItemSnapshots(itemId int64, before *ItemSnapshot, after *ItemSnapshot) []ItemEvent {
+	var events []ItemEvent
+	afterFields := snapshotFields(after)
+	for index, field := range snapshotFields(before) {
+		if field[1] == afterFields[index][1] {
+			continue
+		}
+		events = append(events, ItemEvent{
+			ItemId: itemId, Field: field[0], OldValue: field[1], NewValue: afterFields[index][1],
+		})
+	}
+	return events
+}
+
+// saveItemEvents godoc
+//
+// Saves the events of the fields changed by the changes of items, as made by the origin of ctx.
+//
+// Returns error on error.
+func (uc *defaultUseCase) saveItemEvents(ctx context.Context, changes []JournalChange) error {
+	events := uc.domain.CreateItemEvents(originOf(ctx), changes)
+	if len(events) == 0 {
+		return nil
+	}
+	if _, err := uc.repository.PersistItemEvents(ctx, events); err != nil {
+		return fmt.Errorf("saveItemEvents: %v", err)
+	}
+	return nil
+}
+
+// Log godoc
+//
+// Get the changes of the fields of an item by its ID made since a time, oldest first, including the changes made
+// before it was deleted. A zero since returns every change of the item.
+//
+// Returns nil and error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item
+// does not exist and has no recorded changes.
+//
+// Returns nil and error on error.
+//
+// Returns the events and nil on success.
+func (uc *defaultUseCase) Log(ctx context.Context, itemId int64, since time.Time) ([]ItemEvent, error) {
+	if itemId <= 0 {
+		return nil, fmt.Errorf("defaultUseCase.Log: %w", newInvalidIdError(itemId))
+	}
+
+	events, err := uc.repository.FindItemEvents(ctx, ItemEventFilter{ItemId: itemId, Since: since})
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Log: Failed to find changes of item with ID %d: %v", itemId, err)
+	}
+	if len(events) > 0 {
+		return events, nil
+	}
+	// Items created before changes were recorded have no events
+	snapshot, err := uc.snapshotItem(ctx, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Log: %v", err)
+	}
+	if snapshot != nil {
+		return events, nil
+	}
+	// Items deleted for good exist as long as changes were recorded before since
+	if !since.IsZero() {
+		earlierEvents, err := uc.repository.FindItemEvents(ctx, ItemEventFilter{ItemId: itemId})
+		if err != nil {
+			return nil, fmt.Errorf("defaultUseCase.Log: Failed to find changes of item with ID %d: %v", itemId, err)
+		}
+		if len(earlierEvents) > 0 {
+			return events, nil
+		}
+	}
+	return nil, fmt.Errorf("defaultUseCase.Log: %w", &NotFoundError{ItemId: itemId})
+}
+
+// Activity godoc
+//
+// Get the changes of the fields of every item made since a time, oldest first.
+//
+// Returns nil and error on error.
+//
+// Returns the events and nil on success.
+func (uc *defaultUseCase) Activity(ctx context.Context, since time.Time) ([]ItemEvent, error) {
+	events, err := uc.repository.FindItemEvents(ctx, ItemEventFilter{Since: since})
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Activity: %v", err)
+	}
+	return events, nil
+}
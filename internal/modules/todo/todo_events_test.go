@@ -0,0 +1,167 @@
+package todo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDiffItemSnapshots(t *testing.T) {
+	dueAt := time.Date(2026, time.October, 20, 17, 0, 0, 0, time.UTC)
+	before := &ItemSnapshot{
+		Id: 1, Name: "ship it", Status: StatusTodo, DueAt: dueAt.Unix(), Priority: PriorityNone,
+		Tags: []string{"release"},
+	}
+
+	t.Run("should return the fields which changed", func(t *testing.T) {
+		after := *before
+		after.Status = StatusBlocked
+		after.StatusReason = "QA"
+		after.Priority = PriorityHigh
+		after.Tags = []string{"release", "urgent"}
+		after.DependsOn = []int64{2, 3}
+
+		events := diffItemSnapshots(1, before, &after)
+
+		assert.Equal(t, []ItemEvent{
+			{ItemId: 1, Field: ItemEventFieldStatus, OldValue: "todo", NewValue: "blocked"},
+			{ItemId: 1, Field: ItemEventFieldStatusReason, NewValue: "QA"},
+			{ItemId: 1, Field: ItemEventFieldPriority, NewValue: "high"},
+			{ItemId: 1, Field: ItemEventFieldTags, OldValue: "release", NewValue: "release,urgent"},
+			{ItemId: 1, Field: ItemEventFieldDependsOn, NewValue: "2,3"},
+		}, events)
+	})
+
+	t.Run("should return only new values when the item is created", func(t *testing.T) {
+		events := diffItemSnapshots(1, nil, before)
+
+		assert.Equal(t, []ItemEvent{
+			{ItemId: 1, Field: ItemEventFieldName, NewValue: "ship it"},
+			{ItemId: 1, Field: ItemEventFieldStatus, NewValue: "todo"},
+			{ItemId: 1, Field: ItemEventFieldDueAt, NewValue: dueAt.Format(time.RFC3339)},
+			{ItemId: 1, Field: ItemEventFieldTags, NewValue: "release"},
+		}, events)
+	})
+
+	t.Run("should return only old values when the item is deleted", func(t *testing.T) {
+		events := diffItemSnapshots(1, before, nil)
+
+		assert.Len(t, events, 4)
+		for _, event := range events {
+			assert.NotEmpty(t, event.OldValue)
+			assert.Empty(t, event.NewValue)
+		}
+	})
+
+	t.Run("should return no events when nothing changed", func(t *testing.T) {
+		after := *before
+		after.UpdatedAt = dueAt.Unix()
+
+		assert.Empty(t, diffItemSnapshots(1, before, &after))
+	})
+}
+
+func TestWithOrigin(t *testing.T) {
+	assert.Equal(t, "update", originOf(WithOrigin(ctx, "update")))
+	assert.Equal(t, "", originOf(ctx))
+}
+
+func TestDefaultUseCase_Log(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	item, err := useCase.Create(WithOrigin(ctx, "create"), ItemDraft{Name: "ship it"})
+	if err != nil {
+		t.Fatalf("TestDefaultUseCase_Log: %v", err)
+	}
+	if _, err := useCase.Create(ctx, ItemDraft{Name: "other"}); err != nil {
+		t.Fatalf("TestDefaultUseCase_Log: %v", err)
+	}
+	priority := PriorityHigh
+	if err := useCase.Update(WithOrigin(ctx, "update"), item.GetId(), ItemChanges{Priority: &priority}); err != nil {
+		t.Fatalf("TestDefaultUseCase_Log: %v", err)
+	}
+
+	t.Run("should return the changes of the item with their origin", func(t *testing.T) {
+		events, err := useCase.Log(ctx, item.GetId(), time.Time{})
+
+		assert.NoError(t, err)
+		assert.Len(t, events, 3)
+		assert.Equal(t, ItemEventFieldName, events[0].Field)
+		assert.Equal(t, "ship it", events[0].NewValue)
+		assert.Equal(t, "ship it", events[0].ItemName)
+		assert.Equal(t, "create", events[0].Origin)
+		assert.Equal(t, ItemEvent{
+			Id: 5, ItemId: item.GetId(), ItemName: "ship it", Field: ItemEventFieldPriority, NewValue: "high",
+			Origin: "update", CreatedAt: events[2].CreatedAt,
+		}, events[2])
+	})
+
+	t.Run("should record the changes made by undo", func(t *testing.T) {
+		_, err := useCase.Undo(WithOrigin(ctx, "undo"))
+		assert.NoError(t, err)
+
+		events, err := useCase.Log(ctx, item.GetId(), time.Time{})
+
+		assert.NoError(t, err)
+		assert.Len(t, events, 4)
+		assert.Equal(t, ItemEvent{
+			Id: 6, ItemId: item.GetId(), ItemName: "ship it", Field: ItemEventFieldPriority, OldValue: "high",
+			Origin: "undo", CreatedAt: events[3].CreatedAt,
+		}, events[3])
+	})
+
+	t.Run("should keep the changes of items deleted for good", func(t *testing.T) {
+		assert.NoError(t, useCase.Remove(ctx, item.GetId()))
+		assert.NoError(t, useCase.Purge(ctx, item.GetId()))
+
+		events, err := useCase.Log(ctx, item.GetId(), time.Time{})
+
+		assert.NoError(t, err)
+		assert.Len(t, events, 8)
+		assert.Equal(t, ItemEventFieldDeletedAt, events[4].Field)
+		assert.Empty(t, events[4].OldValue)
+		last := events[len(events)-1]
+		assert.Equal(t, ItemEventFieldDeletedAt, last.Field)
+		assert.Empty(t, last.NewValue)
+		for _, event := range events {
+			assert.Equal(t, "", event.ItemName)
+		}
+	})
+
+	t.Run("should return the changes of the item since a time", func(t *testing.T) {
+		events, err := useCase.Log(ctx, item.GetId(), time.Now().Add(-time.Minute))
+		assert.NoError(t, err)
+		assert.Len(t, events, 8)
+
+		events, err = useCase.Log(ctx, item.GetId(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+
+		_, err = useCase.Log(ctx, 99, time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("should return the activity of every item since a time", func(t *testing.T) {
+		events, err := useCase.Activity(ctx, time.Now().Add(-time.Minute))
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, events)
+		ids := map[int64]bool{}
+		for _, event := range events {
+			ids[event.ItemId] = true
+		}
+		assert.Equal(t, map[int64]bool{1: true, 2: true}, ids)
+
+		events, err = useCase.Activity(ctx, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("should return error when the item does not exist", func(t *testing.T) {
+		_, err := useCase.Log(ctx, 0, time.Time{})
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = useCase.Log(ctx, 99, time.Time{})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
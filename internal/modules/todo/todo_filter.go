@@ -0,0 +1,534 @@
+package todo
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/dateparse"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FilterField godoc
+//
+// Defines a field of the items which a filter expression compares.
+type FilterField string
+
+const (
+	// FilterFieldStatus compares the status: open, closed, completed or one of Statuses.
+	FilterFieldStatus FilterField = "status"
+	// FilterFieldTag matches the items which have a tag.
+	FilterFieldTag FilterField = "tag"
+	// FilterFieldProject matches the items of a project by name, or the items without a project with none.
+	FilterFieldProject FilterField = "project"
+	// FilterFieldPriority compares the priority.
+	FilterFieldPriority FilterField = "priority"
+	// FilterFieldDue compares the due date, or matches the items without a due date with none.
+	FilterFieldDue FilterField = "due"
+	// FilterFieldCreated compares the creation date.
+	FilterFieldCreated FilterField = "created"
+	// FilterFieldUpdated compares the date of the last update.
+	FilterFieldUpdated FilterField = "updated"
+	// FilterFieldName matches the names containing a text with `:`, or equal to it with `=`, ignoring case.
+	FilterFieldName FilterField = "name"
+	// FilterFieldId compares the ID.
+	FilterFieldId FilterField = "id"
+	// FilterFieldParent matches the subtasks of an item, or the top level items with none.
+	FilterFieldParent FilterField = "parent"
+)
+
+// FilterFields godoc
+//
+// Lists the fields of filter expressions in the order they are documented.
+var FilterFields = []FilterField{
+	FilterFieldStatus, FilterFieldTag, FilterFieldProject, FilterFieldPriority, FilterFieldDue, FilterFieldCreated,
+	FilterFieldUpdated, FilterFieldName, FilterFieldId, FilterFieldParent,
+}
+
+// FilterOperator godoc
+//
+// Defines how a field is compared to the value of a filter comparison.
+type FilterOperator string
+
+const (
+	// FilterOperatorMatch is the same as FilterOperatorEqual, except that a name matches when it contains the value.
+	// Dates are equal when they are on the same day.
+	FilterOperatorMatch        FilterOperator = ":"
+	FilterOperatorEqual        FilterOperator = "="
+	FilterOperatorNotEqual     FilterOperator = "!="
+	FilterOperatorLess         FilterOperator = "<"
+	FilterOperatorLessEqual    FilterOperator = "<="
+	FilterOperatorGreater      FilterOperator = ">"
+	FilterOperatorGreaterEqual FilterOperator = ">="
+)
+
+// filterStatuses godoc
+//
+// Values of the status field. Open items are not done nor cancelled, closed items are, and completed is an alias of
+// done.
+var filterStatuses = []string{
+	"open", "closed", "completed", "todo", "in-progress", "blocked", "waiting", "done", "cancelled",
+}
+
+// noneFilterValue godoc
+//
+// Value which matches the items without a project, a due date or a parent.
+const noneFilterValue = "none"
+
+// FilterExpression godoc
+//
+// A parsed filter expression: a comparison, or comparisons combined with and, or and not. Repositories compile it
+// into a query.
+type FilterExpression interface {
+	// String returns the expression in a normalized form, with parentheses around combined expressions.
+	String() string
+}
+
+// FilterAnd godoc
+//
+// Matches the items which match both expressions.
+type FilterAnd struct {
+	Left, Right FilterExpression
+}
+
+// String godoc
+//
+// Returns the expression in a normalized form.
+func (f *FilterAnd) String() string {
+	return fmt.Sprintf("(%s and %s)", f.Left, f.Right)
+}
+
+// FilterOr godoc
+//
+// Matches the items which match either expression.
+type FilterOr struct {
+	Left, Right FilterExpression
+}
+
+// String godoc
+//
+// Returns the expression in a normalized form.
+func (f *FilterOr) String() string {
+	return fmt.Sprintf("(%s or %s)", f.Left, f.Right)
+}
+
+// FilterNot godoc
+//
+// Matches the items which do not match the expression.
+type FilterNot struct {
+	Operand FilterExpression
+}
+
+// String godoc
+//
+// Returns the expression in a normalized form.
+func (f *FilterNot) String() string {
+	return fmt.Sprintf("not %s", f.Operand)
+}
+
+// FilterComparison godoc
+//
+// Compares a field of the items to a value.
+type FilterComparison struct {
+	Field    FilterField
+	Operator FilterOperator
+	// Text is the value as written in the expression.
+	Text string
+	// Value is the resolved value: a string for status, tag, project and name, an int64 for priority, id and parent,
+	// and a time.Time for dates. It is nil for none.
+	Value any
+	// Until is the start of the next day when a date is compared with `:`, `=` or `!=`, which compare whole days.
+	Until time.Time
+}
+
+// String godoc
+//
+// Returns the expression in a normalized form.
+func (f *FilterComparison) String() string {
+	if strings.ContainsFunc(f.Text, isFilterDelimiter) || f.Text == "" {
+		return fmt.Sprintf("%s%s%s", f.Field, f.Operator, strconv.Quote(f.Text))
+	}
+	return fmt.Sprintf("%s%s%s", f.Field, f.Operator, f.Text)
+}
+
+// filterTokenKind godoc
+//
+// Defines the kinds of tokens of filter expressions.
+type filterTokenKind int
+
+const (
+	filterTokenEnd filterTokenKind = iota
+	filterTokenWord
+	filterTokenString
+	filterTokenOperator
+	filterTokenOpen
+	filterTokenClose
+)
+
+// filterToken godoc
+//
+// Defines a token of a filter expression and its 1-based column.
+type filterToken struct {
+	kind   filterTokenKind
+	text   string
+	column int
+}
+
+// isFilterDelimiter godoc
+//
+// Returns true for the characters which end a word of a filter expression.
+func isFilterDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`():<>=!"`, r)
+}
+
+// tokenizeFilter godoc
+//
+// Splits a filter expression into tokens.
+//
+// Returns nil and a FilterError when a string is not terminated or a character cannot start a token.
+//
+// Returns the tokens, ending with a filterTokenEnd token, and nil on success.
+func tokenizeFilter(expression string) ([]filterToken, error) {
+	var tokens []filterToken
+	runes := []rune(expression)
+	for index := 0; index < len(runes); {
+		r := runes[index]
+		column := index + 1
+		switch {
+		case unicode.IsSpace(r):
+			index++
+		case r == '(':
+			tokens = append(tokens, filterToken{kind: filterTokenOpen, text: "(", column: column})
+			index++
+		case r == ')':
+			tokens = append(tokens, filterToken{kind: filterTokenClose, text: ")", column: column})
+			index++
+		case r == '"':
+			var builder strings.Builder
+			index++
+			for index < len(runes) && runes[index] != '"' {
+				// A backslash escapes the next character, e.g. a quote
+				if runes[index] == '\\' && index+1 < len(runes) {
+					index++
+				}
+				builder.WriteRune(runes[index])
+				index++
+			}
+			if index == len(runes) {
+				return nil, newFilterError(expression, column, "the string is not terminated by a quote")
+			}
+			index++
+			tokens = append(tokens, filterToken{kind: filterTokenString, text: builder.String(), column: column})
+		case strings.ContainsRune(":<>=!", r):
+			operator := string(r)
+			if index+1 < len(runes) && runes[index+1] == '=' && r != ':' && r != '=' {
+				operator += "="
+			}
+			if operator == "!" {
+				return nil, newFilterError(expression, column, "'!' is not an operator, did you mean '!='?")
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenOperator, text: operator, column: column})
+			index += utf8.RuneCountInString(operator)
+		default:
+			start := index
+			for index < len(runes) && !isFilterDelimiter(runes[index]) {
+				index++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenWord, text: string(runes[start:index]), column: column})
+		}
+	}
+	tokens = append(tokens, filterToken{kind: filterTokenEnd, column: len(runes) + 1})
+	return tokens, nil
+}
+
+// filterParser godoc
+//
+// A recursive descent parser of filter expressions:
+//
+//	expression = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" expression ")" | comparison
+//	comparison = field operator value
+type filterParser struct {
+	expression string
+	tokens     []filterToken
+	position   int
+	dates      *dateparse.Parser
+}
+
+// ParseFilter godoc
+//
+// Parses a filter expression such as `status:open and (tag:backend or priority>=high) and due<+7d`. Dates are
+// resolved with the date parser.
+//
+// Returns nil and a FilterError, which wraps ErrValidation, when the expression is invalid.
+//
+// Returns the parsed expression and nil on success.
+func ParseFilter(expression string, dates *dateparse.Parser) (FilterExpression, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, fmt.Errorf("ParseFilter: %w", err)
+	}
+	parser := &filterParser{expression: expression, tokens: tokens, dates: dates}
+	if parser.peek().kind == filterTokenEnd {
+		return nil, fmt.Errorf("ParseFilter: %w", parser.errorAt(parser.peek(), "the filter is empty"))
+	}
+
+	parsed, err := parser.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("ParseFilter: %w", err)
+	}
+	if token := parser.peek(); token.kind != filterTokenEnd {
+		return nil, fmt.Errorf(
+			"ParseFilter: %w", parser.errorAt(token, "expected 'and', 'or' or the end of the filter, found '%s'", token.text),
+		)
+	}
+	return parsed, nil
+}
+
+// peek godoc
+//
+// Returns the current token without consuming it.
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.position]
+}
+
+// next godoc
+//
+// Consumes and returns the current token. The end token is never consumed.
+func (p *filterParser) next() filterToken {
+	token := p.tokens[p.position]
+	if token.kind != filterTokenEnd {
+		p.position++
+	}
+	return token
+}
+
+// isKeyword godoc
+//
+// Returns true when the current token is the keyword, ignoring case.
+func (p *filterParser) isKeyword(keyword string) bool {
+	token := p.peek()
+	return token.kind == filterTokenWord && strings.EqualFold(token.text, keyword)
+}
+
+// errorAt godoc
+//
+// Returns a FilterError pointing to the column of the token.
+func (p *filterParser) errorAt(token filterToken, format string, args ...any) error {
+	return newFilterError(p.expression, token.column, format, args...)
+}
+
+// parseOr godoc
+//
+// Parses expressions combined with or.
+func (p *filterParser) parseOr() (FilterExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterOr{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAnd godoc
+//
+// Parses expressions combined with and, which binds tighter than or.
+func (p *filterParser) parseAnd() (FilterExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterAnd{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseUnary godoc
+//
+// Parses a negated expression, an expression between parentheses or a comparison.
+func (p *filterParser) parseUnary() (FilterExpression, error) {
+	if p.isKeyword("not") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &FilterNot{Operand: operand}, nil
+	}
+
+	token := p.peek()
+	switch token.kind {
+	case filterTokenOpen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing.kind != filterTokenClose {
+			return nil, p.errorAt(closing, "expected ')' to close the '(' at column %d", token.column)
+		}
+		p.next()
+		return inner, nil
+	case filterTokenWord:
+		return p.parseComparison()
+	case filterTokenEnd:
+		return nil, p.errorAt(token, "unexpected end of the filter, expected a comparison such as status:open")
+	}
+	return nil, p.errorAt(token, "unexpected '%s', expected a comparison such as status:open", token.text)
+}
+
+// parseComparison godoc
+//
+// Parses a comparison of a field to a value and resolves the value.
+func (p *filterParser) parseComparison() (FilterExpression, error) {
+	fieldToken := p.next()
+	field := FilterField(strings.ToLower(fieldToken.text))
+	if !isFilterField(field) {
+		var names []string
+		for _, name := range FilterFields {
+			names = append(names, string(name))
+		}
+		return nil, p.errorAt(
+			fieldToken, "unknown field '%s', expected one of %s", fieldToken.text, strings.Join(names, ", "),
+		)
+	}
+
+	operatorToken := p.next()
+	if operatorToken.kind != filterTokenOperator {
+		return nil, p.errorAt(operatorToken, "expected an operator such as ':' after '%s'", fieldToken.text)
+	}
+	operator := FilterOperator(operatorToken.text)
+	if !isOrderedFilterField(field) && !isEqualityFilterOperator(operator) {
+		return nil, p.errorAt(operatorToken, "'%s' cannot be compared with '%s', use ':', '=' or '!='", field, operator)
+	}
+
+	valueToken := p.next()
+	if valueToken.kind != filterTokenWord && valueToken.kind != filterTokenString {
+		return nil, p.errorAt(valueToken, "expected a value after '%s%s'", fieldToken.text, operator)
+	}
+	comparison := &FilterComparison{Field: field, Operator: operator, Text: valueToken.text}
+	if err := p.resolveValue(comparison); err != nil {
+		return nil, p.errorAt(valueToken, "%v", err)
+	}
+	return comparison, nil
+}
+
+// resolveValue godoc
+//
+// Sets the Value of a comparison from its Text, according to its field.
+//
+// Returns error when the text is not a valid value of the field, nil otherwise.
+func (p *filterParser) resolveValue(comparison *FilterComparison) error {
+	text := strings.TrimSpace(comparison.Text)
+	isNone := strings.EqualFold(text, noneFilterValue)
+	if isNone && isEqualityFilterOperator(comparison.Operator) {
+		switch comparison.Field {
+		case FilterFieldProject, FilterFieldDue, FilterFieldParent:
+			return nil
+		}
+	}
+
+	switch comparison.Field {
+	case FilterFieldStatus:
+		status := strings.ToLower(text)
+		for _, value := range filterStatuses {
+			if status == value {
+				comparison.Value = status
+				return nil
+			}
+		}
+		return fmt.Errorf("'%s' is not a status, expected one of %s", text, strings.Join(filterStatuses, ", "))
+	case FilterFieldTag, FilterFieldProject, FilterFieldName:
+		if text == "" {
+			return fmt.Errorf("the %s cannot be empty", comparison.Field)
+		}
+		comparison.Value = text
+		if comparison.Field == FilterFieldTag {
+			comparison.Value = strings.ToLower(text)
+		}
+		return nil
+	case FilterFieldPriority:
+		priority, err := ParsePriority(text)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a priority, expected one of %s", text, strings.Join(priorityNames, ", "))
+		}
+		comparison.Value = int64(priority)
+		return nil
+	case FilterFieldId, FilterFieldParent:
+		id, err := strconv.ParseInt(text, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("'%s' is not a valid ID", text)
+		}
+		comparison.Value = id
+		return nil
+	}
+
+	// Dates: a day is compared as a whole, so that due<=fri includes the items due on Friday evening
+	var value time.Time
+	var err error
+	switch comparison.Operator {
+	case FilterOperatorLessEqual, FilterOperatorGreater:
+		value, err = p.dates.ParseDeadline(text)
+	default:
+		value, err = p.dates.Parse(text)
+	}
+	if err != nil {
+		return fmt.Errorf("'%s' is not a date expression, e.g. today, +7d or 2026-11-02", text)
+	}
+	comparison.Value = value
+	if isEqualityFilterOperator(comparison.Operator) {
+		start := time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, value.Location())
+		comparison.Value = start
+		comparison.Until = start.AddDate(0, 0, 1)
+	}
+	return nil
+}
+
+// isFilterField godoc
+//
+// Returns true when the field is one of FilterFields.
+func isFilterField(field FilterField) bool {
+	for _, value := range FilterFields {
+		if field == value {
+			return true
+		}
+	}
+	return false
+}
+
+// isOrderedFilterField godoc
+//
+// Returns true for the fields which can be compared with <, <=, > and >=.
+func isOrderedFilterField(field FilterField) bool {
+	switch field {
+	case FilterFieldPriority, FilterFieldDue, FilterFieldCreated, FilterFieldUpdated, FilterFieldId:
+		return true
+	}
+	return false
+}
+
+// isEqualityFilterOperator godoc
+//
+// Returns true for the operators which every field supports: `:`, `=` and `!=`.
+func isEqualityFilterOperator(operator FilterOperator) bool {
+	switch operator {
+	case FilterOperatorMatch, FilterOperatorEqual, FilterOperatorNotEqual:
+		return true
+	}
+	return false
+}
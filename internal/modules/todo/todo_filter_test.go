@@ -0,0 +1,111 @@
+package todo
+
+import (
+	"errors"
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"github.com/rykeroc/todo-cli/internal/dateparse"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var filterDates = dateparse.NewParser(clock.NewFixedClock(testNow))
+
+func TestParseFilter(t *testing.T) {
+	t.Run("should parse expressions with precedence", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			expected   string
+		}{
+			{"status:open", "status:open"},
+			{"STATUS:Done", "status:Done"},
+			{"tag:a or tag:b and tag:c", "(tag:a or (tag:b and tag:c))"},
+			{"(tag:a or tag:b) and tag:c", "((tag:a or tag:b) and tag:c)"},
+			{"not tag:a and tag:b", "(not tag:a and tag:b)"},
+			{"not (tag:a or tag:b)", "not (tag:a or tag:b)"},
+			{"priority >= high", "priority>=high"},
+			{`name:"fix the bug"`, `name:"fix the bug"`},
+			{`name:"say \"hi\""`, `name:"say \"hi\""`},
+			{"project:none and parent!=3", "(project:none and parent!=3)"},
+			{"id<10 And due<+7d OR created>=2026-10-01", "((id<10 and due<+7d) or created>=2026-10-01)"},
+		}
+		for _, test := range tests {
+			parsed, err := ParseFilter(test.expression, filterDates)
+			assert.NoError(t, err, test.expression)
+			assert.Equal(t, test.expected, parsed.String(), test.expression)
+		}
+	})
+
+	t.Run("should resolve values", func(t *testing.T) {
+		parsed, err := ParseFilter("priority>=high", filterDates)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(PriorityHigh), parsed.(*FilterComparison).Value)
+
+		parsed, err = ParseFilter("tag:Backend", filterDates)
+		assert.NoError(t, err)
+		assert.Equal(t, "backend", parsed.(*FilterComparison).Value)
+
+		parsed, err = ParseFilter("due:none", filterDates)
+		assert.NoError(t, err)
+		assert.Nil(t, parsed.(*FilterComparison).Value)
+	})
+
+	t.Run("should compare whole days", func(t *testing.T) {
+		startOfToday := time.Date(2026, time.October, 14, 0, 0, 0, 0, time.Local)
+
+		parsed, err := ParseFilter("due:today", filterDates)
+		assert.NoError(t, err)
+		comparison := parsed.(*FilterComparison)
+		assert.Equal(t, startOfToday, comparison.Value)
+		assert.Equal(t, startOfToday.AddDate(0, 0, 1), comparison.Until)
+
+		parsed, err = ParseFilter("due<today", filterDates)
+		assert.NoError(t, err)
+		assert.Equal(t, startOfToday, parsed.(*FilterComparison).Value)
+
+		parsed, err = ParseFilter("due<=today", filterDates)
+		assert.NoError(t, err)
+		assert.Equal(t, startOfToday.AddDate(0, 0, 1).Add(-time.Second), parsed.(*FilterComparison).Value)
+
+		parsed, err = ParseFilter("due<+7d", filterDates)
+		assert.NoError(t, err)
+		assert.Equal(t, testNow.AddDate(0, 0, 7), parsed.(*FilterComparison).Value)
+	})
+
+	t.Run("should return an error pointing to the offending column", func(t *testing.T) {
+		tests := []struct {
+			expression string
+			column     int
+			reason     string
+		}{
+			{"", 1, "the filter is empty"},
+			{"stat:open", 1, "unknown field 'stat'"},
+			{"status:open and stat:open", 17, "unknown field 'stat'"},
+			{"status open", 8, "expected an operator"},
+			{"status:", 8, "expected a value"},
+			{"status:finished", 8, "'finished' is not a status"},
+			{"tag<a", 4, "'tag' cannot be compared with '<'"},
+			{"priority>=huge", 11, "'huge' is not a priority"},
+			{"due<soonish", 5, "'soonish' is not a date expression"},
+			{"id:-1", 4, "'-1' is not a valid ID"},
+			{"(tag:a or tag:b", 16, "expected ')' to close the '(' at column 1"},
+			{"tag:a tag:b", 7, "expected 'and', 'or' or the end of the filter, found 'tag'"},
+			{"tag:a and", 10, "unexpected end of the filter"},
+			{"tag:a and )", 11, "unexpected ')'"},
+			{`name:"open`, 6, "the string is not terminated"},
+			{"tag!a", 4, "'!' is not an operator"},
+		}
+		for _, test := range tests {
+			parsed, err := ParseFilter(test.expression, filterDates)
+			assert.Nil(t, parsed, test.expression)
+			assert.ErrorIs(t, err, ErrValidation, test.expression)
+
+			var filterErr *FilterError
+			if assert.True(t, errors.As(err, &filterErr), test.expression) {
+				assert.Equal(t, test.expression, filterErr.Expression)
+				assert.Equal(t, test.column, filterErr.Column, test.expression)
+				assert.Contains(t, filterErr.Reason, test.reason, test.expression)
+			}
+		}
+	})
+}
@@ -0,0 +1,1264 @@
+package todo
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"gopkg.in/yaml.v3"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+)
+
+// OutputFormat godoc
+//
+// Defines how items are printed.
+type OutputFormat string
+
+const (
+	// OutputFormatTable prints items as a human-readable table. This is the default.
+	OutputFormatTable OutputFormat = "table"
+	// OutputFormatJson prints items as JSON following the item schema.
+	OutputFormatJson OutputFormat = "json"
+	// OutputFormatYaml prints items as YAML following the item schema.
+	OutputFormatYaml OutputFormat = "yaml"
+	// OutputFormatCsv prints items as comma-separated values with a header row.
+	OutputFormatCsv OutputFormat = "csv"
+	// OutputFormatTsv prints items as tab-separated values with a header row.
+	OutputFormatTsv OutputFormat = "tsv"
+)
+
+// OutputFormats godoc
+//
+// Lists the supported output formats in the order they are documented.
+var OutputFormats = []OutputFormat{
+	OutputFormatTable, OutputFormatJson, OutputFormatYaml, OutputFormatCsv, OutputFormatTsv,
+}
+
+// formatters godoc
+//
+// Constructors of the formatter of each output format. New output formats are registered here.
+var formatters = map[OutputFormat]func(FormatOptions) Formatter{
+	OutputFormatTable: func(options FormatOptions) Formatter { return &tableFormatter{options: options} },
+	OutputFormatJson:  func(FormatOptions) Formatter { return &jsonFormatter{} },
+	OutputFormatYaml:  func(FormatOptions) Formatter { return &yamlFormatter{} },
+	OutputFormatCsv:   func(FormatOptions) Formatter { return &delimitedFormatter{writeRecords: writeCsvRecords} },
+	OutputFormatTsv:   func(FormatOptions) Formatter { return &delimitedFormatter{writeRecords: writeTsvRecords} },
+}
+
+// FormatOptions godoc
+//
+// Defines how tables are rendered. The other output formats follow the item schema and ignore these options.
+type FormatOptions struct {
+	// DateFormat is the Go time layout of the dates in tables, time.DateTime when empty.
+	DateFormat string
+	// Color highlights overdue items, items due today and completed items with ANSI colors.
+	Color bool
+	// Clock provides the time which due dates are compared to, the system clock when nil.
+	Clock clock.Clock
+	// Columns lists the columns of item tables in the order they are printed, every column of ItemColumns when empty.
+	Columns []ItemColumn
+	// Width is the number of characters available for a line. Names are shortened so that item tables fit in it,
+	// unless it is 0.
+	Width int
+	// RelativeTime prints the dates of item tables relative to the current time, e.g. "3h ago" or "in 2d".
+	RelativeTime bool
+}
+
+// ItemColumn godoc
+//
+// Defines a column of item tables.
+type ItemColumn string
+
+// Columns of item tables, named as they are selected with `list --columns`.
+const (
+	ItemColumnId       ItemColumn = "id"
+	ItemColumnName     ItemColumn = "name"
+	ItemColumnProject  ItemColumn = "project"
+	ItemColumnTags     ItemColumn = "tags"
+	ItemColumnPriority ItemColumn = "priority"
+	ItemColumnDue      ItemColumn = "due"
+	ItemColumnRepeats  ItemColumn = "repeats"
+	ItemColumnUpdated  ItemColumn = "updated"
+	ItemColumnCreated  ItemColumn = "created"
+	ItemColumnStatus   ItemColumn = "status"
+	ItemColumnBlocked  ItemColumn = "blocked"
+	// ItemColumnDeleted is the time an item was moved to the trash. It is not printed by default.
+	ItemColumnDeleted ItemColumn = "deleted"
+)
+
+// ItemColumns godoc
+//
+// Lists the columns of item tables in the order they are printed by default.
+var ItemColumns = []ItemColumn{
+	ItemColumnId, ItemColumnName, ItemColumnProject, ItemColumnTags, ItemColumnPriority, ItemColumnDue,
+	ItemColumnRepeats, ItemColumnUpdated, ItemColumnCreated, ItemColumnStatus, ItemColumnBlocked,
+}
+
+// itemColumnHeaders godoc
+//
+// Header of each column of item tables.
+var itemColumnHeaders = map[ItemColumn]string{
+	ItemColumnId:       "ID",
+	ItemColumnName:     "Name",
+	ItemColumnProject:  "Project",
+	ItemColumnTags:     "Tags",
+	ItemColumnPriority: "Priority",
+	ItemColumnDue:      "Due",
+	ItemColumnRepeats:  "Repeats",
+	ItemColumnUpdated:  "Last Updated",
+	ItemColumnCreated:  "Created",
+	ItemColumnStatus:   "Status",
+	ItemColumnBlocked:  "Blocked",
+	ItemColumnDeleted:  "Deleted",
+}
+
+// ParseItemColumns godoc
+//
+// Converts a comma-separated list of column names, e.g. `id,name,due,tags`, into the columns of item tables. An
+// empty list selects every column.
+//
+// Returns nil and error wrapping ErrValidation when a column is unknown or repeated.
+//
+// Returns the columns in the order they are listed and nil on success.
+func ParseItemColumns(value string) ([]ItemColumn, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var columns []ItemColumn
+	seen := map[ItemColumn]bool{}
+	for _, part := range strings.Split(value, ",") {
+		column := ItemColumn(strings.ToLower(strings.TrimSpace(part)))
+		if _, ok := itemColumnHeaders[column]; !ok {
+			var names []string
+			for _, known := range ItemColumns {
+				names = append(names, string(known))
+			}
+			return nil, fmt.Errorf("ParseItemColumns: %w", newValidationError(
+				"columns", "'%s' is not one of %s", strings.TrimSpace(part), strings.Join(names, ", "),
+			))
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("ParseItemColumns: %w", newValidationError(
+				"columns", "'%s' is listed more than once", column,
+			))
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// ANSI escape sequences used to highlight table rows.
+const (
+	colorBold   = "\x1b[1m"
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorYellow = "\x1b[33m"
+	colorFaint  = "\x1b[2m"
+)
+
+// dateFormat godoc
+//
+// Returns the layout of the dates in tables.
+func (o FormatOptions) dateFormat() string {
+	if o.DateFormat == "" {
+		return time.DateTime
+	}
+	return o.DateFormat
+}
+
+// columns godoc
+//
+// Returns the columns of item tables.
+func (o FormatOptions) columns() []ItemColumn {
+	if len(o.Columns) == 0 {
+		return ItemColumns
+	}
+	return o.Columns
+}
+
+// formatTableTime godoc
+//
+// Returns a date of an item table, relative to the current time when RelativeTime is set.
+func (o FormatOptions) formatTableTime(t time.Time) string {
+	if o.RelativeTime {
+		return formatRelativeTime(t, o.now())
+	}
+	return t.Format(o.dateFormat())
+}
+
+// now godoc
+//
+// Returns the current time of the clock of the options.
+func (o FormatOptions) now() time.Time {
+	if o.Clock == nil {
+		return time.Now()
+	}
+	return o.Clock.Now()
+}
+
+// ParseOutputFormat godoc
+//
+// Converts an output format name into an OutputFormat. An empty name selects OutputFormatTable.
+//
+// Returns OutputFormatTable and error when the name is not a supported output format.
+//
+// Returns the matching OutputFormat and nil on success.
+func ParseOutputFormat(value string) (OutputFormat, error) {
+	normalized := OutputFormat(strings.ToLower(strings.TrimSpace(value)))
+	if normalized == "" {
+		return OutputFormatTable, nil
+	}
+	if _, ok := formatters[normalized]; !ok {
+		var names []string
+		for _, format := range OutputFormats {
+			names = append(names, string(format))
+		}
+		return OutputFormatTable, fmt.Errorf(
+			"ParseOutputFormat: '%s' is not one of %s", value, strings.Join(names, ", "),
+		)
+	}
+	return normalized, nil
+}
+
+// Formatter godoc
+//
+// An interface that defines how items are rendered for an output format.
+type Formatter interface {
+	// FormatItems renders a list of items. When tree is true, subtasks are rendered below their parent.
+	FormatItems(items []Item, tree bool) (string, error)
+	// FormatItem renders every field of a single item along with the items it is related to.
+	FormatItem(details ItemDetails) (string, error)
+	// FormatSearchResults renders the items matching a search, in the order of the results.
+	FormatSearchResults(results []SearchResult) (string, error)
+}
+
+// NewFormatter godoc
+//
+// Creates the Formatter of the output format with the default FormatOptions. An empty output format selects the
+// table formatter.
+//
+// Returns nil and error when the output format is not supported.
+//
+// Returns the Formatter and nil on success.
+func NewFormatter(format OutputFormat) (Formatter, error) {
+	formatter, err := NewFormatterWithOptions(format, FormatOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("NewFormatter: %v", err)
+	}
+	return formatter, nil
+}
+
+// NewFormatterWithOptions godoc
+//
+// Creates the Formatter of the output format, rendering tables with the options. An empty output format selects the
+// table formatter.
+//
+// Returns nil and error when the output format is not supported.
+//
+// Returns the Formatter and nil on success.
+func NewFormatterWithOptions(format OutputFormat, options FormatOptions) (Formatter, error) {
+	if format == "" {
+		format = OutputFormatTable
+	}
+	newFormatter, ok := formatters[format]
+	if !ok {
+		return nil, fmt.Errorf("NewFormatterWithOptions: '%s' is not a supported output format", format)
+	}
+	return newFormatter(options), nil
+}
+
+// ItemDetails godoc
+//
+// Defines an item along with the items it is related to, as shown by the detail view.
+type ItemDetails struct {
+	Item Item
+	// DependsOnIds holds the IDs of the items which the item depends on.
+	DependsOnIds []int64
+	// SubtaskIds holds the IDs of the direct subtasks of the item.
+	SubtaskIds []int64
+}
+
+// itemDocument godoc
+//
+// Defines the JSON and YAML representation of an item. Optional fields are null when unset and timestamps are
+// formatted as RFC 3339.
+//
+// The field names are part of the documented item schema and must not change.
+type itemDocument struct {
+	Id          int64  `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	Status      string `json:"status" yaml:"status"`
+	IsCompleted bool   `json:"isCompleted" yaml:"isCompleted"`
+	IsBlocked   bool   `json:"isBlocked" yaml:"isBlocked"`
+	// StatusReason is the reason an item is blocked or waiting.
+	StatusReason *string    `json:"statusReason" yaml:"statusReason"`
+	ProjectId    *int64     `json:"projectId" yaml:"projectId"`
+	Project      *string    `json:"project" yaml:"project"`
+	ParentId     *int64     `json:"parentId" yaml:"parentId"`
+	Tags         []string   `json:"tags" yaml:"tags"`
+	Priority     string     `json:"priority" yaml:"priority"`
+	DueAt        *time.Time `json:"dueAt" yaml:"dueAt"`
+	Recurrence   *string    `json:"recurrence" yaml:"recurrence"`
+	Description  string     `json:"description" yaml:"description"`
+	UpdatedAt    time.Time  `json:"updatedAt" yaml:"updatedAt"`
+	CreatedAt    time.Time  `json:"createdAt" yaml:"createdAt"`
+	// DeletedAt is only set for items in the trash.
+	DeletedAt *time.Time `json:"deletedAt,omitempty" yaml:"deletedAt,omitempty"`
+}
+
+// itemDetailsDocument godoc
+//
+// Defines the JSON and YAML representation of ItemDetails: the item schema with the IDs of the related items.
+type itemDetailsDocument struct {
+	itemDocument `yaml:",inline"`
+	DependsOn    []int64 `json:"dependsOn" yaml:"dependsOn"`
+	Subtasks     []int64 `json:"subtasks" yaml:"subtasks"`
+}
+
+// newItemDocument godoc
+//
+// Returns the document of the item.
+func newItemDocument(item Item) itemDocument {
+	document := itemDocument{
+		Id:          item.GetId(),
+		Name:        item.GetName(),
+		Status:      item.GetStatus().String(),
+		IsCompleted: item.GetStatus() == StatusDone,
+		IsBlocked:   item.GetIsBlocked(),
+		Tags:        item.GetTags(),
+		Priority:    item.GetPriority().String(),
+		Description: item.GetDescription(),
+		UpdatedAt:   item.GetUpdatedAt(),
+		CreatedAt:   item.GetCreatedAt(),
+	}
+	if item.GetStatusReason() != "" {
+		reason := item.GetStatusReason()
+		document.StatusReason = &reason
+	}
+	if item.GetProjectId() != 0 {
+		projectId, projectName := item.GetProjectId(), item.GetProjectName()
+		document.ProjectId, document.Project = &projectId, &projectName
+	}
+	if item.GetParentId() != 0 {
+		parentId := item.GetParentId()
+		document.ParentId = &parentId
+	}
+	if !item.GetDueAt().IsZero() {
+		dueAt := item.GetDueAt()
+		document.DueAt = &dueAt
+	}
+	if item.GetRecurrence() != "" {
+		rule := item.GetRecurrence()
+		document.Recurrence = &rule
+	}
+	if !item.GetDeletedAt().IsZero() {
+		deletedAt := item.GetDeletedAt()
+		document.DeletedAt = &deletedAt
+	}
+	// Empty lists are encoded as [] rather than null
+	if document.Tags == nil {
+		document.Tags = []string{}
+	}
+	return document
+}
+
+// newItemDocuments godoc
+//
+// Returns the documents of the items, in tree order when tree is true. Never returns nil, so that an empty list is
+// encoded as [].
+func newItemDocuments(items []Item, tree bool) []itemDocument {
+	if tree {
+		items, _ = orderItemTree(items)
+	}
+	documents := make([]itemDocument, 0, len(items))
+	for _, item := range items {
+		documents = append(documents, newItemDocument(item))
+	}
+	return documents
+}
+
+// newItemDetailsDocument godoc
+//
+// Returns the document of the item details.
+func newItemDetailsDocument(details ItemDetails) itemDetailsDocument {
+	document := itemDetailsDocument{
+		itemDocument: newItemDocument(details.Item),
+		DependsOn:    details.DependsOnIds,
+		Subtasks:     details.SubtaskIds,
+	}
+	if document.DependsOn == nil {
+		document.DependsOn = []int64{}
+	}
+	if document.Subtasks == nil {
+		document.Subtasks = []int64{}
+	}
+	return document
+}
+
+// tableFormatter godoc
+//
+// Renders items as human-readable tables.
+//
+// Implements the Formatter interface.
+type tableFormatter struct {
+	options FormatOptions
+}
+
+// FormatItems godoc
+//
+// Returns a string representation of a tabular list of the items that are passed in. When tree is true, subtasks
+// are listed below their parent with an indented name.
+//
+// Returns empty string and error on error writing the list with the tab writer.
+//
+// Returns "No todo items..." and nil when items is an empty slice.
+//
+// Returns items in a tabular format and nil on success.
+func (f *tableFormatter) FormatItems(items []Item, tree bool) (string, error) {
+	var depths map[int64]int
+	if tree {
+		items, depths = orderItemTree(items)
+	}
+	tabularList, err := writeItemTable(items, depths, f.options)
+	if err != nil {
+		return "", fmt.Errorf("tableFormatter.FormatItems: %v", err)
+	}
+	return tabularList, nil
+}
+
+// FormatItem godoc
+//
+// Returns a string representation of every field of the item in a key/value layout, followed by its notes.
+//
+// Returns empty string and error when the item is nil or on error writing the details with the tab writer.
+//
+// Returns the details and nil on success.
+func (f *tableFormatter) FormatItem(details ItemDetails) (string, error) {
+	item := details.Item
+	if item == nil {
+		return "", fmt.Errorf("tableFormatter.FormatItem: item is nil")
+	}
+
+	orDash := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(f.options.dateFormat())
+	}
+	var priority, parent string
+	if item.GetPriority() != PriorityNone {
+		priority = item.GetPriority().String()
+	}
+	if item.GetParentId() != 0 {
+		parent = strconv.FormatInt(item.GetParentId(), 10)
+	}
+	var repeats string
+	if item.GetRecurrence() != "" {
+		repeats = describeRecurrence(item.GetRecurrence())
+	}
+
+	var buffer bytes.Buffer
+	tw := tabwriter.NewWriter(&buffer, 0, 4, 2, ' ', 0)
+	fields := [][2]string{
+		{"ID", strconv.FormatInt(item.GetId(), 10)},
+		{"Name", item.GetName()},
+		{"Status", itemStatusWithReason(item)},
+		{"Project", orDash(item.GetProjectName())},
+		{"Parent", orDash(parent)},
+		{"Subtasks", orDash(joinIds(details.SubtaskIds, ", "))},
+		{"Depends On", orDash(joinIds(details.DependsOnIds, ", "))},
+		{"Tags", orDash(strings.Join(item.GetTags(), ", "))},
+		{"Priority", orDash(priority)},
+		{"Due", orDash(formatTime(item.GetDueAt()))},
+		{"Repeats", orDash(repeats)},
+		{"Last Updated", formatTime(item.GetUpdatedAt())},
+		{"Created", formatTime(item.GetCreatedAt())},
+	}
+	for _, field := range fields {
+		if _, err := fmt.Fprintf(tw, "%s:\t%s\n", field[0], field[1]); err != nil {
+			return "", fmt.Errorf("tableFormatter.FormatItem: Error writing field %s: %v", field[0], err)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return "", fmt.Errorf("tableFormatter.FormatItem: Failed to flush tabWriter: %v", err)
+	}
+
+	buffer.WriteString("\nNotes:\n")
+	if item.GetDescription() == "" {
+		buffer.WriteString("  -\n")
+	} else {
+		for _, line := range strings.Split(item.GetDescription(), "\n") {
+			buffer.WriteString("  " + line + "\n")
+		}
+	}
+	return buffer.String(), nil
+}
+
+// FormatSearchResults godoc
+//
+// Returns a table of the items matching a search, with the matches highlighted in their name and in a snippet of
+// their notes. Matches are bold when colors are enabled and between brackets otherwise.
+//
+// Returns "No matching todo items..." and nil when results is empty.
+//
+// Returns the table and nil on success.
+func (f *tableFormatter) FormatSearchResults(results []SearchResult) (string, error) {
+	if len(results) == 0 {
+		return "No matching todo items...\n", nil
+	}
+
+	highlightStart, highlightEnd := "[", "]"
+	if f.options.Color {
+		highlightStart, highlightEnd = colorBold, colorReset
+	}
+	highlight := strings.NewReplacer(MatchStart, highlightStart, MatchEnd, highlightEnd)
+	plain := strings.NewReplacer(colorBold, "", colorReset, "")
+
+	rows := [][]string{
+		{"ID", "Name", "Project", "Status", "Due", "Notes"},
+		{"--", "----", "-------", "------", "---", "-----"},
+	}
+	for _, result := range results {
+		item := result.Item
+		project, due, notes := "-", "-", "-"
+		if item.GetProjectName() != "" {
+			project = item.GetProjectName()
+		}
+		if !item.GetDueAt().IsZero() {
+			due = item.GetDueAt().Format(f.options.dateFormat())
+		}
+		if result.NotesSnippet != "" {
+			notes = strings.Join(strings.Fields(result.NotesSnippet), " ")
+		}
+		name := result.NameHighlight
+		if name == "" {
+			name = item.GetName()
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(item.GetId(), 10), highlight.Replace(name), project, item.GetStatus().String(), due,
+			highlight.Replace(notes),
+		})
+	}
+
+	// tabwriter would count the escape sequences of the highlights in the width of the columns
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for column, cell := range row {
+			widths[column] = max(widths[column], utf8.RuneCountInString(plain.Replace(cell)))
+		}
+	}
+	var builder strings.Builder
+	for _, row := range rows {
+		for column, cell := range row {
+			builder.WriteString(cell)
+			if column < len(row)-1 {
+				builder.WriteString(strings.Repeat(" ", widths[column]-utf8.RuneCountInString(plain.Replace(cell))+4))
+			}
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String(), nil
+}
+
+// jsonFormatter godoc
+//
+// Renders items as indented JSON following the item schema.
+//
+// Implements the Formatter interface.
+type jsonFormatter struct{}
+
+// FormatItems godoc
+//
+// Returns the items as a JSON array of item objects.
+//
+// Returns empty string and error when the items cannot be encoded.
+//
+// Returns the JSON array and nil on success.
+func (f *jsonFormatter) FormatItems(items []Item, tree bool) (string, error) {
+	encoded, err := json.MarshalIndent(newItemDocuments(items, tree), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("jsonFormatter.FormatItems: %v", err)
+	}
+	return string(encoded) + "\n", nil
+}
+
+// FormatItem godoc
+//
+// Returns the item as a JSON object, with the IDs of its dependencies and subtasks.
+//
+// Returns empty string and error when the item is nil or cannot be encoded.
+//
+// Returns the JSON object and nil on success.
+func (f *jsonFormatter) FormatItem(details ItemDetails) (string, error) {
+	if details.Item == nil {
+		return "", fmt.Errorf("jsonFormatter.FormatItem: item is nil")
+	}
+	encoded, err := json.MarshalIndent(newItemDetailsDocument(details), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("jsonFormatter.FormatItem: %v", err)
+	}
+	return string(encoded) + "\n", nil
+}
+
+// FormatSearchResults godoc
+//
+// Returns the items matching a search as a JSON array of item objects, the most relevant first. The highlights
+// are only rendered by tables.
+//
+// Returns empty string and error when the items cannot be encoded.
+//
+// Returns the items and nil on success.
+func (f *jsonFormatter) FormatSearchResults(results []SearchResult) (string, error) {
+	formatted, err := f.FormatItems(searchResultItems(results), false)
+	if err != nil {
+		return "", fmt.Errorf("jsonFormatter.FormatSearchResults: %v", err)
+	}
+	return formatted, nil
+}
+
+// yamlFormatter godoc
+//
+// Renders items as YAML following the item schema.
+//
+// Implements the Formatter interface.
+type yamlFormatter struct{}
+
+// FormatItems godoc
+//
+// Returns the items as a YAML sequence of item mappings.
+//
+// Returns empty string and error when the items cannot be encoded.
+//
+// Returns the YAML sequence and nil on success.
+func (f *yamlFormatter) FormatItems(items []Item, tree bool) (string, error) {
+	encoded, err := yaml.Marshal(newItemDocuments(items, tree))
+	if err != nil {
+		return "", fmt.Errorf("yamlFormatter.FormatItems: %v", err)
+	}
+	return string(encoded), nil
+}
+
+// FormatItem godoc
+//
+// Returns the item as a YAML mapping, with the IDs of its dependencies and subtasks.
+//
+// Returns empty string and error when the item is nil or cannot be encoded.
+//
+// Returns the YAML mapping and nil on success.
+func (f *yamlFormatter) FormatItem(details ItemDetails) (string, error) {
+	if details.Item == nil {
+		return "", fmt.Errorf("yamlFormatter.FormatItem: item is nil")
+	}
+	encoded, err := yaml.Marshal(newItemDetailsDocument(details))
+	if err != nil {
+		return "", fmt.Errorf("yamlFormatter.FormatItem: %v", err)
+	}
+	return string(encoded), nil
+}
+
+// FormatSearchResults godoc
+//
+// Returns the items matching a search as a YAML sequence of item mappings, the most relevant first. The highlights
+// are only rendered by tables.
+//
+// Returns empty string and error when the items cannot be encoded.
+//
+// Returns the items and nil on success.
+func (f *yamlFormatter) FormatSearchResults(results []SearchResult) (string, error) {
+	formatted, err := f.FormatItems(searchResultItems(results), false)
+	if err != nil {
+		return "", fmt.Errorf("yamlFormatter.FormatSearchResults: %v", err)
+	}
+	return formatted, nil
+}
+
+// itemRecordHeader godoc
+//
+// Names of the columns of a delimited item record. They match the field names of the item schema.
+var itemRecordHeader = []string{
+	"id", "name", "status", "isCompleted", "isBlocked", "projectId", "project", "parentId", "tags", "priority",
+	"dueAt", "recurrence", "description", "updatedAt", "createdAt", "statusReason",
+}
+
+// delimitedFormatter godoc
+//
+// Renders items as delimited records with a header row. Unset fields are empty, lists are joined with commas and
+// timestamps are formatted as RFC 3339.
+//
+// Implements the Formatter interface.
+type delimitedFormatter struct {
+	writeRecords func(records [][]string) (string, error)
+}
+
+// FormatItems godoc
+//
+// Returns a header row followed by one record per item.
+//
+// Returns empty string and error when the records cannot be written.
+//
+// Returns the records and nil on success.
+func (f *delimitedFormatter) FormatItems(items []Item, tree bool) (string, error) {
+	records := [][]string{itemRecordHeader}
+	for _, document := range newItemDocuments(items, tree) {
+		records = append(records, newItemRecord(document))
+	}
+	result, err := f.writeRecords(records)
+	if err != nil {
+		return "", fmt.Errorf("delimitedFormatter.FormatItems: %v", err)
+	}
+	return result, nil
+}
+
+// FormatItem godoc
+//
+// Returns a header row followed by the record of the item, with dependsOn and subtasks columns.
+//
+// Returns empty string and error when the item is nil or the records cannot be written.
+//
+// Returns the records and nil on success.
+func (f *delimitedFormatter) FormatItem(details ItemDetails) (string, error) {
+	if details.Item == nil {
+		return "", fmt.Errorf("delimitedFormatter.FormatItem: item is nil")
+	}
+	document := newItemDetailsDocument(details)
+	header := append(append([]string{}, itemRecordHeader...), "dependsOn", "subtasks")
+	record := append(
+		newItemRecord(document.itemDocument), joinIds(document.DependsOn, ","), joinIds(document.Subtasks, ","),
+	)
+	result, err := f.writeRecords([][]string{header, record})
+	if err != nil {
+		return "", fmt.Errorf("delimitedFormatter.FormatItem: %v", err)
+	}
+	return result, nil
+}
+
+// FormatSearchResults godoc
+//
+// Returns the items matching a search as a header row and one record per item, the most relevant first. The
+// highlights are only rendered by tables.
+//
+// Returns empty string and error when the items cannot be encoded.
+//
+// Returns the items and nil on success.
+func (f *delimitedFormatter) FormatSearchResults(results []SearchResult) (string, error) {
+	formatted, err := f.FormatItems(searchResultItems(results), false)
+	if err != nil {
+		return "", fmt.Errorf("delimitedFormatter.FormatSearchResults: %v", err)
+	}
+	return formatted, nil
+}
+
+// newItemRecord godoc
+//
+// Returns the values of the document in the order of itemRecordHeader.
+func newItemRecord(document itemDocument) []string {
+	formatOptionalId := func(id *int64) string {
+		if id == nil {
+			return ""
+		}
+		return strconv.FormatInt(*id, 10)
+	}
+	formatOptionalString := func(value *string) string {
+		if value == nil {
+			return ""
+		}
+		return *value
+	}
+	var dueAt string
+	if document.DueAt != nil {
+		dueAt = document.DueAt.Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatInt(document.Id, 10),
+		document.Name,
+		document.Status,
+		strconv.FormatBool(document.IsCompleted),
+		strconv.FormatBool(document.IsBlocked),
+		formatOptionalId(document.ProjectId),
+		formatOptionalString(document.Project),
+		formatOptionalId(document.ParentId),
+		strings.Join(document.Tags, ","),
+		document.Priority,
+		dueAt,
+		formatOptionalString(document.Recurrence),
+		document.Description,
+		document.UpdatedAt.Format(time.RFC3339),
+		document.CreatedAt.Format(time.RFC3339),
+		formatOptionalString(document.StatusReason),
+	}
+}
+
+// writeCsvRecords godoc
+//
+// Writes the records as RFC 4180 comma-separated values, quoting values when needed.
+func writeCsvRecords(records [][]string) (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll(records); err != nil {
+		return "", fmt.Errorf("writeCsvRecords: %v", err)
+	}
+	return buffer.String(), nil
+}
+
+// tsvEscaper godoc
+//
+// Escapes the characters which cannot appear in a tab-separated value.
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// writeTsvRecords godoc
+//
+// Writes the records as tab-separated values. Backslashes, tabs and line breaks within values are escaped as \\,
+// \t, \n and \r, so that every record is a single line.
+func writeTsvRecords(records [][]string) (string, error) {
+	var buffer strings.Builder
+	for _, record := range records {
+		for index, value := range record {
+			if index > 0 {
+				buffer.WriteByte('\t')
+			}
+			buffer.WriteString(tsvEscaper.Replace(value))
+		}
+		buffer.WriteByte('\n')
+	}
+	return buffer.String(), nil
+}
+
+// FormatJournalTable godoc
+//
+// Returns a string representation of a tabular list of the journal entries that are passed in, with the dates
+// formatted following the options.
+//
+// Returns empty string and error on error writing the list with the tab writer.
+//
+// Returns "No history..." and nil when entries is an empty slice.
+//
+// Returns entries in a tabular format and nil on success.
+func FormatJournalTable(entries []JournalEntry, options FormatOptions) (string, error) {
+	if len(entries) == 0 {
+		return "No history...\n", nil
+	}
+
+	var buffer bytes.Buffer
+
+	padding := 4
+	tabWidth := 4
+	tw := tabwriter.NewWriter(&buffer, 0, tabWidth, padding, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "ID\tWhen\tOperation\tItems\tUndone"); err != nil {
+		return "", fmt.Errorf("FormatJournalTable: Error writing table header to tabWriter: %v", err)
+	}
+	if _, err := fmt.Fprintln(tw, "--\t----\t---------\t-----\t------"); err != nil {
+		return "", fmt.Errorf("FormatJournalTable: Error writing table header to tabWriter: %v", err)
+	}
+	for _, entry := range entries {
+		undone := "-"
+		if entry.IsUndone {
+			undone = "yes"
+		}
+		_, err := fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%d\t%s\n",
+			entry.Id,
+			options.formatTableTime(entry.CreatedAt),
+			entry.Summary,
+			len(entry.Changes),
+			undone,
+		)
+		if err != nil {
+			return "", fmt.Errorf("FormatJournalTable: Error writing entry %d: %v", entry.Id, err)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return "", fmt.Errorf("FormatJournalTable: Failed to flush tabWriter: %v", err)
+	}
+	return buffer.String(), nil
+}
+
+// FormatItemEventLog godoc
+//
+// Returns a diff-style representation of the item events that are passed in, oldest first. The events recorded
+// together are listed below a line with their time and origin, and with the item when showItems is true. Old values
+// are prefixed with "-" and new values with "+":
+//
+//	2026-10-14 10:30:00  update  item 3: Ship it
+//	  - dueAt: 2026-10-20 17:00:00
+//	  + dueAt: 2026-10-22 17:00:00
+//
+// Returns "No changes...\n" when events is an empty slice.
+func FormatItemEventLog(events []ItemEvent, showItems bool, options FormatOptions) string {
+	if len(events) == 0 {
+		return "No changes...\n"
+	}
+
+	var builder strings.Builder
+	for index, event := range events {
+		if index == 0 || !sameItemEventOperation(events[index-1], event) {
+			origin := event.Origin
+			if origin == "" {
+				origin = "-"
+			}
+			builder.WriteString(options.formatTableTime(event.CreatedAt) + "  " + origin)
+			if showItems {
+				fmt.Fprintf(&builder, "  item %d", event.ItemId)
+				if event.ItemName != "" {
+					builder.WriteString(": " + event.ItemName)
+				}
+			}
+			builder.WriteString("\n")
+		}
+		if event.OldValue != "" {
+			fmt.Fprintf(&builder, "  - %s: %s\n", event.Field, formatItemEventValue(event.Field, event.OldValue, options))
+		}
+		if event.NewValue != "" {
+			fmt.Fprintf(&builder, "  + %s: %s\n", event.Field, formatItemEventValue(event.Field, event.NewValue, options))
+		}
+	}
+	return builder.String()
+}
+
+// sameItemEventOperation godoc
+//
+// Returns true when both events belong to the same item and were recorded together by the same origin.
+func sameItemEventOperation(a ItemEvent, b ItemEvent) bool {
+	return a.ItemId == b.ItemId && a.Origin == b.Origin && a.CreatedAt.Equal(b.CreatedAt)
+}
+
+// formatItemEventValue godoc
+//
+// Returns the value of a field of an item event as it is printed: timestamps follow the options, lists are separated
+// by commas and spaces, and notes are reduced to their first line.
+func formatItemEventValue(field string, value string, options FormatOptions) string {
+	switch field {
+	case ItemEventFieldDueAt, ItemEventFieldDeletedAt:
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return options.formatTableTime(t.Local())
+		}
+	case ItemEventFieldTags, ItemEventFieldDependsOn:
+		return strings.ReplaceAll(value, ",", ", ")
+	case ItemEventFieldDescription:
+		if firstLine, _, found := strings.Cut(value, "\n"); found {
+			return firstLine + " …"
+		}
+	}
+	return value
+}
+
+// orderItemTree godoc
+//
+// Returns the items ordered so that subtasks come right after their parent, along with the depth of each item.
+//
+// Items whose parent is not passed in are top level items. The order of the passed in items is kept between
+// siblings.
+func orderItemTree(items []Item) ([]Item, map[int64]int) {
+	present := map[int64]bool{}
+	for _, item := range items {
+		present[item.GetId()] = true
+	}
+	var roots []Item
+	children := map[int64][]Item{}
+	for _, item := range items {
+		if present[item.GetParentId()] {
+			children[item.GetParentId()] = append(children[item.GetParentId()], item)
+		} else {
+			roots = append(roots, item)
+		}
+	}
+
+	var ordered []Item
+	depths := map[int64]int{}
+	var visit func(item Item, depth int)
+	visit = func(item Item, depth int) {
+		ordered = append(ordered, item)
+		depths[item.GetId()] = depth
+		for _, child := range children[item.GetId()] {
+			visit(child, depth+1)
+		}
+	}
+	for _, root := range roots {
+		visit(root, 0)
+	}
+	return ordered, depths
+}
+
+// writeItemTable godoc
+//
+// Writes the items as a table. The name of an item is indented by its depth in depths, if any. Dates are written
+// and rows are highlighted according to the options.
+//
+// Returns empty string and error on error writing the list with the tab writer.
+//
+// Returns "No todo items..." and nil when items is an empty slice.
+//
+// Returns items in a tabular format and nil on success.
+func writeItemTable(items []Item, depths map[int64]int, options FormatOptions) (string, error) {
+	if items == nil || len(items) == 0 {
+		return fmt.Sprintf("No todo items...\n"), nil
+	}
+
+	columns := options.columns()
+	header := make([]string, len(columns))
+	separator := make([]string, len(columns))
+	for index, column := range columns {
+		header[index] = itemColumnHeaders[column]
+		separator[index] = strings.Repeat("-", utf8.RuneCountInString(header[index]))
+	}
+	rows := [][]string{header, separator}
+	for _, item := range items {
+		row := make([]string, len(columns))
+		for index, column := range columns {
+			row[index] = itemCell(item, column, depths[item.GetId()], options)
+		}
+		rows = append(rows, row)
+	}
+
+	padding := 4
+	tabWidth := 4
+	if options.Width > 0 {
+		truncateItemNames(rows, columns, options.Width, padding)
+	}
+
+	var buffer bytes.Buffer
+	tw := tabwriter.NewWriter(&buffer, 0, tabWidth, padding, ' ', 0)
+	for index, row := range rows {
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			// The header and the separator come before the rows of the items
+			if index < 2 {
+				return "", fmt.Errorf("writeItemTable: Error writing table header to tabWriter: %v", err)
+			}
+			return "", fmt.Errorf("writeItemTable: Error writing item %d: %v", items[index-2].GetId(), err)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return "", fmt.Errorf(
+			"writeItemTable: Failed to flush tabWriter: %d", err,
+		)
+	}
+	if !options.Color {
+		return buffer.String(), nil
+	}
+	return highlightItemRows(buffer.String(), items, options.now()), nil
+}
+
+// itemCell godoc
+//
+// Returns the text of a column of an item table for the item, listed at depth in a tree.
+func itemCell(item Item, column ItemColumn, depth int, options FormatOptions) string {
+	switch column {
+	case ItemColumnId:
+		return strconv.FormatInt(item.GetId(), 10)
+	case ItemColumnName:
+		if depth > 0 {
+			return strings.Repeat("   ", depth-1) + "└─ " + item.GetName()
+		}
+		return item.GetName()
+	case ItemColumnProject:
+		if item.GetProjectName() != "" {
+			return item.GetProjectName()
+		}
+	case ItemColumnTags:
+		if len(item.GetTags()) > 0 {
+			return strings.Join(item.GetTags(), ", ")
+		}
+	case ItemColumnPriority:
+		if item.GetPriority() != PriorityNone {
+			return item.GetPriority().String()
+		}
+	case ItemColumnDue:
+		if !item.GetDueAt().IsZero() {
+			return options.formatTableTime(item.GetDueAt())
+		}
+	case ItemColumnRepeats:
+		if item.GetRecurrence() != "" {
+			return describeRecurrence(item.GetRecurrence())
+		}
+	case ItemColumnUpdated:
+		return options.formatTableTime(item.GetUpdatedAt())
+	case ItemColumnCreated:
+		return options.formatTableTime(item.GetCreatedAt())
+	case ItemColumnStatus:
+		return statusIcons[item.GetStatus()] + " " + item.GetStatus().String()
+	case ItemColumnBlocked:
+		if item.GetIsBlocked() {
+			return "⛔"
+		}
+	case ItemColumnDeleted:
+		if !item.GetDeletedAt().IsZero() {
+			return options.formatTableTime(item.GetDeletedAt())
+		}
+	}
+	return "-"
+}
+
+// minNameWidth godoc
+//
+// Number of characters below which names are not shortened to fit a table in the width of the terminal.
+const minNameWidth = 12
+
+// truncateItemNames godoc
+//
+// Shortens the names of the item rows, ending them with "…", so that the table fits in width characters once its
+// columns are separated by padding spaces. Names keep at least minNameWidth characters, so a table with many
+// columns can still be wider than width.
+func truncateItemNames(rows [][]string, columns []ItemColumn, width int, padding int) {
+	nameIndex := -1
+	for index, column := range columns {
+		if column == ItemColumnName {
+			nameIndex = index
+		}
+	}
+	if nameIndex < 0 {
+		return
+	}
+
+	columnWidths := make([]int, len(columns))
+	for _, row := range rows {
+		for index, cell := range row {
+			columnWidths[index] = max(columnWidths[index], utf8.RuneCountInString(cell))
+		}
+	}
+	tableWidth := padding * (len(columns) - 1)
+	for _, columnWidth := range columnWidths {
+		tableWidth += columnWidth
+	}
+	if tableWidth <= width {
+		return
+	}
+
+	nameWidth := max(columnWidths[nameIndex]-(tableWidth-width), minNameWidth)
+	// The header and the separator come before the rows of the items
+	for _, row := range rows[2:] {
+		name := []rune(row[nameIndex])
+		if len(name) > nameWidth {
+			row[nameIndex] = string(name[:nameWidth-1]) + "…"
+		}
+	}
+}
+
+// formatRelativeTime godoc
+//
+// Returns the time relative to now in its largest whole unit, e.g. "3h ago" or "in 2d". Times less than a minute
+// away are "now".
+func formatRelativeTime(t time.Time, now time.Time) string {
+	distance := now.Sub(t)
+	future := distance < 0
+	if future {
+		distance = -distance
+	}
+
+	var amount string
+	switch day := 24 * time.Hour; {
+	case distance < time.Minute:
+		return "now"
+	case distance < time.Hour:
+		amount = fmt.Sprintf("%dm", distance/time.Minute)
+	case distance < day:
+		amount = fmt.Sprintf("%dh", distance/time.Hour)
+	case distance < 7*day:
+		amount = fmt.Sprintf("%dd", distance/day)
+	case distance < 30*day:
+		amount = fmt.Sprintf("%dw", distance/(7*day))
+	case distance < 365*day:
+		amount = fmt.Sprintf("%dmo", distance/(30*day))
+	default:
+		amount = fmt.Sprintf("%dy", distance/(365*day))
+	}
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
+
+// highlightItemRows godoc
+//
+// Colors the rows of a table written by writeItemTable: overdue items in red, items due today in yellow and
+// completed items faint. The rows are colored once the columns are aligned, as escape sequences would be counted in
+// the width of the columns.
+//
+// Returns the table with highlighted rows.
+func highlightItemRows(table string, items []Item, now time.Time) string {
+	lines := strings.SplitAfter(table, "\n")
+	// The header and the separator come before the rows of the items
+	for index, item := range items {
+		color := itemColor(item, now)
+		if color == "" {
+			continue
+		}
+		lines[index+2] = color + strings.TrimSuffix(lines[index+2], "\n") + colorReset + "\n"
+	}
+	return strings.Join(lines, "")
+}
+
+// itemColor godoc
+//
+// Returns the escape sequence which highlights the row of an item, or an empty string when it is not highlighted.
+func itemColor(item Item, now time.Time) string {
+	dueAt := item.GetDueAt()
+	switch {
+	case item.GetStatus().IsClosed():
+		return colorFaint
+	case dueAt.IsZero():
+		return ""
+	case dueAt.Before(now):
+		return colorRed
+	case dueAt.Year() == now.Year() && dueAt.YearDay() == now.YearDay():
+		return colorYellow
+	}
+	return ""
+}
+
+// searchResultItems godoc
+//
+// Returns the items of search results, in the order of the results.
+func searchResultItems(results []SearchResult) []Item {
+	items := make([]Item, 0, len(results))
+	for _, result := range results {
+		items = append(items, result.Item)
+	}
+	return items
+}
+
+// statusIcons godoc
+//
+// Icon printed before the status of an item in item tables.
+var statusIcons = map[Status]string{
+	StatusTodo:       "⬜",
+	StatusInProgress: "🔄",
+	StatusBlocked:    "⛔",
+	StatusWaiting:    "⏳",
+	StatusDone:       "✅",
+	StatusCancelled:  "❌",
+}
+
+// itemStatusWithReason godoc
+//
+// Returns the status of the item followed by the reason it is blocked or waiting, if any.
+func itemStatusWithReason(item Item) string {
+	if item.GetStatusReason() != "" {
+		return fmt.Sprintf("%s (%s)", item.GetStatus(), item.GetStatusReason())
+	}
+	return item.GetStatus().String()
+}
+
+// joinIds godoc
+//
+// Returns the IDs joined by the separator.
+func joinIds(ids []int64, separator string) string {
+	var values []string
+	for _, id := range ids {
+		values = append(values, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(values, separator)
+}
@@ -0,0 +1,624 @@
+package todo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var table = &tableFormatter{}
+var jsonFormat = &jsonFormatter{}
+
+func TestParseOutputFormat(t *testing.T) {
+	for _, format := range OutputFormats {
+		t.Run("should parse "+string(format), func(t *testing.T) {
+			result, err := ParseOutputFormat(strings.ToUpper(string(format)))
+			assert.NoError(t, err)
+			assert.Equal(t, format, result)
+
+			formatter, err := NewFormatter(result)
+			assert.NoError(t, err)
+			assert.NotNil(t, formatter)
+		})
+	}
+
+	t.Run("should default to table", func(t *testing.T) {
+		result, err := ParseOutputFormat("")
+		assert.NoError(t, err)
+		assert.Equal(t, OutputFormatTable, result)
+	})
+
+	t.Run("should return error for unknown format", func(t *testing.T) {
+		_, err := ParseOutputFormat("xml")
+		assert.Error(t, err)
+
+		formatter, err := NewFormatter("xml")
+		assert.Error(t, err)
+		assert.Nil(t, formatter)
+	})
+}
+
+func TestTableFormatter_FormatItems(t *testing.T) {
+	t.Run("should return tabular list", func(t *testing.T) {
+		nowTime := testNow
+		items := []Item{
+			NewItem(1, "item 1", StatusTodo, time.Time{}, nowTime, nowTime),
+		}
+		result, err := table.FormatItems(items, false)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result)
+
+		assert.Contains(
+			t, result, strconv.FormatInt(items[0].GetId(), 10),
+		)
+		assert.Contains(
+			t, result, items[0].GetName(),
+		)
+		assert.Contains(
+			t, result, items[0].GetUpdatedAt().Format(time.DateOnly),
+		)
+		assert.Contains(
+			t, result, items[0].GetCreatedAt().Format(time.DateOnly),
+		)
+	})
+
+	t.Run("should include tags in tabular list", func(t *testing.T) {
+		item := NewItem(1, "item 1", StatusTodo, time.Time{}, testNow, testNow)
+		item.SetTags([]string{"backend", "urgent"})
+
+		result, err := table.FormatItems([]Item{item}, false)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "backend, urgent")
+	})
+
+	t.Run("should include due date in tabular list", func(t *testing.T) {
+		dueAt := time.Date(2030, time.January, 2, 15, 4, 5, 0, time.Local)
+		items := []Item{
+			NewItem(1, "item 1", StatusTodo, dueAt, testNow, testNow),
+		}
+		result, err := table.FormatItems(items, false)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Due")
+		assert.Contains(t, result, dueAt.Format(time.DateTime))
+	})
+
+	t.Run("should return 'No todo items...' when no items is nil", func(t *testing.T) {
+		result, err := table.FormatItems(nil, false)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "No todo items...")
+	})
+
+	t.Run("should return 'No todo items...' when no items is empty", func(t *testing.T) {
+		result, err := table.FormatItems([]Item{}, false)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "No todo items...")
+	})
+
+	t.Run("should list subtasks below their parent", func(t *testing.T) {
+		items := []Item{
+			NewItem(3, "grandchild", StatusTodo, time.Time{}, testNow, testNow),
+			NewItem(1, "parent", StatusTodo, time.Time{}, testNow, testNow),
+			NewItem(2, "child", StatusTodo, time.Time{}, testNow, testNow),
+			NewItem(4, "orphan", StatusTodo, time.Time{}, testNow, testNow),
+		}
+		items[0].SetParentId(2)
+		items[2].SetParentId(1)
+		// The parent of item 4 is filtered out, so it is listed as a top level item
+		items[3].SetParentId(10)
+
+		result, err := table.FormatItems(items, true)
+
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(result), "\n")
+		assert.Len(t, lines, 6)
+		assert.Regexp(t, `^1\s+parent\s`, lines[2])
+		assert.Regexp(t, `^2\s+└─ child\s`, lines[3])
+		assert.Regexp(t, `^3\s+   └─ grandchild\s`, lines[4])
+		assert.Regexp(t, `^4\s+orphan\s`, lines[5])
+	})
+
+	t.Run("should return 'No todo items...' when items is empty", func(t *testing.T) {
+		result, err := table.FormatItems(nil, true)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "No todo items...")
+	})
+
+	t.Run("should write dates with the date format of the options", func(t *testing.T) {
+		formatter, err := NewFormatterWithOptions(OutputFormatTable, FormatOptions{DateFormat: "02/01/2006"})
+		assert.NoError(t, err)
+
+		result, err := formatter.FormatItems([]Item{NewItem(1, "item 1", StatusTodo, testNow, testNow, testNow)}, false)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, testNow.Format("02/01/2006"))
+		assert.NotContains(t, result, testNow.Format(time.DateTime))
+	})
+
+	t.Run("should highlight overdue, due today and completed items when colors are enabled", func(t *testing.T) {
+		now := time.Date(2030, time.January, 2, 12, 0, 0, 0, time.Local)
+		items := []Item{
+			NewItem(1, "overdue", StatusTodo, now.Add(-time.Hour), now, now),
+			NewItem(2, "due today", StatusTodo, now.Add(time.Hour), now, now),
+			NewItem(3, "completed", StatusDone, now.Add(-time.Hour), now, now),
+			NewItem(4, "later", StatusTodo, now.Add(48*time.Hour), now, now),
+		}
+		formatter, _ := NewFormatterWithOptions(
+			OutputFormatTable, FormatOptions{Color: true, Clock: clock.NewFixedClock(now)},
+		)
+
+		result, err := formatter.FormatItems(items, false)
+
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(result), "\n")
+		assert.True(t, strings.HasPrefix(lines[2], colorRed+"1 "))
+		assert.True(t, strings.HasPrefix(lines[3], colorYellow+"2 "))
+		assert.True(t, strings.HasPrefix(lines[4], colorFaint+"3 "))
+		assert.True(t, strings.HasPrefix(lines[5], "4 "))
+		assert.True(t, strings.HasSuffix(lines[2], colorReset))
+	})
+
+	t.Run("should only write the columns of the options in their order", func(t *testing.T) {
+		item := NewItem(7, "item 7", StatusTodo, time.Time{}, testNow, testNow)
+		item.SetTags([]string{"backend"})
+		formatter, _ := NewFormatterWithOptions(
+			OutputFormatTable,
+			FormatOptions{Columns: []ItemColumn{ItemColumnTags, ItemColumnId, ItemColumnDue}},
+		)
+
+		result, err := formatter.FormatItems([]Item{item}, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Tags       ID    Due\n----       --    ---\nbackend    7     -\n", result)
+	})
+
+	t.Run("should write the time an item was moved to the trash", func(t *testing.T) {
+		trashed := NewItem(7, "item 7", StatusTodo, time.Time{}, testNow, testNow)
+		trashed.SetDeletedAt(testNow)
+		formatter, _ := NewFormatterWithOptions(
+			OutputFormatTable, FormatOptions{Columns: []ItemColumn{ItemColumnId, ItemColumnDeleted}},
+		)
+
+		result, err := formatter.FormatItems(
+			[]Item{trashed, NewItem(8, "item 8", StatusTodo, time.Time{}, testNow, testNow)}, false,
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ID    Deleted\n--    -------\n7     2026-10-14 10:30:00\n8     -\n", result)
+	})
+
+	t.Run("should write the status of each item with its icon", func(t *testing.T) {
+		formatter, _ := NewFormatterWithOptions(
+			OutputFormatTable, FormatOptions{Columns: []ItemColumn{ItemColumnId, ItemColumnStatus}},
+		)
+		var items []Item
+		for index, status := range Statuses {
+			items = append(items, NewItem(int64(index+1), "item", status, time.Time{}, testNow, testNow))
+		}
+
+		result, err := formatter.FormatItems(items, false)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "1     ⬜ todo\n")
+		assert.Contains(t, result, "2     🔄 in-progress\n")
+		assert.Contains(t, result, "3     ⛔ blocked\n")
+		assert.Contains(t, result, "4     ⏳ waiting\n")
+		assert.Contains(t, result, "5     ✅ done\n")
+		assert.Contains(t, result, "6     ❌ cancelled\n")
+	})
+
+	t.Run("should shorten the names to fit the width of the options", func(t *testing.T) {
+		items := []Item{
+			NewItem(1, "a name which is much too long for the terminal", StatusTodo, time.Time{}, testNow, testNow),
+			NewItem(2, "short", StatusTodo, time.Time{}, testNow, testNow),
+		}
+		formatter, _ := NewFormatterWithOptions(
+			OutputFormatTable, FormatOptions{Columns: []ItemColumn{ItemColumnId, ItemColumnName}, Width: 26},
+		)
+
+		result, err := formatter.FormatItems(items, false)
+
+		assert.NoError(t, err)
+		assert.Equal(
+			t, "ID    Name\n--    ----\n1     a name which is muc…\n2     short\n", result,
+		)
+	})
+
+	t.Run("should keep a minimum width of the names", func(t *testing.T) {
+		item := NewItem(1, "a name which is much too long for the terminal", StatusTodo, time.Time{}, testNow, testNow)
+		formatter, _ := NewFormatterWithOptions(
+			OutputFormatTable, FormatOptions{Columns: []ItemColumn{ItemColumnId, ItemColumnName}, Width: 5},
+		)
+
+		result, err := formatter.FormatItems([]Item{item}, false)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "1     a name whic…\n")
+	})
+
+	t.Run("should write dates relative to the current time", func(t *testing.T) {
+		item := NewItem(1, "item 1", StatusTodo, testNow.Add(50*time.Hour), testNow.Add(-3*time.Hour), testNow)
+		formatter, _ := NewFormatterWithOptions(OutputFormatTable, FormatOptions{
+			Columns:      []ItemColumn{ItemColumnDue, ItemColumnUpdated, ItemColumnCreated},
+			RelativeTime: true,
+			Clock:        clock.NewFixedClock(testNow),
+		})
+
+		result, err := formatter.FormatItems([]Item{item}, false)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "in 2d    3h ago          now\n")
+	})
+}
+
+func TestParseItemColumns(t *testing.T) {
+	t.Run("should parse the columns in their order", func(t *testing.T) {
+		columns, err := ParseItemColumns("ID, name,Due,tags")
+		assert.NoError(t, err)
+		assert.Equal(t, []ItemColumn{ItemColumnId, ItemColumnName, ItemColumnDue, ItemColumnTags}, columns)
+	})
+
+	t.Run("should return no columns for an empty list", func(t *testing.T) {
+		columns, err := ParseItemColumns("")
+		assert.NoError(t, err)
+		assert.Empty(t, columns)
+	})
+
+	t.Run("should reject unknown and repeated columns", func(t *testing.T) {
+		for _, value := range []string{"id,size", "id,name,id", "id,"} {
+			_, err := ParseItemColumns(value)
+			assert.ErrorIs(t, err, ErrValidation, value)
+		}
+	})
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	day := 24 * time.Hour
+	testCases := map[time.Duration]string{
+		-30 * time.Second: "now",
+		-5 * time.Minute:  "5m ago",
+		-3 * time.Hour:    "3h ago",
+		2*day + time.Hour: "in 2d",
+		-15 * day:         "2w ago",
+		90 * day:          "in 3mo",
+		-800 * day:        "2y ago",
+	}
+	for offset, expected := range testCases {
+		assert.Equal(t, expected, formatRelativeTime(testNow.Add(offset), testNow), offset.String())
+	}
+}
+
+func TestTableFormatter_FormatItem(t *testing.T) {
+	t.Run("should return every field and the notes", func(t *testing.T) {
+		item := NewItem(1, "Ship it", StatusTodo, testNow, testNow, testNow)
+		item.SetPriority(PriorityHigh)
+		item.SetTags([]string{"backend", "release"})
+		item.SetParentId(4)
+		item.SetRecurrence("FREQ=WEEKLY")
+		item.SetDescription("first line\nsecond line")
+
+		result, err := table.FormatItem(ItemDetails{Item: item, DependsOnIds: []int64{2, 3}, SubtaskIds: []int64{5}})
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Name:          Ship it\n")
+		assert.Contains(t, result, "Subtasks:      5\n")
+		assert.Contains(t, result, "Depends On:    2, 3\n")
+		assert.Contains(t, result, "Status:        todo\n")
+		assert.Contains(t, result, "Project:       -\n")
+		assert.Contains(t, result, "Parent:        4\n")
+		assert.Contains(t, result, "Tags:          backend, release\n")
+		assert.Contains(t, result, "Priority:      high\n")
+		assert.Contains(t, result, "Due:           "+testNow.Format(time.DateTime)+"\n")
+		assert.Contains(t, result, "Repeats:       every week\n")
+		assert.True(t, strings.HasSuffix(result, "Notes:\n  first line\n  second line\n"))
+	})
+
+	t.Run("should return the reason an item is blocked", func(t *testing.T) {
+		item := NewItem(1, "Ship it", StatusBlocked, time.Time{}, testNow, testNow)
+		item.SetStatusReason("waiting on QA")
+
+		result, err := table.FormatItem(ItemDetails{Item: item})
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "Status:        blocked (waiting on QA)\n")
+	})
+
+	t.Run("should return error when item is nil", func(t *testing.T) {
+		result, err := table.FormatItem(ItemDetails{})
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestJsonFormatter_FormatItem(t *testing.T) {
+	t.Run("should return every field as JSON", func(t *testing.T) {
+		item := NewItem(1, "Ship it", StatusTodo, testNow, testNow, testNow)
+		item.SetPriority(PriorityHigh)
+		item.SetTags([]string{"backend"})
+		item.SetRecurrence("FREQ=WEEKLY")
+		item.SetDescription("notes")
+
+		result, err := jsonFormat.FormatItem(ItemDetails{Item: item, DependsOnIds: []int64{2}})
+		assert.NoError(t, err)
+
+		var document map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(result), &document))
+		assert.Equal(t, float64(1), document["id"])
+		assert.Equal(t, "Ship it", document["name"])
+		assert.Equal(t, "todo", document["status"])
+		assert.Equal(t, false, document["isCompleted"])
+		assert.Nil(t, document["statusReason"])
+		assert.Equal(t, "high", document["priority"])
+		assert.Equal(t, []any{"backend"}, document["tags"])
+		assert.Equal(t, testNow.Format(time.RFC3339), document["dueAt"])
+		assert.Equal(t, "FREQ=WEEKLY", document["recurrence"])
+		assert.Equal(t, "notes", document["description"])
+		assert.Equal(t, []any{float64(2)}, document["dependsOn"])
+		assert.Equal(t, []any{}, document["subtasks"])
+		assert.Nil(t, document["project"])
+		assert.Nil(t, document["parentId"])
+	})
+
+	t.Run("should return the stored status of an item blocked by its dependencies", func(t *testing.T) {
+		blockedItem := NewItem(1, "Ship it", StatusTodo, testNow, testNow, testNow)
+		blockedItem.(*item).isBlocked = true
+
+		result, err := jsonFormat.FormatItem(ItemDetails{Item: blockedItem, DependsOnIds: []int64{2}})
+		assert.NoError(t, err)
+
+		var document map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(result), &document))
+		assert.Equal(t, "todo", document["status"])
+		assert.Equal(t, true, document["isBlocked"])
+	})
+
+	t.Run("should return error when item is nil", func(t *testing.T) {
+		result, err := jsonFormat.FormatItem(ItemDetails{})
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestJsonFormatter_FormatItems(t *testing.T) {
+	t.Run("should return an array of items", func(t *testing.T) {
+		item := NewItem(1, "item 1", StatusDone, time.Time{}, testNow, testNow)
+		item.SetProjectId(3)
+
+		result, err := jsonFormat.FormatItems([]Item{item}, false)
+		assert.NoError(t, err)
+
+		var documents []map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(result), &documents))
+		assert.Len(t, documents, 1)
+		assert.Equal(t, "done", documents[0]["status"])
+		assert.Equal(t, true, documents[0]["isCompleted"])
+		assert.Equal(t, float64(3), documents[0]["projectId"])
+		assert.Nil(t, documents[0]["dueAt"])
+		assert.NotContains(t, documents[0], "dependsOn")
+	})
+
+	t.Run("should return an empty array when there are no items", func(t *testing.T) {
+		result, err := jsonFormat.FormatItems(nil, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "[]\n", result)
+	})
+}
+
+func TestYamlFormatter(t *testing.T) {
+	formatter := &yamlFormatter{}
+	item := NewItem(1, "item 1", StatusTodo, testNow, testNow, testNow)
+	item.SetTags([]string{"backend"})
+
+	t.Run("should return a sequence of items", func(t *testing.T) {
+		result, err := formatter.FormatItems([]Item{item}, false)
+		assert.NoError(t, err)
+
+		var documents []map[string]any
+		assert.NoError(t, yaml.Unmarshal([]byte(result), &documents))
+		assert.Len(t, documents, 1)
+		assert.Equal(t, "item 1", documents[0]["name"])
+		assert.Equal(t, []any{"backend"}, documents[0]["tags"])
+		assert.Nil(t, documents[0]["recurrence"])
+	})
+
+	t.Run("should return the item with its related items", func(t *testing.T) {
+		result, err := formatter.FormatItem(ItemDetails{Item: item, SubtaskIds: []int64{2}})
+		assert.NoError(t, err)
+
+		var document map[string]any
+		assert.NoError(t, yaml.Unmarshal([]byte(result), &document))
+		assert.Equal(t, 1, document["id"])
+		assert.Equal(t, []any{2}, document["subtasks"])
+		assert.Equal(t, []any{}, document["dependsOn"])
+	})
+}
+
+func TestDelimitedFormatters(t *testing.T) {
+	item := NewItem(1, "say \"hi\", then leave", StatusTodo, time.Time{}, testNow, testNow)
+	item.SetTags([]string{"a", "b"})
+	item.SetDescription("line 1\n\tline 2")
+
+	t.Run("should return csv records with a header", func(t *testing.T) {
+		formatter, _ := NewFormatter(OutputFormatCsv)
+		result, err := formatter.FormatItems([]Item{item}, false)
+		assert.NoError(t, err)
+
+		records, err := csv.NewReader(strings.NewReader(result)).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, itemRecordHeader, records[0])
+		assert.Equal(t, "say \"hi\", then leave", records[1][1])
+		assert.Equal(t, "a,b", records[1][8])
+		assert.Equal(t, "", records[1][10])
+		assert.Equal(t, "line 1\n\tline 2", records[1][12])
+	})
+
+	t.Run("should return tsv records on a single line each", func(t *testing.T) {
+		formatter, _ := NewFormatter(OutputFormatTsv)
+		result, err := formatter.FormatItems([]Item{item}, false)
+		assert.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSuffix(result, "\n"), "\n")
+		assert.Len(t, lines, 2)
+		assert.Equal(t, strings.Join(itemRecordHeader, "\t"), lines[0])
+		fields := strings.Split(lines[1], "\t")
+		assert.Len(t, fields, len(itemRecordHeader))
+		assert.Equal(t, `line 1\n\tline 2`, fields[12])
+	})
+
+	t.Run("should return the related items of a single item", func(t *testing.T) {
+		formatter, _ := NewFormatter(OutputFormatCsv)
+		result, err := formatter.FormatItem(ItemDetails{Item: item, DependsOnIds: []int64{2, 3}})
+		assert.NoError(t, err)
+
+		records, err := csv.NewReader(strings.NewReader(result)).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"dependsOn", "subtasks"}, records[0][len(itemRecordHeader):])
+		assert.Equal(t, []string{"2,3", ""}, records[1][len(itemRecordHeader):])
+	})
+
+	t.Run("should return only the header when there are no items", func(t *testing.T) {
+		formatter, _ := NewFormatter(OutputFormatTsv)
+		result, err := formatter.FormatItems(nil, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Join(itemRecordHeader, "\t")+"\n", result)
+	})
+}
+
+func TestFormatter_FormatSearchResults(t *testing.T) {
+	item := NewItem(1, "Deploy to staging", StatusTodo, time.Time{}, testNow, testNow)
+	item.SetDescription("Check the logs")
+	results := []SearchResult{{
+		Item:          item,
+		Rank:          1,
+		NameHighlight: "Deploy to " + MatchStart + "staging" + MatchEnd,
+		NotesSnippet:  "Check the " + MatchStart + "logs" + MatchEnd,
+	}}
+
+	t.Run("should highlight the matches between brackets", func(t *testing.T) {
+		result, err := table.FormatSearchResults(results)
+
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			"ID    Name                   Project    Status    Due    Notes\n"+
+				"--    ----                   -------    ------    ---    -----\n"+
+				"1     Deploy to [staging]    -          todo      -      Check the [logs]\n",
+			result,
+		)
+	})
+
+	t.Run("should highlight the matches in bold without breaking the alignment", func(t *testing.T) {
+		formatter, _ := NewFormatterWithOptions(OutputFormatTable, FormatOptions{Color: true})
+
+		result, err := formatter.FormatSearchResults(results)
+
+		assert.NoError(t, err)
+		lines := strings.Split(result, "\n")
+		assert.Equal(t, "1     Deploy to "+colorBold+"staging"+colorReset+"    -          todo      -      Check the "+
+			colorBold+"logs"+colorReset, lines[2])
+	})
+
+	t.Run("should return 'No matching todo items...' when results is empty", func(t *testing.T) {
+		result, err := table.FormatSearchResults(nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "No matching todo items...\n", result)
+	})
+
+	t.Run("should print the items in the other formats", func(t *testing.T) {
+		formatter, _ := NewFormatter(OutputFormatJson)
+
+		result, err := formatter.FormatSearchResults(results)
+
+		assert.NoError(t, err)
+		var documents []map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(result), &documents))
+		assert.Equal(t, "Deploy to staging", documents[0]["name"])
+	})
+}
+
+func TestFormatJournalTable(t *testing.T) {
+	entries := []JournalEntry{
+		{Id: 2, Summary: "remove item 1", Changes: make([]JournalChange, 2), IsUndone: true, CreatedAt: testNow},
+		{Id: 1, Summary: "create item 1", Changes: make([]JournalChange, 1), CreatedAt: testNow.Add(-time.Hour)},
+	}
+
+	t.Run("should print the entries", func(t *testing.T) {
+		result, err := FormatJournalTable(entries, FormatOptions{DateFormat: time.DateOnly})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ID    When          Operation        Items    Undone\n"+
+			"--    ----          ---------        -----    ------\n"+
+			"2     2026-10-14    remove item 1    2        yes\n"+
+			"1     2026-10-14    create item 1    1        -\n", result)
+	})
+
+	t.Run("should print relative times", func(t *testing.T) {
+		result, err := FormatJournalTable(
+			entries, FormatOptions{RelativeTime: true, Clock: clock.NewFixedClock(testNow)},
+		)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "1     1h ago    create item 1")
+	})
+
+	t.Run("should return 'No history...' when entries is empty", func(t *testing.T) {
+		result, err := FormatJournalTable(nil, FormatOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "No history...\n", result)
+	})
+}
+
+func TestFormatItemEventLog(t *testing.T) {
+	dueAt := time.Date(2026, time.October, 20, 17, 0, 0, 0, time.Local).Format(time.RFC3339)
+	events := []ItemEvent{
+		{ItemId: 3, ItemName: "Ship it", Field: ItemEventFieldName, NewValue: "Ship it", Origin: "create",
+			CreatedAt: testNow.Add(-time.Hour)},
+		{ItemId: 3, ItemName: "Ship it", Field: ItemEventFieldTags, NewValue: "release,qa", Origin: "create",
+			CreatedAt: testNow.Add(-time.Hour)},
+		{ItemId: 3, ItemName: "Ship it", Field: ItemEventFieldDueAt, OldValue: dueAt, Origin: "update",
+			CreatedAt: testNow},
+		{ItemId: 3, ItemName: "Ship it", Field: ItemEventFieldDescription, NewValue: "first\nsecond",
+			CreatedAt: testNow},
+	}
+
+	t.Run("should print the changes of an item", func(t *testing.T) {
+		result := FormatItemEventLog(events, false, FormatOptions{DateFormat: time.DateTime})
+
+		assert.Equal(t, "2026-10-14 09:30:00  create\n"+
+			"  + name: Ship it\n"+
+			"  + tags: release, qa\n"+
+			"2026-10-14 10:30:00  update\n"+
+			"  - dueAt: 2026-10-20 17:00:00\n"+
+			"2026-10-14 10:30:00  -\n"+
+			"  + description: first …\n", result)
+	})
+
+	t.Run("should print the items and relative times", func(t *testing.T) {
+		result := FormatItemEventLog(
+			events[:2], true, FormatOptions{RelativeTime: true, Clock: clock.NewFixedClock(testNow)},
+		)
+
+		assert.Equal(t, "1h ago  create  item 3: Ship it\n  + name: Ship it\n  + tags: release, qa\n", result)
+	})
+
+	t.Run("should return 'No changes...' when events is empty", func(t *testing.T) {
+		assert.Equal(t, "No changes...\n", FormatItemEventLog(nil, true, FormatOptions{}))
+	})
+}
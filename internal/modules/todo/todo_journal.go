@@ -0,0 +1,497 @@
+package todo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// journalSize godoc
+//
+// Number of operations kept in the journal. Older operations can no longer be undone.
+const journalSize = 200
+
+// ItemSnapshot godoc
+//
+// Defines the state of an item, along with its tags and dependencies, at a point of the journal.
+type ItemSnapshot struct {
+	Id           int64  `json:"id"`
+	Name         string `json:"name"`
+	Status       Status `json:"status,omitempty"`
+	StatusReason string `json:"statusReason,omitempty"`
+	// IsCompleted is only set by the entries recorded before items had a status, see status.
+	IsCompleted int8 `json:"isCompleted,omitempty"`
+	// DueAt is a Unix timestamp, 0 when the item has no due date.
+	DueAt       int64    `json:"dueAt,omitempty"`
+	Priority    Priority `json:"priority"`
+	ProjectId   int64    `json:"projectId,omitempty"`
+	ParentId    int64    `json:"parentId,omitempty"`
+	Recurrence  string   `json:"recurrence,omitempty"`
+	Description string   `json:"description,omitempty"`
+	UpdatedAt   int64    `json:"updatedAt"`
+	CreatedAt   int64    `json:"createdAt"`
+	// DeletedAt is a Unix timestamp, 0 when the item is not in the trash.
+	DeletedAt int64    `json:"deletedAt,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	// DependsOn holds the IDs of the items which the item depends on.
+	DependsOn []int64 `json:"dependsOn,omitempty"`
+	// Dependents holds the IDs of the items which depend on the item.
+	Dependents []int64 `json:"dependents,omitempty"`
+}
+
+// newItemSnapshot godoc
+//
+// Creates the snapshot of an item from the item and the dependencies between items.
+func newItemSnapshot(item Item, dependencies []Dependency) *ItemSnapshot {
+	snapshot := &ItemSnapshot{
+		Id:           item.GetId(),
+		Name:         item.GetName(),
+		Status:       item.GetStatus(),
+		StatusReason: item.GetStatusReason(),
+		Priority:     item.GetPriority(),
+		ProjectId:    item.GetProjectId(),
+		ParentId:     item.GetParentId(),
+		Recurrence:   item.GetRecurrence(),
+		Description:  item.GetDescription(),
+		UpdatedAt:    item.GetUpdatedAt().Unix(),
+		CreatedAt:    item.GetCreatedAt().Unix(),
+	}
+	if !item.GetDueAt().IsZero() {
+		snapshot.DueAt = item.GetDueAt().Unix()
+	}
+	if !item.GetDeletedAt().IsZero() {
+		snapshot.DeletedAt = item.GetDeletedAt().Unix()
+	}
+	if len(item.GetTags()) > 0 {
+		snapshot.Tags = slices.Clone(item.GetTags())
+	}
+	for _, dependency := range dependencies {
+		switch snapshot.Id {
+		case dependency.ItemId:
+			snapshot.DependsOn = append(snapshot.DependsOn, dependency.DependsOnId)
+		case dependency.DependsOnId:
+			snapshot.Dependents = append(snapshot.Dependents, dependency.ItemId)
+		}
+	}
+	return snapshot
+}
+
+// status godoc
+//
+// Returns the status of the item, derived from IsCompleted for the entries recorded before items had a status.
+func (s ItemSnapshot) status() Status {
+	switch {
+	case s.Status != "":
+		return s.Status
+	case s.IsCompleted == 1:
+		return StatusDone
+	}
+	return StatusTodo
+}
+
+// JournalChange godoc
+//
+// Defines the change of an item made by an operation.
+type JournalChange struct {
+	ItemId int64 `json:"itemId"`
+	// Before is the item before the operation, nil when the operation created it.
+	Before *ItemSnapshot `json:"before"`
+	// After is the item after the operation, nil when the operation removed it.
+	After *ItemSnapshot `json:"after"`
+}
+
+// JournalEntry godoc
+//
+// Defines an operation recorded in the journal, which can be undone and redone.
+type JournalEntry struct {
+	Id int64
+	// Summary describes the operation, e.g. "complete item 3".
+	Summary string
+	// Changes holds the change of each item, in the order the items were first changed.
+	Changes []JournalChange
+	// IsUndone is true when the operation was undone and can be redone.
+	IsUndone  bool
+	CreatedAt time.Time
+	// UnlinkedItemIds holds the IDs of the items which could not be put back in their project, as it was deleted.
+	// It is only set on the entries returned by Undo and Redo.
+	UnlinkedItemIds []int64
+}
+
+// NewJournalEntryFromRow godoc
+//
+// Create a new JournalEntry by scanning a sql.Rows struct selecting journalColumns.
+//
+// Returns nil and error on error.
+//
+// Return a new JournalEntry and nil on success.
+func NewJournalEntryFromRow(rows *sql.Rows) (*JournalEntry, error) {
+	var entry JournalEntry
+	var changes string
+	var createdAtTimestamp int64
+
+	if err := rows.Scan(&entry.Id, &entry.Summary, &changes, &entry.IsUndone, &createdAtTimestamp); err != nil {
+		return nil, fmt.Errorf("NewJournalEntryFromRow: %v", err)
+	}
+	if err := json.Unmarshal([]byte(changes), &entry.Changes); err != nil {
+		return nil, fmt.Errorf("NewJournalEntryFromRow: Failed to read changes of entry %d: %v", entry.Id, err)
+	}
+	entry.CreatedAt = time.Unix(createdAtTimestamp, 0)
+	return &entry, nil
+}
+
+// journalRecorder godoc
+//
+// Collects the state of the items changed by an operation before it changes them.
+type journalRecorder struct {
+	// ids holds the IDs of the changed items in the order they were first captured.
+	ids []int64
+	// before holds the snapshot of each captured item, nil for the items created by the operation.
+	before map[int64]*ItemSnapshot
+}
+
+// newJournalRecorder godoc
+//
+// Creates a journalRecorder which has not captured any item.
+func newJournalRecorder() *journalRecorder {
+	return &journalRecorder{before: map[int64]*ItemSnapshot{}}
+}
+
+// isCaptured godoc
+//
+// Returns true when the item was already captured, so that its first state is kept.
+func (r *journalRecorder) isCaptured(itemId int64) bool {
+	_, captured := r.before[itemId]
+	return captured
+}
+
+// record godoc
+//
+// Records the state of an item before the operation, nil when the operation creates it.
+func (r *journalRecorder) record(itemId int64, snapshot *ItemSnapshot) {
+	if r.isCaptured(itemId) {
+		return
+	}
+	r.ids = append(r.ids, itemId)
+	r.before[itemId] = snapshot
+}
+
+// journaled godoc
+//
+// Runs operation in a single transaction, with a use case whose repository runs in the transaction, and records the
+// changes of the items captured by the operation in the journal. The operation returns the summary of the journal
+// entry. When the use case already records an operation, e.g. for a bulk operation, operation is part of it.
+//
+// Returns the error returned by operation, in which case nothing is saved, or error on error.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) journaled(ctx context.Context, operation func(*defaultUseCase) (string, error)) error {
+	if uc.recorder != nil {
+		_, err := operation(uc)
+		return err
+	}
+
+	return uc.repository.WithTransaction(ctx, func(repository Repository) error {
+		txUseCase := uc.inTransaction(repository)
+		summary, err := operation(txUseCase)
+		if err != nil {
+			return err
+		}
+		if err := txUseCase.saveJournalEntry(ctx, summary); err != nil {
+			return fmt.Errorf("journaled: %v", err)
+		}
+		return nil
+	})
+}
+
+// inTransaction godoc
+//
+// Returns a copy of the use case which uses the repository of a transaction and records the items it changes.
+func (uc *defaultUseCase) inTransaction(repository Repository) *defaultUseCase {
+	return &defaultUseCase{
+		domain:           uc.domain,
+		repository:       repository,
+		completionPolicy: uc.completionPolicy,
+		recorder:         newJournalRecorder(),
+	}
+}
+
+// capture godoc
+//
+// Records the state of items before the operation changes them. Missing items are recorded as created, so a
+// missing ID must only be captured when the operation creates it or fails.
+//
+// Returns error on error.
+func (uc *defaultUseCase) capture(ctx context.Context, itemIds ...int64) error {
+	for _, itemId := range itemIds {
+		if uc.recorder == nil || uc.recorder.isCaptured(itemId) {
+			continue
+		}
+		snapshot, err := uc.snapshotItem(ctx, itemId)
+		if err != nil {
+			return fmt.Errorf("capture: %v", err)
+		}
+		uc.recorder.record(itemId, snapshot)
+	}
+	return nil
+}
+
+// captureTree godoc
+//
+// Records the state of an item and of its subtasks, at any depth, before the operation changes them.
+//
+// Returns error on error.
+func (uc *defaultUseCase) captureTree(ctx context.Context, itemId int64) error {
+	if uc.recorder == nil || itemId <= 0 {
+		return nil
+	}
+	if err := uc.capture(ctx, itemId); err != nil {
+		return fmt.Errorf("captureTree: %v", err)
+	}
+	descendants, err := uc.repository.FindDescendantItems(ctx, itemId)
+	if err != nil {
+		return fmt.Errorf("captureTree: Failed to find subtasks of item with ID %d: %v", itemId, err)
+	}
+	for _, descendant := range descendants {
+		if err := uc.capture(ctx, descendant.GetId()); err != nil {
+			return fmt.Errorf("captureTree: %v", err)
+		}
+	}
+	return nil
+}
+
+// captureCreated godoc
+//
+// Records that the operation created an item.
+func (uc *defaultUseCase) captureCreated(itemId int64) {
+	if uc.recorder != nil {
+		uc.recorder.record(itemId, nil)
+	}
+}
+
+// snapshotItem godoc
+//
+// Returns the snapshot of an item, in the trash or not, nil when it does not exist, and nil.
+//
+// Returns nil and error on error.
+func (uc *defaultUseCase) snapshotItem(ctx context.Context, itemId int64) (*ItemSnapshot, error) {
+	if itemId <= 0 {
+		return nil, nil
+	}
+	item, err := uc.repository.FindItemById(ctx, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotItem: Failed to find item with ID %d: %v", itemId, err)
+	}
+	if item == nil {
+		item, err = uc.repository.FindTrashedItemById(ctx, itemId)
+		if err != nil {
+			return nil, fmt.Errorf("snapshotItem: Failed to find item with ID %d in the trash: %v", itemId, err)
+		}
+	}
+	if item == nil {
+		return nil, nil
+	}
+	dependencies, err := uc.repository.FindItemDependencies(ctx, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotItem: %v", err)
+	}
+	return newItemSnapshot(item, dependencies), nil
+}
+
+// saveJournalEntry godoc
+//
+// Saves the changes of the items captured by the use case as a journal entry. Nothing is saved when no item
+// changed. The operations which were undone can no longer be redone once the entry is saved.
+//
+// Returns error on error.
+func (uc *defaultUseCase) saveJournalEntry(ctx context.Context, summary string) error {
+	var changes []JournalChange
+	for _, itemId := range uc.recorder.ids {
+		after, err := uc.snapshotItem(ctx, itemId)
+		if err != nil {
+			return fmt.Errorf("saveJournalEntry: %v", err)
+		}
+		before := uc.recorder.before[itemId]
+		if reflect.DeepEqual(before, after) {
+			continue
+		}
+		changes = append(changes, JournalChange{ItemId: itemId, Before: before, After: after})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if _, err := uc.repository.DeleteUndoneJournalEntries(ctx); err != nil {
+		return fmt.Errorf("saveJournalEntry: %v", err)
+	}
+	entry := uc.domain.CreateJournalEntry(summary, changes)
+	if _, err := uc.repository.PersistJournalEntry(ctx, entry); err != nil {
+		return fmt.Errorf("saveJournalEntry: %v", err)
+	}
+	if err := uc.saveItemEvents(ctx, changes); err != nil {
+		return fmt.Errorf("saveJournalEntry: %v", err)
+	}
+	if _, err := uc.repository.TrimJournal(ctx, journalSize); err != nil {
+		return fmt.Errorf("saveJournalEntry: %v", err)
+	}
+	return nil
+}
+
+// Undo godoc
+//
+// Reverts the latest operation of the journal which is not undone, restoring the items it changed with their
+// original IDs and timestamps.
+//
+// Returns nil and error wrapping ErrNothingToUndo when every operation of the journal is undone.
+//
+// Returns nil and error on error.
+//
+// Returns the undone entry and nil on success.
+func (uc *defaultUseCase) Undo(ctx context.Context) (*JournalEntry, error) {
+	entry, err := uc.replayJournalEntry(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Undo: %w", err)
+	}
+	return entry, nil
+}
+
+// Redo godoc
+//
+// Applies again the latest undone operation of the journal.
+//
+// Returns nil and error wrapping ErrNothingToRedo when no operation is undone, or when an operation was recorded
+// since the last undo.
+//
+// Returns nil and error on error.
+//
+// Returns the redone entry and nil on success.
+func (uc *defaultUseCase) Redo(ctx context.Context) (*JournalEntry, error) {
+	entry, err := uc.replayJournalEntry(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Redo: %w", err)
+	}
+	return entry, nil
+}
+
+// replayJournalEntry godoc
+//
+// Undoes the latest operation which is not undone, or redoes the latest undone operation when redo is true, in a
+// single transaction.
+//
+// Returns nil and error wrapping ErrNothingToUndo or ErrNothingToRedo when there is no such operation.
+//
+// Returns nil and error on error.
+//
+// Returns the replayed entry and nil on success.
+func (uc *defaultUseCase) replayJournalEntry(ctx context.Context, redo bool) (*JournalEntry, error) {
+	var entry *JournalEntry
+	err := uc.repository.WithTransaction(ctx, func(repository Repository) error {
+		var err error
+		entry, err = repository.FindLastJournalEntry(ctx, redo)
+		if err != nil {
+			return err
+		}
+		if entry == nil && redo {
+			return ErrNothingToRedo
+		}
+		if entry == nil {
+			return ErrNothingToUndo
+		}
+
+		var removedIds []int64
+		var restored []ItemSnapshot
+		var replayedChanges []JournalChange
+		for _, change := range entry.Changes {
+			state := change.Before
+			if redo {
+				state = change.After
+			} else {
+				change.Before, change.After = change.After, change.Before
+			}
+			replayedChanges = append(replayedChanges, change)
+			if state == nil {
+				removedIds = append(removedIds, change.ItemId)
+				continue
+			}
+			restored = append(restored, *state)
+		}
+		// Subtasks are removed before their parent
+		slices.Reverse(removedIds)
+		for _, itemId := range removedIds {
+			if _, err := repository.DeleteItemById(ctx, itemId); err != nil {
+				return fmt.Errorf("Failed to delete item with ID %d: %v", itemId, err)
+			}
+		}
+		if err := repository.RestoreItems(ctx, restored); err != nil {
+			return err
+		}
+		txUseCase := uc.inTransaction(repository)
+		// Projects are not journaled, so the items of a deleted project are restored without one
+		for i, change := range replayedChanges {
+			if change.After == nil || change.After.ProjectId == 0 {
+				continue
+			}
+			snapshot, err := txUseCase.snapshotItem(ctx, change.ItemId)
+			if err != nil {
+				return err
+			}
+			if snapshot != nil && snapshot.ProjectId != change.After.ProjectId {
+				entry.UnlinkedItemIds = append(entry.UnlinkedItemIds, change.ItemId)
+				state := *change.After
+				state.ProjectId = snapshot.ProjectId
+				replayedChanges[i].After = &state
+			}
+		}
+		if err := txUseCase.saveItemEvents(ctx, replayedChanges); err != nil {
+			return err
+		}
+
+		entry.IsUndone = !redo
+		if _, err := repository.SetJournalEntryUndone(ctx, entry.Id, entry.IsUndone); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("replayJournalEntry: %w", err)
+	}
+	return entry, nil
+}
+
+// History godoc
+//
+// Get the latest operations of the journal, newest first. A limit of 0 returns every operation.
+//
+// Returns nil and error on error.
+//
+// Returns the journal entries and nil on success.
+func (uc *defaultUseCase) History(ctx context.Context, limit int) ([]JournalEntry, error) {
+	entries, err := uc.repository.FindJournalEntries(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.History: %v", err)
+	}
+	return entries, nil
+}
+
+// summarizeItems godoc
+//
+// Returns the summary of an operation on items, e.g. "complete item 3" or "remove items 1, 2, 5".
+func summarizeItems(verb string, itemIds []int64) string {
+	if len(itemIds) == 1 {
+		return fmt.Sprintf("%s item %d", verb, itemIds[0])
+	}
+	const listedIds = 5
+	ids := make([]string, 0, listedIds+1)
+	for i, itemId := range itemIds {
+		if i == listedIds {
+			ids = append(ids, fmt.Sprintf("… (%d items)", len(itemIds)))
+			break
+		}
+		ids = append(ids, fmt.Sprint(itemId))
+	}
+	return fmt.Sprintf("%s items %s", verb, strings.Join(ids, ", "))
+}
@@ -0,0 +1,176 @@
+package todo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDefaultUseCase_Undo(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	parent, err := useCase.Create(ctx, ItemDraft{Name: "parent", Tags: []string{"home"}})
+	if err != nil {
+		t.Fatalf("TestDefaultUseCase_Undo: %v", err)
+	}
+	subtask, err := useCase.Create(ctx, ItemDraft{Name: "subtask", ParentId: parent.GetId()})
+	if err != nil {
+		t.Fatalf("TestDefaultUseCase_Undo: %v", err)
+	}
+	other, err := useCase.Create(ctx, ItemDraft{Name: "other"})
+	if err != nil {
+		t.Fatalf("TestDefaultUseCase_Undo: %v", err)
+	}
+	if err := useCase.Depend(ctx, other.GetId(), subtask.GetId()); err != nil {
+		t.Fatalf("TestDefaultUseCase_Undo: %v", err)
+	}
+
+	t.Run("should restore removed items with their IDs, timestamps, tags and dependencies", func(t *testing.T) {
+		assert.NoError(t, useCase.Remove(ctx, parent.GetId()))
+
+		entry, err := useCase.Undo(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "remove item 1", entry.Summary)
+		assert.True(t, entry.IsUndone)
+		details, err := useCase.Get(ctx, subtask.GetId())
+		assert.NoError(t, err)
+		assert.Equal(t, parent.GetId(), details.Item.GetParentId())
+		assert.Equal(t, subtask.GetCreatedAt(), details.Item.GetCreatedAt())
+		restoredParent, err := useCase.Get(ctx, parent.GetId())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"home"}, restoredParent.Item.GetTags())
+		otherDetails, err := useCase.Get(ctx, other.GetId())
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{subtask.GetId()}, otherDetails.DependsOnIds)
+	})
+
+	t.Run("should redo the undone operation", func(t *testing.T) {
+		entry, err := useCase.Redo(ctx)
+
+		assert.NoError(t, err)
+		assert.False(t, entry.IsUndone)
+		items, err := useCase.List(ctx, ListOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+
+		_, err = useCase.Undo(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should undo the changes of an update", func(t *testing.T) {
+		name := "renamed"
+		assert.NoError(t, useCase.Update(ctx, other.GetId(), ItemChanges{Name: &name}))
+
+		_, err := useCase.Undo(ctx)
+
+		assert.NoError(t, err)
+		details, err := useCase.Get(ctx, other.GetId())
+		assert.NoError(t, err)
+		assert.Equal(t, "other", details.Item.GetName())
+		assert.Equal(t, other.GetUpdatedAt(), details.Item.GetUpdatedAt())
+	})
+
+	t.Run("should remove the next occurrence when undoing the completion of a recurring item", func(t *testing.T) {
+		recurring, err := useCase.Create(ctx, ItemDraft{
+			Name: "water plants", Recurrence: "daily", DueAt: time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+		completion, err := useCase.Complete(ctx, recurring.GetId())
+		assert.NoError(t, err)
+
+		_, err = useCase.Undo(ctx)
+
+		assert.NoError(t, err)
+		_, err = useCase.Get(ctx, completion.NextOccurrence.GetId())
+		assert.ErrorIs(t, err, ErrNotFound)
+		details, err := useCase.Get(ctx, recurring.GetId())
+		assert.NoError(t, err)
+		assert.Equal(t, StatusTodo, details.Item.GetStatus())
+	})
+
+	t.Run("should not be able to redo once another operation is recorded", func(t *testing.T) {
+		assert.NoError(t, useCase.Tag(ctx, other.GetId(), "work"))
+
+		_, err := useCase.Redo(ctx)
+
+		assert.ErrorIs(t, err, ErrNothingToRedo)
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("should not record failed operations", func(t *testing.T) {
+		before, err := useCase.History(ctx, 0)
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, useCase.Remove(ctx, 100), ErrNotFound)
+
+		after, err := useCase.History(ctx, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, len(before), len(after))
+	})
+
+	t.Run("should return error when every operation is undone", func(t *testing.T) {
+		for {
+			if _, err := useCase.Undo(ctx); err != nil {
+				assert.ErrorIs(t, err, ErrNothingToUndo)
+				break
+			}
+		}
+
+		items, err := useCase.List(ctx, ListOptions{})
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+}
+
+func TestDefaultUseCase_History(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	for _, name := range []string{"first", "second", "third"} {
+		if _, err := useCase.Create(ctx, ItemDraft{Name: name}); err != nil {
+			t.Fatalf("TestDefaultUseCase_History: %v", err)
+		}
+	}
+
+	t.Run("should record a bulk operation as a single entry", func(t *testing.T) {
+		_, err := useCase.CompleteItems(ctx, BulkOptions{Ids: []int64{1, 2, 3}})
+		assert.NoError(t, err)
+
+		entries, err := useCase.History(ctx, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "complete items 1, 2, 3", entries[0].Summary)
+		assert.Len(t, entries[0].Changes, 3)
+		assert.Equal(t, "create item 3", entries[1].Summary)
+	})
+
+	t.Run("should not record dry runs", func(t *testing.T) {
+		_, err := useCase.RemoveItems(ctx, BulkOptions{Ids: []int64{1}, DryRun: true})
+		assert.NoError(t, err)
+
+		entries, err := useCase.History(ctx, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, entries, 4)
+	})
+
+	t.Run("should undo a bulk operation at once", func(t *testing.T) {
+		_, err := useCase.Undo(ctx)
+		assert.NoError(t, err)
+
+		items, err := useCase.List(ctx, ListOptions{Filters: []string{"status:done"}})
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+}
+
+func TestSummarizeItems(t *testing.T) {
+	assert.Equal(t, "remove item 3", summarizeItems("remove", []int64{3}))
+	assert.Equal(t, "complete items 1, 2", summarizeItems("complete", []int64{1, 2}))
+	assert.Equal(
+		t, "update items 1, 2, 3, 4, 5, … (7 items)", summarizeItems("update", []int64{1, 2, 3, 4, 5, 6, 7}),
+	)
+}
@@ -3,9 +3,113 @@ package todo
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// Priority godoc
+//
+// Defines how important an item is. Higher values are more important.
+type Priority int8
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+// priorityNames godoc
+//
+// Names of the priorities, indexed by Priority.
+var priorityNames = []string{"none", "low", "medium", "high", "urgent"}
+
+// ParsePriority godoc
+//
+// Converts a priority name (e.g. "high") or its numeric value (e.g. "3") into a Priority.
+//
+// Returns PriorityNone and error when the value is not a known priority.
+//
+// Returns the matching Priority and nil on success.
+func ParsePriority(value string) (Priority, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	for index, name := range priorityNames {
+		if normalized == name || normalized == strconv.Itoa(index) {
+			return Priority(index), nil
+		}
+	}
+	return PriorityNone, fmt.Errorf(
+		"ParsePriority: '%s' is not one of %s", value, strings.Join(priorityNames, ", "),
+	)
+}
+
+// String godoc
+//
+// Returns the name of the priority.
+func (p Priority) String() string {
+	if p < PriorityNone || int(p) >= len(priorityNames) {
+		return fmt.Sprintf("Priority(%d)", p)
+	}
+	return priorityNames[p]
+}
+
+// Status godoc
+//
+// Defines where an item stands in its workflow. Items which are done or cancelled are closed, every other item is
+// open.
+type Status string
+
+const (
+	StatusTodo       Status = "todo"
+	StatusInProgress Status = "in-progress"
+	StatusBlocked    Status = "blocked"
+	StatusWaiting    Status = "waiting"
+	StatusDone       Status = "done"
+	StatusCancelled  Status = "cancelled"
+)
+
+// Statuses godoc
+//
+// Lists the statuses in the order of the workflow.
+var Statuses = []Status{
+	StatusTodo, StatusInProgress, StatusBlocked, StatusWaiting, StatusDone, StatusCancelled,
+}
+
+// ParseStatus godoc
+//
+// Converts a status name (e.g. "in-progress") into a Status.
+//
+// Returns StatusTodo and error when the value is not a known status.
+//
+// Returns the matching Status and nil on success.
+func ParseStatus(value string) (Status, error) {
+	normalized := Status(strings.ToLower(strings.TrimSpace(value)))
+	var names []string
+	for _, status := range Statuses {
+		if normalized == status {
+			return status, nil
+		}
+		names = append(names, string(status))
+	}
+	return StatusTodo, fmt.Errorf("ParseStatus: '%s' is not one of %s", value, strings.Join(names, ", "))
+}
+
+// String godoc
+//
+// Returns the name of the status.
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsClosed godoc
+//
+// Returns true when the status is StatusDone or StatusCancelled.
+func (s Status) IsClosed() bool {
+	return s == StatusDone || s == StatusCancelled
+}
+
 // Item godoc
 //
 // Defines an interface for an item with getters and setters for encapsulation purposes.
@@ -13,11 +117,31 @@ type Item interface {
 	GetId() int64
 	GetName() string
 	SetName(string)
-	GetIsCompleted() int8
-	SetIsCompleted(int8)
+	GetStatus() Status
+	SetStatus(Status)
+	GetStatusReason() string
+	SetStatusReason(string)
+	GetDueAt() time.Time
+	SetDueAt(time.Time)
+	GetPriority() Priority
+	SetPriority(Priority)
+	GetTags() []string
+	SetTags([]string)
+	GetProjectId() int64
+	SetProjectId(int64)
+	GetProjectName() string
+	GetParentId() int64
+	SetParentId(int64)
+	GetIsBlocked() bool
+	GetRecurrence() string
+	SetRecurrence(string)
+	GetDescription() string
+	SetDescription(string)
 	GetUpdatedAt() time.Time
 	SetUpdatedAt(time.Time)
 	GetCreatedAt() time.Time
+	GetDeletedAt() time.Time
+	SetDeletedAt(time.Time)
 }
 
 // item godoc
@@ -26,29 +150,74 @@ type Item interface {
 //
 // Implements the Item interface.
 type item struct {
-	id          int64
-	name        string
-	isCompleted int8
-	updatedAt   time.Time
-	createdAt   time.Time
+	id           int64
+	name         string
+	status       Status
+	statusReason string
+	dueAt        time.Time
+	priority     Priority
+	tags         []string
+	projectId    int64
+	projectName  string
+	parentId     int64
+	isBlocked    bool
+	recurrence   string
+	description  string
+	updatedAt    time.Time
+	createdAt    time.Time
+	deletedAt    time.Time
+}
+
+// ItemDraft godoc
+//
+// Defines the attributes of an item which has not been created yet.
+//
+// Zero valued fields are left unset on the created item.
+type ItemDraft struct {
+	Name     string
+	DueAt    time.Time
+	Priority Priority
+	Tags     []string
+	// ProjectId of the project which owns the item, 0 when the item has no project.
+	ProjectId int64
+	// ParentId of the item which the item is a subtask of, 0 when the item is not a subtask.
+	ParentId int64
+	// Recurrence expression of the item, empty when the item does not repeat.
+	Recurrence string
+	// Description holds free-form notes about the item.
+	Description string
+}
+
+// Dependency godoc
+//
+// Defines an edge between two items: the item with ItemId cannot start before the item with DependsOnId is
+// completed.
+type Dependency struct {
+	ItemId      int64
+	DependsOnId int64
 }
 
 // NewItem godoc
 //
 // Create a new instance of item which adheres to the Item interface.
+//
+// A zero dueAt means that the item has no due date. The item has no priority, tags, project, parent, recurrence,
+// description or status reason until the matching setters are called.
 func NewItem(
 	id int64,
 	name string,
-	isCompleted int8,
+	status Status,
+	dueAt time.Time,
 	updatedAt time.Time,
 	createdAt time.Time,
 ) Item {
 	return &item{
-		id:          id,
-		name:        name,
-		isCompleted: isCompleted,
-		updatedAt:   updatedAt,
-		createdAt:   createdAt,
+		id:        id,
+		name:      name,
+		status:    status,
+		dueAt:     dueAt,
+		updatedAt: updatedAt,
+		createdAt: createdAt,
 	}
 }
 
@@ -62,14 +231,36 @@ func NewItem(
 func NewItemFromRow(rows *sql.Rows) (Item, error) {
 	var item item
 	var updatedAtTimestamp, createdAtTimestamp int64
+	var dueAtTimestamp, deletedAtTimestamp sql.NullInt64
+	var tags, projectName, recurrence, statusReason sql.NullString
+	var projectId, parentId sql.NullInt64
 
-	err := rows.Scan(&item.id, &item.name, &item.isCompleted, &updatedAtTimestamp, &createdAtTimestamp)
+	err := rows.Scan(
+		&item.id, &item.name, &item.status, &dueAtTimestamp, &updatedAtTimestamp, &createdAtTimestamp,
+		&item.priority, &tags, &projectId, &projectName, &parentId, &item.isBlocked,
+		&recurrence, &item.description, &deletedAtTimestamp, &statusReason,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("NewItemFromRow: %v", err)
 	}
 
+	if tags.Valid && tags.String != "" {
+		item.tags = strings.Split(tags.String, ",")
+	}
+	item.projectId = projectId.Int64
+	item.projectName = projectName.String
+	item.parentId = parentId.Int64
+	item.recurrence = recurrence.String
+	item.statusReason = statusReason.String
+
+	if dueAtTimestamp.Valid {
+		item.dueAt = time.Unix(dueAtTimestamp.Int64, 0)
+	}
 	item.updatedAt = time.Unix(updatedAtTimestamp, 0)
 	item.createdAt = time.Unix(createdAtTimestamp, 0)
+	if deletedAtTimestamp.Valid {
+		item.deletedAt = time.Unix(deletedAtTimestamp.Int64, 0)
+	}
 
 	return &item, nil
 }
@@ -95,18 +286,147 @@ func (item *item) SetName(name string) {
 	item.name = name
 }
 
-// GetIsCompleted godoc
+// GetStatus godoc
 //
-// Returns the item's completion flag.
-func (item *item) GetIsCompleted() int8 {
-	return item.isCompleted
+// Returns the item's status.
+func (item *item) GetStatus() Status {
+	return item.status
 }
 
-// SetIsCompleted godoc
+// SetStatus godoc
 //
-// Sets the item's completion flag.
-func (item *item) SetIsCompleted(isCompleted int8) {
-	item.isCompleted = isCompleted
+// Sets the item's status.
+func (item *item) SetStatus(status Status) {
+	item.status = status
+}
+
+// GetStatusReason godoc
+//
+// Returns why the item is blocked or waiting, empty when no reason was given.
+func (item *item) GetStatusReason() string {
+	return item.statusReason
+}
+
+// SetStatusReason godoc
+//
+// Sets why the item is blocked or waiting. Passing an empty string removes the reason.
+func (item *item) SetStatusReason(reason string) {
+	item.statusReason = reason
+}
+
+// GetDueAt godoc
+//
+// Returns the time that the item is due. The zero time is returned when the item has no due date.
+func (item *item) GetDueAt() time.Time {
+	return item.dueAt
+}
+
+// SetDueAt godoc
+//
+// Sets the due time of the item. Passing the zero time removes the due date.
+func (item *item) SetDueAt(dueAt time.Time) {
+	item.dueAt = dueAt
+}
+
+// GetPriority godoc
+//
+// Returns the item's priority.
+func (item *item) GetPriority() Priority {
+	return item.priority
+}
+
+// SetPriority godoc
+//
+// Sets the item's priority.
+func (item *item) SetPriority(priority Priority) {
+	item.priority = priority
+}
+
+// GetTags godoc
+//
+// Returns the names of the tags attached to the item, sorted by name.
+func (item *item) GetTags() []string {
+	return item.tags
+}
+
+// SetTags godoc
+//
+// Sets the names of the tags attached to the item.
+func (item *item) SetTags(tags []string) {
+	item.tags = tags
+}
+
+// GetProjectId godoc
+//
+// Returns the ID of the project which owns the item, 0 when the item has no project.
+func (item *item) GetProjectId() int64 {
+	return item.projectId
+}
+
+// SetProjectId godoc
+//
+// Sets the ID of the project which owns the item. Passing 0 removes the item from its project.
+func (item *item) SetProjectId(projectId int64) {
+	item.projectId = projectId
+	item.projectName = ""
+}
+
+// GetProjectName godoc
+//
+// Returns the name of the project which owns the item, empty when the item has no project or was not read from
+// the database.
+func (item *item) GetProjectName() string {
+	return item.projectName
+}
+
+// GetParentId godoc
+//
+// Returns the ID of the item which the item is a subtask of, 0 when the item is not a subtask.
+func (item *item) GetParentId() int64 {
+	return item.parentId
+}
+
+// SetParentId godoc
+//
+// Sets the ID of the item which the item is a subtask of. Passing 0 makes the item a top level item.
+func (item *item) SetParentId(parentId int64) {
+	item.parentId = parentId
+}
+
+// GetIsBlocked godoc
+//
+// Returns true when the item depends on at least one open item. Always false when the item was not read from the
+// database.
+func (item *item) GetIsBlocked() bool {
+	return item.isBlocked
+}
+
+// GetRecurrence godoc
+//
+// Returns the recurrence rule of the item in its iCalendar RRULE form, empty when the item does not repeat.
+func (item *item) GetRecurrence() string {
+	return item.recurrence
+}
+
+// SetRecurrence godoc
+//
+// Sets the recurrence rule of the item. Passing an empty string stops the item from repeating.
+func (item *item) SetRecurrence(recurrence string) {
+	item.recurrence = recurrence
+}
+
+// GetDescription godoc
+//
+// Returns the free-form, possibly multi-line, notes of the item.
+func (item *item) GetDescription() string {
+	return item.description
+}
+
+// SetDescription godoc
+//
+// Sets the notes of the item. Passing an empty string removes them.
+func (item *item) SetDescription(description string) {
+	item.description = description
 }
 
 // GetUpdatedAt godoc
@@ -129,3 +449,17 @@ func (item *item) SetUpdatedAt(time time.Time) {
 func (item *item) GetCreatedAt() time.Time {
 	return item.createdAt
 }
+
+// GetDeletedAt godoc
+//
+// Returns the time that the item was moved to the trash, the zero time when it is not in the trash.
+func (item *item) GetDeletedAt() time.Time {
+	return item.deletedAt
+}
+
+// SetDeletedAt godoc
+//
+// Sets the time that the item was moved to the trash. Passing the zero time takes it out of the trash.
+func (item *item) SetDeletedAt(deletedAt time.Time) {
+	item.deletedAt = deletedAt
+}
@@ -9,15 +9,123 @@ import (
 func TestNewItem(t *testing.T) {
 	var id int64 = 0
 	name := "name"
-	var isCompleted int8 = 0
+	status := StatusInProgress
+	dueAt := time.Now().Add(time.Hour)
 	updatedAt := time.Now()
 	createdAt := updatedAt
 
-	item := NewItem(id, name, isCompleted, updatedAt, createdAt)
+	item := NewItem(id, name, status, dueAt, updatedAt, createdAt)
 
 	assert.Equal(t, id, item.GetId())
 	assert.Equal(t, name, item.GetName())
-	assert.Equal(t, isCompleted, item.GetIsCompleted())
+	assert.Equal(t, status, item.GetStatus())
+	assert.Equal(t, dueAt, item.GetDueAt())
 	assert.Equal(t, updatedAt, item.GetUpdatedAt())
 	assert.Equal(t, createdAt, item.GetCreatedAt())
 }
+
+func TestItem_Priority(t *testing.T) {
+	item := NewItem(0, "name", StatusTodo, time.Time{}, time.Now(), time.Now())
+	assert.Equal(t, PriorityNone, item.GetPriority())
+
+	item.SetPriority(PriorityHigh)
+	assert.Equal(t, PriorityHigh, item.GetPriority())
+}
+
+func TestParsePriority(t *testing.T) {
+	type testCase struct {
+		value       string
+		expected    Priority
+		expectError bool
+	}
+
+	testCases := []testCase{
+		{value: "none", expected: PriorityNone},
+		{value: "low", expected: PriorityLow},
+		{value: "Medium", expected: PriorityMedium},
+		{value: "HIGH", expected: PriorityHigh},
+		{value: "urgent", expected: PriorityUrgent},
+		{value: "3", expected: PriorityHigh},
+		{value: "critical", expected: PriorityNone, expectError: true},
+		{value: "5", expected: PriorityNone, expectError: true},
+	}
+
+	for _, test := range testCases {
+		priority, err := ParsePriority(test.value)
+		if test.expectError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, test.expected, priority)
+	}
+}
+
+func TestPriority_String(t *testing.T) {
+	assert.Equal(t, "urgent", PriorityUrgent.String())
+	assert.Equal(t, "none", PriorityNone.String())
+	assert.Equal(t, "Priority(9)", Priority(9).String())
+}
+
+func TestParseStatus(t *testing.T) {
+	type testCase struct {
+		value       string
+		expected    Status
+		expectError bool
+	}
+
+	testCases := []testCase{
+		{value: "todo", expected: StatusTodo},
+		{value: "In-Progress", expected: StatusInProgress},
+		{value: " blocked ", expected: StatusBlocked},
+		{value: "waiting", expected: StatusWaiting},
+		{value: "DONE", expected: StatusDone},
+		{value: "cancelled", expected: StatusCancelled},
+		{value: "open", expected: StatusTodo, expectError: true},
+		{value: "", expected: StatusTodo, expectError: true},
+	}
+
+	for _, test := range testCases {
+		status, err := ParseStatus(test.value)
+		if test.expectError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, test.expected, status)
+	}
+}
+
+func TestStatus_IsClosed(t *testing.T) {
+	for _, status := range Statuses {
+		expected := status == StatusDone || status == StatusCancelled
+		assert.Equal(t, expected, status.IsClosed(), status.String())
+	}
+}
+
+func TestItem_Status(t *testing.T) {
+	item := NewItem(0, "name", StatusTodo, time.Time{}, time.Now(), time.Now())
+	assert.Equal(t, "", item.GetStatusReason())
+
+	item.SetStatus(StatusWaiting)
+	item.SetStatusReason("vendor")
+	assert.Equal(t, StatusWaiting, item.GetStatus())
+	assert.Equal(t, "vendor", item.GetStatusReason())
+}
+
+func TestItem_Tags(t *testing.T) {
+	item := NewItem(0, "name", StatusTodo, time.Time{}, time.Now(), time.Now())
+	assert.Empty(t, item.GetTags())
+
+	item.SetTags([]string{"backend", "urgent"})
+	assert.Equal(t, []string{"backend", "urgent"}, item.GetTags())
+}
+
+func TestItem_DeletedAt(t *testing.T) {
+	item := NewItem(0, "name", StatusTodo, time.Time{}, time.Now(), time.Now())
+	assert.True(t, item.GetDeletedAt().IsZero())
+
+	deletedAt := time.Date(2026, 10, 14, 10, 30, 0, 0, time.Local)
+	item.SetDeletedAt(deletedAt)
+	assert.Equal(t, deletedAt, item.GetDeletedAt())
+}
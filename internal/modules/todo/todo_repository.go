@@ -1,20 +1,51 @@
 package todo
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"sort"
+	"strings"
+	"time"
 )
 
 // Repository godoc
 //
 // Define a repository for a collection of Item.
 type Repository interface {
-	PersistItem(Item) (int64, error)
-	FindAllItems() ([]Item, error)
-	FindItemById(int64) (Item, error)
-	UpdateItemById(Item) (int64, error)
-	DeleteItemById(int64) (int64, error)
+	PersistItem(context.Context, Item) (int64, error)
+	FindAllItems(context.Context) ([]Item, error)
+	FindItems(context.Context, ItemFilter) ([]Item, error)
+	FindItemById(context.Context, int64) (Item, error)
+	FindTrashedItemById(context.Context, int64) (Item, error)
+	FindDescendantItems(context.Context, int64) ([]Item, error)
+	FindLineageIds(context.Context, int64) ([]int64, error)
+	UpdateItemById(context.Context, Item) (int64, error)
+	UpdateItemsById(context.Context, []Item) (int64, error)
+	DeleteItemById(context.Context, int64) (int64, error)
+	DeleteTrashedItems(context.Context, time.Time) (int64, error)
+	AttachTag(context.Context, int64, string) (int64, error)
+	DetachTag(context.Context, int64, string) (int64, error)
+	FindTagsByItemId(context.Context, int64) ([]string, error)
+	FindAllTags(context.Context) ([]string, error)
+	AddDependency(context.Context, Dependency) (int64, error)
+	RemoveDependency(context.Context, Dependency) (int64, error)
+	FindAllDependencies(context.Context) ([]Dependency, error)
+	FindItemDependencies(context.Context, int64) ([]Dependency, error)
+	SearchItems(context.Context, string) ([]SearchResult, error)
+	WithTransaction(context.Context, func(Repository) error) error
+	RestoreItems(context.Context, []ItemSnapshot) error
+	PersistJournalEntry(context.Context, JournalEntry) (int64, error)
+	FindJournalEntries(context.Context, int) ([]JournalEntry, error)
+	FindLastJournalEntry(context.Context, bool) (*JournalEntry, error)
+	SetJournalEntryUndone(context.Context, int64, bool) (int64, error)
+	DeleteUndoneJournalEntries(context.Context) (int64, error)
+	TrimJournal(context.Context, int) (int64, error)
+	PersistItemEvents(context.Context, []ItemEvent) (int64, error)
+	FindItemEvents(context.Context, ItemEventFilter) ([]ItemEvent, error)
 }
 
 // sqliteRepository godoc
@@ -22,6 +53,8 @@ type Repository interface {
 // Define a repository for a collection of Item that adheres to Repository.
 type sqliteRepository struct {
 	db *sql.DB
+	// tx is the transaction which statements run in, set for the repository passed to WithTransaction.
+	tx *sql.Tx
 }
 
 // NewSqliteRepository godoc
@@ -32,11 +65,273 @@ func NewSqliteRepository(db *sql.DB) Repository {
 	}
 }
 
+// ItemFilter godoc
+//
+// Defines the criteria used to narrow down the items returned by Repository.FindItems.
+//
+// Zero valued fields are ignored.
+type ItemFilter struct {
+	// OpenOnly excludes closed items, which are done or cancelled.
+	OpenOnly bool
+	// DueFrom keeps items due at or after this time.
+	DueFrom time.Time
+	// DueBefore keeps items due strictly before this time.
+	DueBefore time.Time
+	// Tags keeps items which have every one of these tags.
+	Tags []string
+	// ExcludedTags drops items which have any of these tags.
+	ExcludedTags []string
+	// ProjectId keeps items owned by this project, or items without a project when NoProjectId.
+	ProjectId int64
+	// HideArchivedProjects drops items owned by archived projects.
+	HideArchivedProjects bool
+	// ReadyOnly keeps the items to do or in progress whose dependencies are all closed.
+	ReadyOnly bool
+	// RecurringOnly keeps items which repeat.
+	RecurringOnly bool
+	// ParentId keeps the direct subtasks of this item.
+	ParentId int64
+	// Expression keeps items matching a parsed filter expression, see ParseFilter.
+	Expression FilterExpression
+	// Order sorts the items by each key in turn, the default item order when empty.
+	Order []SortKey
+	// Trashed keeps the items in the trash, most recently removed first unless Order is set, instead of excluding
+	// them.
+	Trashed bool
+}
+
+// ItemEventFilter godoc
+//
+// Defines the criteria used to narrow down the events returned by Repository.FindItemEvents.
+//
+// Zero valued fields are ignored.
+type ItemEventFilter struct {
+	// ItemId keeps the events of a single item.
+	ItemId int64
+	// Since keeps the events recorded at or after this time.
+	Since time.Time
+}
+
+// SearchResult godoc
+//
+// Defines an item matching a full-text search, as returned by Repository.SearchItems.
+type SearchResult struct {
+	Item Item
+	// Rank is the BM25 relevance of the item, higher is more relevant.
+	Rank float64
+	// NameHighlight is the name of the item with the matches between MatchStart and MatchEnd.
+	NameHighlight string
+	// NotesSnippet is the part of the notes around the matches, between MatchStart and MatchEnd, or empty when the
+	// notes do not match.
+	NotesSnippet string
+}
+
+// Markers placed around the matches of SearchResult.NameHighlight and SearchResult.NotesSnippet.
+const (
+	MatchStart = "\x02"
+	MatchEnd   = "\x03"
+)
+
+// NoProjectId godoc
+//
+// Project ID used in an ItemFilter to select the items which are not owned by a project.
+const NoProjectId int64 = -1
+
 // tableName godoc
 //
 // Name for the database table which hold the items.
 const tableName = "todos"
 
+// tagsTableName godoc
+//
+// Name for the database table which holds the tag names.
+const tagsTableName = "tags"
+
+// itemTagsTableName godoc
+//
+// Name for the database table which links items to tags.
+const itemTagsTableName = "todo_tags"
+
+// dependenciesTableName godoc
+//
+// Name for the database table which links items to the items they depend on.
+const dependenciesTableName = "todo_dependencies"
+
+// journalTableName godoc
+//
+// Name for the database table which records the operations on items, to undo and redo them.
+const journalTableName = "journal"
+
+// journalColumns godoc
+//
+// Columns selected for a journal entry, in the order expected by NewJournalEntryFromRow.
+const journalColumns = "id, summary, changes, isUndone, createdAt"
+
+// itemEventsTableName godoc
+//
+// Name for the database table which records the changes of the fields of items.
+const itemEventsTableName = "item_events"
+
+// itemEventColumns godoc
+//
+// Columns selected for an item event, in the order expected by NewItemEventFromRow. The name of the item is empty
+// once it is deleted for good.
+const itemEventColumns = "item_events.id, item_events.itemId, COALESCE(todos.displayName, ''), item_events.field, " +
+	"item_events.oldValue, item_events.newValue, item_events.origin, item_events.createdAt"
+
+// itemColumns godoc
+//
+// Columns selected for an item, in the order expected by NewItemFromRow.
+//
+// Tags are aggregated into a comma separated list, the project name is looked up from the project ID and an item
+// is blocked while it depends on an open item.
+const itemColumns = "id, displayName, status, dueAt, updatedAt, createdAt, priority, " +
+	"(SELECT GROUP_CONCAT(tags.name, ',' ORDER BY tags.name) FROM todo_tags " +
+	"JOIN tags ON tags.id = todo_tags.tagId WHERE todo_tags.todoId = todos.id) AS tags, " +
+	"projectId, (SELECT projects.name FROM projects WHERE projects.id = todos.projectId) AS projectName, parentId, " +
+	isBlockedCondition + " AS isBlocked, recurrence, description, deletedAt, statusReason"
+
+// notTrashedCondition godoc
+//
+// Condition which matches the items which are not in the trash. Every query excludes the items in the trash unless
+// it is about the trash.
+const notTrashedCondition = "deletedAt IS NULL"
+
+// isClosedCondition godoc
+//
+// Condition which matches items which are done or cancelled, see Status.IsClosed.
+const isClosedCondition = "status IN ('done', 'cancelled')"
+
+// isBlockedCondition godoc
+//
+// Condition which matches items depending on at least one open item which is not in the trash.
+const isBlockedCondition = "EXISTS (SELECT 1 FROM todo_dependencies " +
+	"JOIN todos AS dependencies ON dependencies.id = todo_dependencies.dependsOnId " +
+	"WHERE todo_dependencies.todoId = todos.id AND dependencies.status NOT IN ('done', 'cancelled') " +
+	"AND dependencies.deletedAt IS NULL)"
+
+// descendantsQuery godoc
+//
+// Recursive query which selects the IDs of the subtasks of the item bound to the placeholder, at any depth, which
+// are not in the trash.
+const descendantsQuery = "WITH RECURSIVE descendants (id) AS (" +
+	"SELECT id FROM todos WHERE parentId = ? AND deletedAt IS NULL " +
+	"UNION SELECT todos.id FROM todos JOIN descendants ON todos.parentId = descendants.id " +
+	"WHERE todos.deletedAt IS NULL" +
+	") SELECT id FROM descendants"
+
+// lineageQuery godoc
+//
+// Recursive query which selects the ID of the item bound to the placeholder followed by the IDs of its parents,
+// up to the top level item. Nothing is selected when the item is in the trash.
+const lineageQuery = "WITH RECURSIVE lineage (id, parentId, depth) AS (" +
+	"SELECT id, parentId, 0 FROM todos WHERE id = ? AND deletedAt IS NULL " +
+	"UNION SELECT todos.id, todos.parentId, lineage.depth + 1 FROM todos JOIN lineage ON todos.id = lineage.parentId" +
+	") SELECT id FROM lineage ORDER BY depth"
+
+// hasTagCondition godoc
+//
+// Condition which matches items having a tag with one of the names bound to the placeholders.
+const hasTagCondition = "EXISTS (SELECT 1 FROM todo_tags JOIN tags ON tags.id = todo_tags.tagId " +
+	"WHERE todo_tags.todoId = todos.id AND tags.name IN (%s))"
+
+// searchTableName godoc
+//
+// Name for the FTS5 virtual table which indexes the names and notes of the items.
+const searchTableName = "todos_fts"
+
+// searchColumnWeights godoc
+//
+// Weight of a match in each column of the search table when ranking results with bm25(): a match in the name
+// counts twice as much as a match in the notes.
+var searchColumnWeights = []any{2.0, 1.0}
+
+// itemOrder godoc
+//
+// Default ordering of items: open items first, then by descending priority, then by the closest due date.
+// Items without a due date are placed last within their priority.
+const itemOrder = isClosedCondition + ", priority DESC, dueAt IS NULL, dueAt, id"
+
+// sortColumns godoc
+//
+// Column which each SortOrder sorts by, in ascending order.
+var sortColumns = map[SortOrder]string{
+	SortOrderPriority: "priority",
+	SortOrderDue:      "dueAt",
+	SortOrderCreated:  "createdAt",
+	SortOrderUpdated:  "updatedAt",
+	SortOrderName:     "displayName COLLATE NOCASE",
+	SortOrderId:       "id",
+}
+
+// sortTieBreakers godoc
+//
+// Returns the keys which order the items that are equal on the key, so that a single key lists items as it always
+// did, e.g. `due` lists the items due at the same time by descending priority.
+func sortTieBreakers(key SortKey) []SortKey {
+	switch key.Order {
+	case SortOrderPriority:
+		return []SortKey{{Order: SortOrderDue}, {Order: SortOrderId}}
+	case SortOrderDue:
+		return []SortKey{{Order: SortOrderPriority, Descending: true}, {Order: SortOrderId}}
+	case SortOrderCreated, SortOrderUpdated:
+		// IDs grow with the creation time, so they follow the direction of the key
+		return []SortKey{{Order: SortOrderId, Descending: key.Descending}}
+	}
+	return []SortKey{{Order: SortOrderId}}
+}
+
+// orderByKeys godoc
+//
+// Returns the ORDER BY clause of the sort keys, itemOrder when there are none. Open items always come first and ties
+// are broken by the tie-breakers of the first key. Items without a due date come last whatever the direction.
+func orderByKeys(keys []SortKey) string {
+	if len(keys) == 0 {
+		return itemOrder
+	}
+	terms := []string{isClosedCondition}
+	seen := map[SortOrder]bool{}
+	for _, key := range append(append([]SortKey{}, keys...), sortTieBreakers(keys[0])...) {
+		column, ok := sortColumns[key.Order]
+		if !ok || seen[key.Order] {
+			continue
+		}
+		seen[key.Order] = true
+		if key.Order == SortOrderDue {
+			terms = append(terms, "dueAt IS NULL")
+		}
+		if key.Descending {
+			column += " DESC"
+		}
+		terms = append(terms, column)
+	}
+	return strings.Join(terms, ", ")
+}
+
+// nullableUnix godoc
+//
+// Converts a time to a nullable Unix timestamp. The zero time is stored as NULL.
+func nullableUnix(t time.Time) sql.NullInt64 {
+	if t.IsZero() {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.Unix(), Valid: true}
+}
+
+// nullableString godoc
+//
+// Converts a string into a nullable string. The empty string is stored as NULL.
+func nullableString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullableId godoc
+//
+// Converts a reference to another row into a nullable ID. The ID 0 is stored as NULL.
+func nullableId(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
 // PersistItem godoc
 //
 // Adds an Item to the database.
@@ -44,20 +339,31 @@ const tableName = "todos"
 // Returns -1 and an error on error.
 //
 // Returns ID (Greater than 0) of inserted item and nil on success.
-func (repo *sqliteRepository) PersistItem(itemToPersist Item) (int64, error) {
+func (repo *sqliteRepository) PersistItem(ctx context.Context, itemToPersist Item) (int64, error) {
 	if repo.db == nil {
 		return -1, fmt.Errorf("PersistItem: database connection is nil")
 	}
 
 	query := fmt.Sprintf(
-		"INSERT INTO %s (displayName, updatedAt, createdAt) VALUES (?, ?, ?)",
+		"INSERT INTO %s (displayName, status, statusReason, dueAt, priority, projectId, parentId, recurrence, "+
+			"description, updatedAt, createdAt, deletedAt) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
 		tableName,
 	)
-	result, err := repo.db.Exec(
+	result, err := repo.conn().ExecContext(
+		ctx,
 		query,
 		itemToPersist.GetName(),
+		itemToPersist.GetStatus(),
+		nullableString(itemToPersist.GetStatusReason()),
+		nullableUnix(itemToPersist.GetDueAt()),
+		itemToPersist.GetPriority(),
+		nullableId(itemToPersist.GetProjectId()),
+		nullableId(itemToPersist.GetParentId()),
+		nullableString(itemToPersist.GetRecurrence()),
+		itemToPersist.GetDescription(),
 		itemToPersist.GetUpdatedAt().Unix(),
 		itemToPersist.GetCreatedAt().Unix(),
+		nullableUnix(itemToPersist.GetDeletedAt()),
 	)
 	if err != nil {
 		return -1, fmt.Errorf("PersistItem: %v", err)
@@ -78,67 +384,155 @@ func (repo *sqliteRepository) PersistItem(itemToPersist Item) (int64, error) {
 // Returns nil and error on error.
 //
 // Returns a slice containing Item instances and nil on success.
-func (repo *sqliteRepository) FindAllItems() ([]Item, error) {
+func (repo *sqliteRepository) FindAllItems(ctx context.Context) ([]Item, error) {
+	items, err := repo.FindItems(ctx, ItemFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("FindAllItems: %v", err)
+	}
+	return items, nil
+}
+
+// FindItems godoc
+//
+// Retrieves the items stored in the database table which match the filter.
+//
+// Returns nil and error on error.
+//
+// Returns a slice containing Item instances and nil on success.
+func (repo *sqliteRepository) FindItems(ctx context.Context, filter ItemFilter) ([]Item, error) {
 	if repo.db == nil {
-		return nil, fmt.Errorf("FindAllItems: database connection is nil")
+		return nil, fmt.Errorf("FindItems: database connection is nil")
 	}
 
-	var result []Item
-	query := fmt.Sprintf(
-		"SELECT id, displayName, isCompleted, updatedAt, createdAt FROM %s ORDER BY isCompleted", tableName,
-	)
-	rows, err := repo.db.Query(query)
-	defer func(rows *sql.Rows) {
-		closeErr := rows.Close()
-		if closeErr != nil {
-			if err == nil {
-				// Return `closeErr` if `err` is not set already
-				err = fmt.Errorf("FindAllItems failed to close rows: %w", closeErr)
-			} else {
-				// Log `closeErr` when `err` is already set
-				log.Warnf("WARNING: FindAllItems failed to close rows (original error: %v): %v", err, closeErr)
-			}
+	conditions := []string{notTrashedCondition}
+	if filter.Trashed {
+		conditions[0] = "deletedAt IS NOT NULL"
+	}
+	var args []any
+	if filter.OpenOnly {
+		conditions = append(conditions, "NOT "+isClosedCondition)
+	}
+	if !filter.DueFrom.IsZero() {
+		conditions = append(conditions, "dueAt >= ?")
+		args = append(args, filter.DueFrom.Unix())
+	}
+	if !filter.DueBefore.IsZero() {
+		conditions = append(conditions, "dueAt < ?")
+		args = append(args, filter.DueBefore.Unix())
+	}
+	for _, tag := range filter.Tags {
+		conditions = append(conditions, fmt.Sprintf(hasTagCondition, "?"))
+		args = append(args, tag)
+	}
+	if filter.ProjectId == NoProjectId {
+		conditions = append(conditions, "projectId IS NULL")
+	} else if filter.ProjectId != 0 {
+		conditions = append(conditions, "projectId = ?")
+		args = append(args, filter.ProjectId)
+	}
+	if filter.HideArchivedProjects {
+		conditions = append(
+			conditions,
+			"NOT EXISTS (SELECT 1 FROM projects WHERE projects.id = todos.projectId AND projects.archivedAt IS NOT NULL)",
+		)
+	}
+	if filter.ParentId != 0 {
+		conditions = append(conditions, "parentId = ?")
+		args = append(args, filter.ParentId)
+	}
+	if filter.RecurringOnly {
+		conditions = append(conditions, "recurrence IS NOT NULL")
+	}
+	if filter.ReadyOnly {
+		conditions = append(conditions, "status IN ('todo', 'in-progress')", "NOT "+isBlockedCondition)
+	}
+	if len(filter.ExcludedTags) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.ExcludedTags)), ", ")
+		conditions = append(conditions, "NOT "+fmt.Sprintf(hasTagCondition, placeholders))
+		for _, tag := range filter.ExcludedTags {
+			args = append(args, tag)
 		}
-	}(rows)
-	if err != nil {
-		return nil, fmt.Errorf("FindAllItems: %v", err)
 	}
-
-	result = []Item{}
-	for rows.Next() {
-		item, err := NewItemFromRow(rows)
+	if filter.Expression != nil {
+		condition, expressionArgs, err := compileFilter(filter.Expression)
 		if err != nil {
-			return nil, fmt.Errorf("FindAllItems: %v", err)
+			return nil, fmt.Errorf("FindItems: %v", err)
 		}
-		result = append(result, item)
+		conditions = append(conditions, condition)
+		args = append(args, expressionArgs...)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("FindAllItems: %v", err)
+	order := orderByKeys(filter.Order)
+	if filter.Trashed && len(filter.Order) == 0 {
+		order = "deletedAt DESC, id"
+	}
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s ORDER BY %s", itemColumns, tableName, strings.Join(conditions, " AND "), order,
+	)
+	result, err := repo.findItems(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("FindItems: %v", err)
+	}
+	return result, nil
+}
+
+// FindDescendantItems godoc
+//
+// Retrieves the subtasks of an Item using its ID, at any depth, in the default item order.
+//
+// Returns nil and error on error.
+//
+// Returns a slice containing Item instances and nil on success.
+func (repo *sqliteRepository) FindDescendantItems(ctx context.Context, parentId int64) ([]Item, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindDescendantItems: database connection is nil")
 	}
 
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE id IN (%s) ORDER BY %s", itemColumns, tableName, descendantsQuery, itemOrder,
+	)
+	result, err := repo.findItems(ctx, query, parentId)
+	if err != nil {
+		return nil, fmt.Errorf("FindDescendantItems: %v", err)
+	}
 	return result, nil
 }
 
+// FindLineageIds godoc
+//
+// Retrieves the ID of an Item followed by the IDs of its parents, up to the top level item.
+//
+// Returns nil and error on error.
+//
+// Returns the IDs and nil on success. The slice is empty when the item does not exist.
+func (repo *sqliteRepository) FindLineageIds(ctx context.Context, itemId int64) ([]int64, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindLineageIds: database connection is nil")
+	}
+
+	ids, err := repo.findIds(ctx, lineageQuery, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("FindLineageIds: %v", err)
+	}
+	return ids, nil
+}
+
 // FindItemById godoc
 //
-// Get a persisted todo item by its ID.
+// Get a persisted todo item by its ID, unless it is in the trash.
 //
 // Returns nil and nil when no item is found.
 //
 // Returns nil and error on error.
 //
 // Returns the found Item and nil on success.
-func (repo *sqliteRepository) FindItemById(id int64) (Item, error) {
+func (repo *sqliteRepository) FindItemById(ctx context.Context, id int64) (Item, error) {
 	if id == 0 {
 		return nil, nil
 	}
 
-	query := fmt.Sprintf(
-		"SELECT id, displayName, isCompleted, updatedAt, createdAt FROM %s WHERE id = %d",
-		tableName, id,
-	)
-	rows, err := repo.db.Query(query)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = ? AND %s", itemColumns, tableName, notTrashedCondition)
+	rows, err := repo.conn().QueryContext(ctx, query, id)
 	if err != nil {
 		return nil, fmt.Errorf("FindItemById: %v", err)
 	}
@@ -167,6 +561,31 @@ func (repo *sqliteRepository) FindItemById(id int64) (Item, error) {
 	return item, nil
 }
 
+// FindTrashedItemById godoc
+//
+// Get a todo item in the trash by its ID.
+//
+// Returns nil and nil when no item is found in the trash.
+//
+// Returns nil and error on error.
+//
+// Returns the found Item and nil on success.
+func (repo *sqliteRepository) FindTrashedItemById(ctx context.Context, id int64) (Item, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindTrashedItemById: database connection is nil")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = ? AND deletedAt IS NOT NULL", itemColumns, tableName)
+	items, err := repo.findItems(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("FindTrashedItemById: %v", err)
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return items[0], nil
+}
+
 // UpdateItemById godoc
 //
 // Update an Item in the database table using its ID.
@@ -175,29 +594,174 @@ func (repo *sqliteRepository) FindItemById(id int64) (Item, error) {
 //
 // Returns number of updated rows and nil on success. If an item is updated the number of updated rows will be 1,
 // else 0.
-func (repo *sqliteRepository) UpdateItemById(itemToUpdate Item) (int64, error) {
+func (repo *sqliteRepository) UpdateItemById(ctx context.Context, itemToUpdate Item) (int64, error) {
 	if repo.db == nil {
 		return -1, fmt.Errorf("UpdateItemById: database connection is nil")
 	}
 
+	rowCount, err := updateItem(ctx, repo.conn(), itemToUpdate)
+	if err != nil {
+		return -1, fmt.Errorf("UpdateItemById: %v", err)
+	}
+	return rowCount, nil
+}
+
+// UpdateItemsById godoc
+//
+// Update several Item in the database table using their IDs, in a single transaction.
+//
+// Returns -1 and error on error. No item is updated in that case.
+//
+// Returns the total number of updated rows and nil on success.
+func (repo *sqliteRepository) UpdateItemsById(ctx context.Context, itemsToUpdate []Item) (rowCount int64, err error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("UpdateItemsById: database connection is nil")
+	}
+	// The items are updated along with the other statements of the transaction of the repository
+	if repo.tx != nil {
+		return updateItems(ctx, repo.tx, itemsToUpdate)
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return -1, fmt.Errorf("UpdateItemsById: %v", err)
+	}
+	defer func(tx *sql.Tx) {
+		// Roll back unless the transaction was committed
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Warnf("WARNING: UpdateItemsById: Failed to roll back transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	rowCount, err = updateItems(ctx, tx, itemsToUpdate)
+	if err != nil {
+		return -1, fmt.Errorf("UpdateItemsById: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return -1, fmt.Errorf("UpdateItemsById: %v", err)
+	}
+	return rowCount, nil
+}
+
+// WithTransaction godoc
+//
+// Runs fn with a Repository whose statements run in a single transaction, which is committed when fn returns nil
+// and rolled back otherwise. When the repository already runs in a transaction, fn runs in that transaction.
+//
+// Returns the error returned by fn, or error on error starting or committing the transaction.
+//
+// Returns nil on success.
+func (repo *sqliteRepository) WithTransaction(ctx context.Context, fn func(Repository) error) error {
+	if repo.db == nil {
+		return fmt.Errorf("WithTransaction: database connection is nil")
+	}
+	if repo.tx != nil {
+		return fn(repo)
+	}
+
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("WithTransaction: %v", err)
+	}
+	defer func(tx *sql.Tx) {
+		// Roll back unless the transaction was committed
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Warnf("WARNING: WithTransaction: Failed to roll back transaction: %v", rollbackErr)
+		}
+	}(tx)
+
+	if err := fn(&sqliteRepository{db: repo.db, tx: tx}); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("WithTransaction: %v", err)
+	}
+	return nil
+}
+
+// connection godoc
+//
+// Defines the subset of sql.DB and sql.Tx used to run statements and queries.
+type connection interface {
+	execer
+	QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+}
+
+// conn godoc
+//
+// Returns the transaction of the repository, or its database when it does not run in a transaction.
+func (repo *sqliteRepository) conn() connection {
+	if repo.tx != nil {
+		return repo.tx
+	}
+	return repo.db
+}
+
+// execer godoc
+//
+// Defines the subset of sql.DB and sql.Tx used to run statements.
+type execer interface {
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+}
+
+// updateItems godoc
+//
+// Writes every attribute of several Item to their rows.
+//
+// Returns -1 and error on error.
+//
+// Returns the total number of updated rows and nil on success.
+func updateItems(ctx context.Context, exec execer, itemsToUpdate []Item) (int64, error) {
+	var rowCount int64
+	for _, itemToUpdate := range itemsToUpdate {
+		updatedRows, err := updateItem(ctx, exec, itemToUpdate)
+		if err != nil {
+			return -1, fmt.Errorf("updateItems: Failed to update item with ID %d: %v", itemToUpdate.GetId(), err)
+		}
+		rowCount += updatedRows
+	}
+	return rowCount, nil
+}
+
+// updateItem godoc
+//
+// Writes every attribute of an Item to its row.
+//
+// Returns -1 and error on error.
+//
+// Returns number of updated rows and nil on success.
+func updateItem(ctx context.Context, exec execer, itemToUpdate Item) (int64, error) {
 	query := fmt.Sprintf(
-		"UPDATE %s SET displayName = ?, updatedAt = ?, isCompleted = ? WHERE id = ?",
+		"UPDATE %s SET displayName = ?, dueAt = ?, priority = ?, projectId = ?, parentId = ?, recurrence = ?, "+
+			"description = ?, updatedAt = ?, status = ?, statusReason = ?, deletedAt = ? WHERE id = ?",
 		tableName,
 	)
-	result, err := repo.db.Exec(
+	result, err := exec.ExecContext(
+		ctx,
 		query,
 		itemToUpdate.GetName(),
+		nullableUnix(itemToUpdate.GetDueAt()),
+		itemToUpdate.GetPriority(),
+		nullableId(itemToUpdate.GetProjectId()),
+		nullableId(itemToUpdate.GetParentId()),
+		nullableString(itemToUpdate.GetRecurrence()),
+		itemToUpdate.GetDescription(),
 		itemToUpdate.GetUpdatedAt().Unix(),
-		itemToUpdate.GetIsCompleted(),
+		itemToUpdate.GetStatus(),
+		nullableString(itemToUpdate.GetStatusReason()),
+		nullableUnix(itemToUpdate.GetDeletedAt()),
 		itemToUpdate.GetId(),
 	)
 	if err != nil {
-		return -1, fmt.Errorf("UpdateItemById: %v", err)
+		return -1, fmt.Errorf("updateItem: %v", err)
 	}
 
 	rowCount, err := result.RowsAffected()
 	if err != nil {
-		return -1, fmt.Errorf("UpdateItemById: %v", err)
+		return -1, fmt.Errorf("updateItem: %v", err)
 	}
 	return rowCount, nil
 }
@@ -210,7 +774,7 @@ func (repo *sqliteRepository) UpdateItemById(itemToUpdate Item) (int64, error) {
 //
 // Returns number of deleted rows and nil on success. If an item is deleted the number of deleted rows will be 1,
 // else 0.
-func (repo *sqliteRepository) DeleteItemById(idToDelete int64) (int64, error) {
+func (repo *sqliteRepository) DeleteItemById(ctx context.Context, idToDelete int64) (int64, error) {
 	if repo.db == nil {
 		return -1, fmt.Errorf("DeleteItemById: database connection is nil")
 	}
@@ -219,7 +783,8 @@ func (repo *sqliteRepository) DeleteItemById(idToDelete int64) (int64, error) {
 		"DELETE FROM %s WHERE id = ?",
 		tableName,
 	)
-	result, err := repo.db.Exec(
+	result, err := repo.conn().ExecContext(
+		ctx,
 		query,
 		idToDelete,
 	)
@@ -239,3 +804,1071 @@ func (repo *sqliteRepository) DeleteItemById(idToDelete int64) (int64, error) {
 	}
 	return rowCount, nil
 }
+
+// DeleteTrashedItems godoc
+//
+// Delete the items which were moved to the trash before a time, or every item in the trash when before is the zero
+// time. Their subtasks are deleted along with them.
+//
+// Returns -1 and error on error.
+//
+// Returns number of deleted items, not counting their subtasks, and nil on success.
+func (repo *sqliteRepository) DeleteTrashedItems(ctx context.Context, before time.Time) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("DeleteTrashedItems: database connection is nil")
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE deletedAt IS NOT NULL", tableName)
+	var args []any
+	if !before.IsZero() {
+		query += " AND deletedAt < ?"
+		args = append(args, before.Unix())
+	}
+	rowCount, err := repo.execRowCount(ctx, query, args...)
+	if err != nil {
+		return -1, fmt.Errorf("DeleteTrashedItems: %v", err)
+	}
+	return rowCount, nil
+}
+
+// AttachTag godoc
+//
+// Attach a tag to an Item using its ID. The tag is created when it does not exist yet.
+//
+// Returns -1 and error on error.
+//
+// Returns number of attached tags and nil on success. If the tag is newly attached the number will be 1,
+// else 0.
+func (repo *sqliteRepository) AttachTag(ctx context.Context, itemId int64, tag string) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("AttachTag: database connection is nil")
+	}
+
+	query := fmt.Sprintf("INSERT OR IGNORE INTO %s (name) VALUES (?)", tagsTableName)
+	if _, err := repo.conn().ExecContext(ctx, query, tag); err != nil {
+		return -1, fmt.Errorf("AttachTag: %v", err)
+	}
+
+	query = fmt.Sprintf(
+		"INSERT OR IGNORE INTO %s (todoId, tagId) SELECT ?, id FROM %s WHERE name = ?",
+		itemTagsTableName, tagsTableName,
+	)
+	result, err := repo.conn().ExecContext(ctx, query, itemId, tag)
+	if err != nil {
+		return -1, fmt.Errorf("AttachTag: %v", err)
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("AttachTag: %v", err)
+	}
+	return rowCount, nil
+}
+
+// DetachTag godoc
+//
+// Detach a tag from an Item using its ID.
+//
+// Returns -1 and error on error.
+//
+// Returns number of detached tags and nil on success. If the tag is detached the number will be 1, else 0.
+func (repo *sqliteRepository) DetachTag(ctx context.Context, itemId int64, tag string) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("DetachTag: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE todoId = ? AND tagId = (SELECT id FROM %s WHERE name = ?)",
+		itemTagsTableName, tagsTableName,
+	)
+	result, err := repo.conn().ExecContext(ctx, query, itemId, tag)
+	if err != nil {
+		return -1, fmt.Errorf("DetachTag: %v", err)
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("DetachTag: %v", err)
+	}
+	return rowCount, nil
+}
+
+// FindTagsByItemId godoc
+//
+// Retrieves the names of the tags attached to an Item using its ID, sorted by name.
+//
+// Returns nil and error on error.
+//
+// Returns the tag names and nil on success.
+func (repo *sqliteRepository) FindTagsByItemId(ctx context.Context, itemId int64) ([]string, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindTagsByItemId: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"SELECT tags.name FROM %s JOIN %s ON tags.id = todo_tags.tagId WHERE todo_tags.todoId = ? ORDER BY tags.name",
+		itemTagsTableName, tagsTableName,
+	)
+	tags, err := repo.findNames(ctx, query, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("FindTagsByItemId: %v", err)
+	}
+	return tags, nil
+}
+
+// FindAllTags godoc
+//
+// Retrieves the names of all tags which are attached to at least one Item which is not in the trash, sorted by name.
+//
+// Returns nil and error on error.
+//
+// Returns the tag names and nil on success.
+func (repo *sqliteRepository) FindAllTags(ctx context.Context) ([]string, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindAllTags: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"SELECT DISTINCT tags.name FROM %s JOIN %s ON tags.id = todo_tags.tagId "+
+			"JOIN %s ON todos.id = todo_tags.todoId WHERE todos.%s ORDER BY tags.name",
+		itemTagsTableName, tagsTableName, tableName, notTrashedCondition,
+	)
+	tags, err := repo.findNames(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindAllTags: %v", err)
+	}
+	return tags, nil
+}
+
+// findNames godoc
+//
+// Runs a query which selects a single text column and collects the values.
+//
+// Returns nil and error on error.
+//
+// Returns the selected values and nil on success.
+func (repo *sqliteRepository) findNames(ctx context.Context, query string, args ...any) (names []string, err error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("findNames: %v", err)
+	}
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("findNames: Failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: findNames: Failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	names = []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("findNames: %v", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("findNames: %v", err)
+	}
+	return names, nil
+}
+
+// AddDependency godoc
+//
+// Records that an Item depends on another Item.
+//
+// Returns -1 and error on error.
+//
+// Returns number of added dependencies and nil on success. If the dependency is new the number will be 1, else 0.
+func (repo *sqliteRepository) AddDependency(ctx context.Context, dependency Dependency) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("AddDependency: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"INSERT OR IGNORE INTO %s (todoId, dependsOnId) VALUES (?, ?)", dependenciesTableName,
+	)
+	result, err := repo.conn().ExecContext(ctx, query, dependency.ItemId, dependency.DependsOnId)
+	if err != nil {
+		return -1, fmt.Errorf("AddDependency: %v", err)
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("AddDependency: %v", err)
+	}
+	return rowCount, nil
+}
+
+// RemoveDependency godoc
+//
+// Removes the dependency of an Item on another Item.
+//
+// Returns -1 and error on error.
+//
+// Returns number of removed dependencies and nil on success. If the dependency is removed the number will be 1,
+// else 0.
+func (repo *sqliteRepository) RemoveDependency(ctx context.Context, dependency Dependency) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("RemoveDependency: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE todoId = ? AND dependsOnId = ?", dependenciesTableName,
+	)
+	result, err := repo.conn().ExecContext(ctx, query, dependency.ItemId, dependency.DependsOnId)
+	if err != nil {
+		return -1, fmt.Errorf("RemoveDependency: %v", err)
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("RemoveDependency: %v", err)
+	}
+	return rowCount, nil
+}
+
+// FindAllDependencies godoc
+//
+// Retrieves every dependency between items which are not in the trash.
+//
+// Returns nil and error on error.
+//
+// Returns the dependencies and nil on success.
+func (repo *sqliteRepository) FindAllDependencies(ctx context.Context) ([]Dependency, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindAllDependencies: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"SELECT todoId, dependsOnId FROM %s WHERE todoId IN (SELECT id FROM %s WHERE %s) "+
+			"AND dependsOnId IN (SELECT id FROM %s WHERE %s) ORDER BY todoId, dependsOnId",
+		dependenciesTableName, tableName, notTrashedCondition, tableName, notTrashedCondition,
+	)
+	dependencies, err := repo.findDependencies(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindAllDependencies: %v", err)
+	}
+	return dependencies, nil
+}
+
+// FindItemDependencies godoc
+//
+// Retrieves the dependencies of an Item on other items and of other items on it, including the dependencies which
+// involve items in the trash.
+//
+// Returns nil and error on error.
+//
+// Returns the dependencies and nil on success.
+func (repo *sqliteRepository) FindItemDependencies(ctx context.Context, itemId int64) ([]Dependency, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindItemDependencies: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"SELECT todoId, dependsOnId FROM %s WHERE todoId = ? OR dependsOnId = ? ORDER BY todoId, dependsOnId",
+		dependenciesTableName,
+	)
+	dependencies, err := repo.findDependencies(ctx, query, itemId, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("FindItemDependencies: %v", err)
+	}
+	return dependencies, nil
+}
+
+// findDependencies godoc
+//
+// Runs a query which selects the todoId and dependsOnId columns of dependencies.
+//
+// Returns nil and error on error.
+//
+// Returns the dependencies and nil on success.
+func (repo *sqliteRepository) findDependencies(
+	ctx context.Context, query string, args ...any,
+) (dependencies []Dependency, err error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("findDependencies: %v", err)
+	}
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("findDependencies: Failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: findDependencies: Failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	dependencies = []Dependency{}
+	for rows.Next() {
+		var dependency Dependency
+		if err := rows.Scan(&dependency.ItemId, &dependency.DependsOnId); err != nil {
+			return nil, fmt.Errorf("findDependencies: %v", err)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("findDependencies: %v", err)
+	}
+	return dependencies, nil
+}
+
+// findIds godoc
+//
+// Runs a query which selects a single integer column and collects the values.
+//
+// Returns nil and error on error.
+//
+// Returns the selected values and nil on success.
+func (repo *sqliteRepository) findIds(ctx context.Context, query string, args ...any) (ids []int64, err error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("findIds: %v", err)
+	}
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("findIds: Failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: findIds: Failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	ids = []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("findIds: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("findIds: %v", err)
+	}
+	return ids, nil
+}
+
+// findItems godoc
+//
+// Runs a query which selects itemColumns and collects the items.
+//
+// Returns nil and error on error.
+//
+// Returns a slice containing Item instances and nil on success.
+func (repo *sqliteRepository) findItems(ctx context.Context, query string, args ...any) (items []Item, err error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("findItems: %v", err)
+	}
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("findItems: Failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: findItems: Failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	items = []Item{}
+	for rows.Next() {
+		item, err := NewItemFromRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("findItems: %v", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("findItems: %v", err)
+	}
+	return items, nil
+}
+
+// RestoreItems godoc
+//
+// Writes items, along with their tags and dependencies, back to the database with their IDs and timestamps. Missing
+// items are inserted and existing items are overwritten, without removing their subtasks. Projects, parents and
+// dependencies which no longer exist are dropped. The items are restored in a single transaction.
+//
+// Returns error on error.
+//
+// Returns nil on success.
+func (repo *sqliteRepository) RestoreItems(ctx context.Context, snapshots []ItemSnapshot) error {
+	if repo.db == nil {
+		return fmt.Errorf("RestoreItems: database connection is nil")
+	}
+	if repo.tx == nil {
+		return repo.WithTransaction(ctx, func(txRepository Repository) error {
+			return txRepository.RestoreItems(ctx, snapshots)
+		})
+	}
+
+	// Items are written before their parents and dependencies are linked, as they may be restored together
+	query := fmt.Sprintf(
+		"INSERT INTO %s (id, displayName, status, statusReason, dueAt, priority, projectId, recurrence, "+
+			"description, updatedAt, createdAt, deletedAt) "+
+			"VALUES (?, ?, ?, ?, ?, ?, (SELECT id FROM projects WHERE id = ?), ?, ?, ?, ?, ?) "+
+			"ON CONFLICT (id) DO UPDATE SET displayName = excluded.displayName, status = excluded.status, "+
+			"statusReason = excluded.statusReason, dueAt = excluded.dueAt, priority = excluded.priority, "+
+			"projectId = excluded.projectId, recurrence = excluded.recurrence, description = excluded.description, "+
+			"updatedAt = excluded.updatedAt, createdAt = excluded.createdAt, deletedAt = excluded.deletedAt",
+		tableName,
+	)
+	for _, snapshot := range snapshots {
+		dueAt := sql.NullInt64{Int64: snapshot.DueAt, Valid: snapshot.DueAt != 0}
+		deletedAt := sql.NullInt64{Int64: snapshot.DeletedAt, Valid: snapshot.DeletedAt != 0}
+		_, err := repo.tx.ExecContext(
+			ctx,
+			query,
+			snapshot.Id,
+			snapshot.Name,
+			snapshot.status(),
+			nullableString(snapshot.StatusReason),
+			dueAt,
+			snapshot.Priority,
+			snapshot.ProjectId,
+			nullableString(snapshot.Recurrence),
+			snapshot.Description,
+			snapshot.UpdatedAt,
+			snapshot.CreatedAt,
+			deletedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("RestoreItems: Failed to restore item with ID %d: %v", snapshot.Id, err)
+		}
+	}
+
+	for _, snapshot := range snapshots {
+		if err := repo.restoreLinks(ctx, snapshot); err != nil {
+			return fmt.Errorf("RestoreItems: Failed to restore item with ID %d: %v", snapshot.Id, err)
+		}
+	}
+	return nil
+}
+
+// restoreLinks godoc
+//
+// Restores the parent, tags and dependencies of an item which was written by RestoreItems. The tags and the items
+// which the item depends on are replaced, while the items which depended on it are added back.
+//
+// Returns error on error.
+func (repo *sqliteRepository) restoreLinks(ctx context.Context, snapshot ItemSnapshot) error {
+	query := fmt.Sprintf("UPDATE %s SET parentId = (SELECT id FROM %s WHERE id = ?) WHERE id = ?", tableName, tableName)
+	if _, err := repo.tx.ExecContext(ctx, query, snapshot.ParentId, snapshot.Id); err != nil {
+		return fmt.Errorf("restoreLinks: %v", err)
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s WHERE todoId = ?", itemTagsTableName)
+	if _, err := repo.tx.ExecContext(ctx, query, snapshot.Id); err != nil {
+		return fmt.Errorf("restoreLinks: %v", err)
+	}
+	for _, tag := range snapshot.Tags {
+		if _, err := repo.AttachTag(ctx, snapshot.Id, tag); err != nil {
+			return fmt.Errorf("restoreLinks: %v", err)
+		}
+	}
+
+	query = fmt.Sprintf("DELETE FROM %s WHERE todoId = ?", dependenciesTableName)
+	if _, err := repo.tx.ExecContext(ctx, query, snapshot.Id); err != nil {
+		return fmt.Errorf("restoreLinks: %v", err)
+	}
+	query = fmt.Sprintf(
+		"INSERT OR IGNORE INTO %s (todoId, dependsOnId) SELECT ?, id FROM %s WHERE id = ?",
+		dependenciesTableName, tableName,
+	)
+	for _, dependsOnId := range snapshot.DependsOn {
+		if _, err := repo.tx.ExecContext(ctx, query, snapshot.Id, dependsOnId); err != nil {
+			return fmt.Errorf("restoreLinks: %v", err)
+		}
+	}
+	query = fmt.Sprintf(
+		"INSERT OR IGNORE INTO %s (todoId, dependsOnId) SELECT id, ? FROM %s WHERE id = ?",
+		dependenciesTableName, tableName,
+	)
+	for _, dependentId := range snapshot.Dependents {
+		if _, err := repo.tx.ExecContext(ctx, query, snapshot.Id, dependentId); err != nil {
+			return fmt.Errorf("restoreLinks: %v", err)
+		}
+	}
+	return nil
+}
+
+// PersistJournalEntry godoc
+//
+// Adds a JournalEntry to the database.
+//
+// Returns -1 and an error on error.
+//
+// Returns ID (Greater than 0) of inserted entry and nil on success.
+func (repo *sqliteRepository) PersistJournalEntry(ctx context.Context, entry JournalEntry) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("PersistJournalEntry: database connection is nil")
+	}
+
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return -1, fmt.Errorf("PersistJournalEntry: %v", err)
+	}
+	query := fmt.Sprintf(
+		"INSERT INTO %s (summary, changes, isUndone, createdAt) VALUES (?, ?, ?, ?)", journalTableName,
+	)
+	result, err := repo.conn().ExecContext(
+		ctx, query, entry.Summary, string(changes), entry.IsUndone, entry.CreatedAt.Unix(),
+	)
+	if err != nil {
+		return -1, fmt.Errorf("PersistJournalEntry: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("PersistJournalEntry: %v", err)
+	}
+	return id, nil
+}
+
+// FindJournalEntries godoc
+//
+// Retrieves the latest journal entries, newest first. A limit of 0 retrieves every entry.
+//
+// Returns nil and error on error.
+//
+// Returns the entries and nil on success.
+func (repo *sqliteRepository) FindJournalEntries(ctx context.Context, limit int) ([]JournalEntry, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindJournalEntries: database connection is nil")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s ORDER BY id DESC", journalColumns, journalTableName)
+	var args []any
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	entries, err := repo.findJournalEntries(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("FindJournalEntries: %v", err)
+	}
+	return entries, nil
+}
+
+// FindLastJournalEntry godoc
+//
+// Retrieves the entry which undo applies to, the latest entry which is not undone, or the entry which redo applies
+// to when undone is true, the earliest undone entry.
+//
+// Returns nil and nil when there is no such entry.
+//
+// Returns nil and error on error.
+//
+// Returns the found entry and nil on success.
+func (repo *sqliteRepository) FindLastJournalEntry(ctx context.Context, undone bool) (*JournalEntry, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindLastJournalEntry: database connection is nil")
+	}
+
+	order := "DESC"
+	if undone {
+		order = "ASC"
+	}
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE isUndone = ? ORDER BY id %s LIMIT 1", journalColumns, journalTableName, order,
+	)
+	entries, err := repo.findJournalEntries(ctx, query, undone)
+	if err != nil {
+		return nil, fmt.Errorf("FindLastJournalEntry: %v", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+// SetJournalEntryUndone godoc
+//
+// Marks a journal entry as undone, or as applied again when undone is false.
+//
+// Returns -1 and error on error.
+//
+// Returns number of updated rows and nil on success.
+func (repo *sqliteRepository) SetJournalEntryUndone(ctx context.Context, id int64, undone bool) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("SetJournalEntryUndone: database connection is nil")
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET isUndone = ? WHERE id = ?", journalTableName)
+	rowCount, err := repo.execRowCount(ctx, query, undone, id)
+	if err != nil {
+		return -1, fmt.Errorf("SetJournalEntryUndone: %v", err)
+	}
+	return rowCount, nil
+}
+
+// DeleteUndoneJournalEntries godoc
+//
+// Deletes the undone journal entries, which cannot be redone once another operation is recorded.
+//
+// Returns -1 and error on error.
+//
+// Returns number of deleted rows and nil on success.
+func (repo *sqliteRepository) DeleteUndoneJournalEntries(ctx context.Context) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("DeleteUndoneJournalEntries: database connection is nil")
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE isUndone = 1", journalTableName)
+	rowCount, err := repo.execRowCount(ctx, query)
+	if err != nil {
+		return -1, fmt.Errorf("DeleteUndoneJournalEntries: %v", err)
+	}
+	return rowCount, nil
+}
+
+// TrimJournal godoc
+//
+// Deletes the journal entries older than the latest size entries.
+//
+// Returns -1 and error on error.
+//
+// Returns number of deleted rows and nil on success.
+func (repo *sqliteRepository) TrimJournal(ctx context.Context, size int) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("TrimJournal: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"DELETE FROM %s WHERE id NOT IN (SELECT id FROM %s ORDER BY id DESC LIMIT ?)",
+		journalTableName, journalTableName,
+	)
+	rowCount, err := repo.execRowCount(ctx, query, size)
+	if err != nil {
+		return -1, fmt.Errorf("TrimJournal: %v", err)
+	}
+	return rowCount, nil
+}
+
+// execRowCount godoc
+//
+// Runs a statement and returns the number of rows it changed.
+//
+// Returns -1 and error on error.
+func (repo *sqliteRepository) execRowCount(ctx context.Context, query string, args ...any) (int64, error) {
+	result, err := repo.conn().ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, fmt.Errorf("execRowCount: %v", err)
+	}
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("execRowCount: %v", err)
+	}
+	return rowCount, nil
+}
+
+// findJournalEntries godoc
+//
+// Runs a query which selects journalColumns and collects the entries.
+//
+// Returns nil and error on error.
+//
+// Returns the entries and nil on success.
+func (repo *sqliteRepository) findJournalEntries(
+	ctx context.Context, query string, args ...any,
+) (entries []JournalEntry, err error) {
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("findJournalEntries: %v", err)
+	}
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("findJournalEntries: Failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: findJournalEntries: Failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	entries = []JournalEntry{}
+	for rows.Next() {
+		entry, err := NewJournalEntryFromRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("findJournalEntries: %v", err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("findJournalEntries: %v", err)
+	}
+	return entries, nil
+}
+
+// PersistItemEvents godoc
+//
+// Inserts item events, ignoring their ID and item name.
+//
+// Returns -1 and error on error.
+//
+// Returns the number of inserted events and nil on success.
+func (repo *sqliteRepository) PersistItemEvents(ctx context.Context, events []ItemEvent) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("PersistItemEvents: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (itemId, field, oldValue, newValue, origin, createdAt) VALUES (?, ?, ?, ?, ?, ?)",
+		itemEventsTableName,
+	)
+	var rowCount int64
+	for _, event := range events {
+		count, err := repo.execRowCount(
+			ctx, query, event.ItemId, event.Field, event.OldValue, event.NewValue, event.Origin, event.CreatedAt.Unix(),
+		)
+		if err != nil {
+			return -1, fmt.Errorf("PersistItemEvents: Failed to insert event of item with ID %d: %v", event.ItemId, err)
+		}
+		rowCount += count
+	}
+	return rowCount, nil
+}
+
+// FindItemEvents godoc
+//
+// Retrieves the item events matching the filter, oldest first.
+//
+// Returns nil and error on error.
+//
+// Returns the events and nil on success.
+func (repo *sqliteRepository) FindItemEvents(
+	ctx context.Context, filter ItemEventFilter,
+) (events []ItemEvent, err error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindItemEvents: database connection is nil")
+	}
+
+	var conditions []string
+	var args []any
+	if filter.ItemId != 0 {
+		conditions = append(conditions, "item_events.itemId = ?")
+		args = append(args, filter.ItemId)
+	}
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, "item_events.createdAt >= ?")
+		args = append(args, filter.Since.Unix())
+	}
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s LEFT JOIN %s todos ON todos.id = item_events.itemId",
+		itemEventColumns, itemEventsTableName, tableName,
+	)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY item_events.createdAt, item_events.id"
+
+	rows, err := repo.conn().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("FindItemEvents: %v", err)
+	}
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("FindItemEvents: Failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: FindItemEvents: Failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	events = []ItemEvent{}
+	for rows.Next() {
+		event, err := NewItemEventFromRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("FindItemEvents: %v", err)
+		}
+		events = append(events, *event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("FindItemEvents: %v", err)
+	}
+	return events, nil
+}
+
+// SearchItems godoc
+//
+// Retrieves the items whose name or notes match a full-text query, using the FTS4 query syntax: terms, "phrases",
+// prefix*, AND, OR, NOT and parentheses. Items are ranked by relevance, then in the default item order.
+//
+// Returns nil and error wrapping ErrValidation when the query is malformed.
+//
+// Returns nil and error on error.
+//
+// Returns the matching items and nil on success.
+func (repo *sqliteRepository) SearchItems(ctx context.Context, query string) ([]SearchResult, error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("SearchItems: database connection is nil")
+	}
+
+	matches, err := repo.findSearchMatches(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("SearchItems: %w", err)
+	}
+
+	itemsQuery := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE id IN (SELECT rowid FROM %s WHERE %s MATCH ?) AND %s ORDER BY %s",
+		itemColumns, tableName, searchTableName, searchTableName, notTrashedCondition, itemOrder,
+	)
+	items, err := repo.findItems(ctx, itemsQuery, query)
+	if err != nil {
+		return nil, fmt.Errorf("SearchItems: %v", err)
+	}
+
+	results := make([]SearchResult, 0, len(items))
+	for _, item := range items {
+		result := matches[item.GetId()]
+		result.Item = item
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Rank > results[j].Rank
+	})
+	return results, nil
+}
+
+// findSearchMatches godoc
+//
+// Retrieves the highlights and the rank of the items matching a full-text query, by item ID.
+//
+// Returns nil and error wrapping ErrValidation when the query is malformed.
+//
+// Returns nil and error on error.
+//
+// Returns the search results without their item and nil on success.
+func (repo *sqliteRepository) findSearchMatches(
+	ctx context.Context,
+	query string,
+) (matches map[int64]SearchResult, err error) {
+	// The name is short enough to be highlighted in full
+	matchesQuery := fmt.Sprintf(
+		"SELECT rowid, highlight(%[1]s, 0, ?, ?), snippet(%[1]s, 1, ?, ?, '…', 12), bm25(%[1]s, ?, ?) "+
+			"FROM %[1]s WHERE %[1]s MATCH ?",
+		searchTableName,
+	)
+	args := append([]any{MatchStart, MatchEnd, MatchStart, MatchEnd}, searchColumnWeights...)
+	rows, err := repo.conn().QueryContext(ctx, matchesQuery, append(args, query)...)
+	if err != nil {
+		return nil, fmt.Errorf("findSearchMatches: %w", newSearchQueryError(query, err))
+	}
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil && err == nil {
+			err = fmt.Errorf("findSearchMatches: Failed to close rows: %w", closeErr)
+		}
+	}(rows)
+
+	matches = map[int64]SearchResult{}
+	for rows.Next() {
+		var itemId int64
+		var result SearchResult
+		var rank float64
+		if err := rows.Scan(&itemId, &result.NameHighlight, &result.NotesSnippet, &rank); err != nil {
+			return nil, fmt.Errorf("findSearchMatches: %v", err)
+		}
+		if !strings.Contains(result.NotesSnippet, MatchStart) {
+			result.NotesSnippet = ""
+		}
+		// bm25() is lower for more relevant rows
+		result.Rank = -rank
+		matches[itemId] = result
+	}
+	// SQLite reports a malformed query once the first row is read
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("findSearchMatches: %w", newSearchQueryError(query, err))
+	}
+	return matches, nil
+}
+
+// searchQueryErrorMessages godoc
+//
+// Parts of the messages of the errors which FTS5 returns for a malformed query, e.g. for an unknown column filter.
+var searchQueryErrorMessages = []string{"fts5: syntax error", "unterminated string", "no such column"}
+
+// newSearchQueryError godoc
+//
+// Returns a ValidationError when a full-text query failed because it is malformed, err otherwise.
+func newSearchQueryError(query string, err error) error {
+	for _, message := range searchQueryErrorMessages {
+		if strings.Contains(err.Error(), message) {
+			return newValidationError("search query", "'%s' is malformed", query)
+		}
+	}
+	return err
+}
+
+// filterDateColumns godoc
+//
+// Column compared by each date field of filter expressions.
+var filterDateColumns = map[FilterField]string{
+	FilterFieldDue:     "dueAt",
+	FilterFieldCreated: "createdAt",
+	FilterFieldUpdated: "updatedAt",
+}
+
+// filterOrderedColumns godoc
+//
+// Column compared by each numeric field of filter expressions.
+var filterOrderedColumns = map[FilterField]string{
+	FilterFieldPriority: "priority",
+	FilterFieldId:       "id",
+}
+
+// compileFilter godoc
+//
+// Compiles a filter expression into a condition of the items table. Every value is bound to a placeholder.
+//
+// Negations are applied to COALESCE(condition, 0) so that an item whose column is NULL, e.g. an item without a due
+// date, does not match due<today but matches not due<today.
+//
+// Returns an empty string, nil and error when the expression holds an unknown node.
+//
+// Returns the condition, the arguments of its placeholders and nil on success.
+func compileFilter(expression FilterExpression) (string, []any, error) {
+	switch node := expression.(type) {
+	case *FilterAnd, *FilterOr:
+		var left, right FilterExpression
+		keyword := "AND"
+		if and, ok := node.(*FilterAnd); ok {
+			left, right = and.Left, and.Right
+		} else {
+			or := node.(*FilterOr)
+			left, right, keyword = or.Left, or.Right, "OR"
+		}
+		leftCondition, leftArgs, err := compileFilter(left)
+		if err != nil {
+			return "", nil, fmt.Errorf("compileFilter: %v", err)
+		}
+		rightCondition, rightArgs, err := compileFilter(right)
+		if err != nil {
+			return "", nil, fmt.Errorf("compileFilter: %v", err)
+		}
+		return fmt.Sprintf("(%s %s %s)", leftCondition, keyword, rightCondition), append(leftArgs, rightArgs...), nil
+	case *FilterNot:
+		condition, args, err := compileFilter(node.Operand)
+		if err != nil {
+			return "", nil, fmt.Errorf("compileFilter: %v", err)
+		}
+		return negateCondition(condition), args, nil
+	case *FilterComparison:
+		condition, args, err := compileFilterComparison(node)
+		if err != nil {
+			return "", nil, fmt.Errorf("compileFilter: %v", err)
+		}
+		if node.Operator == FilterOperatorNotEqual {
+			return negateCondition(condition), args, nil
+		}
+		return condition, args, nil
+	}
+	return "", nil, fmt.Errorf("compileFilter: unknown filter expression %T", expression)
+}
+
+// negateCondition godoc
+//
+// Returns a condition matching the rows which do not match the condition, including rows for which it is NULL.
+func negateCondition(condition string) string {
+	return fmt.Sprintf("NOT COALESCE(%s, 0)", condition)
+}
+
+// compileFilterComparison godoc
+//
+// Compiles a comparison into a condition of the items table. FilterOperatorNotEqual compiles like
+// FilterOperatorEqual, the caller negates the condition.
+//
+// Returns an empty string, nil and error when the field is unknown.
+//
+// Returns the condition, the arguments of its placeholders and nil on success.
+func compileFilterComparison(comparison *FilterComparison) (string, []any, error) {
+	operator := string(comparison.Operator)
+	if comparison.Operator == FilterOperatorMatch || comparison.Operator == FilterOperatorNotEqual {
+		operator = string(FilterOperatorEqual)
+	}
+
+	switch comparison.Field {
+	case FilterFieldStatus:
+		switch comparison.Value {
+		case "open":
+			return "NOT " + isClosedCondition, nil, nil
+		case "closed":
+			return isClosedCondition, nil, nil
+		case "completed":
+			return "status = ?", []any{StatusDone.String()}, nil
+		}
+		return "status = ?", []any{comparison.Value}, nil
+	case FilterFieldTag:
+		return fmt.Sprintf(hasTagCondition, "?"), []any{comparison.Value}, nil
+	case FilterFieldProject:
+		if comparison.Value == nil {
+			return "projectId IS NULL", nil, nil
+		}
+		return "EXISTS (SELECT 1 FROM projects WHERE projects.id = todos.projectId " +
+			"AND projects.name = ? COLLATE NOCASE)", []any{comparison.Value}, nil
+	case FilterFieldName:
+		if comparison.Operator == FilterOperatorMatch {
+			return `displayName LIKE ? ESCAPE '\'`, []any{"%" + escapeLikePattern(comparison.Value.(string)) + "%"}, nil
+		}
+		return "displayName = ? COLLATE NOCASE", []any{comparison.Value}, nil
+	case FilterFieldParent:
+		if comparison.Value == nil {
+			return "parentId IS NULL", nil, nil
+		}
+		return "parentId = ?", []any{comparison.Value}, nil
+	}
+
+	if column, ok := filterOrderedColumns[comparison.Field]; ok {
+		return fmt.Sprintf("%s %s ?", column, operator), []any{comparison.Value}, nil
+	}
+	column, ok := filterDateColumns[comparison.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("compileFilterComparison: unknown filter field '%s'", comparison.Field)
+	}
+	if comparison.Value == nil {
+		return column + " IS NULL", nil, nil
+	}
+	value := comparison.Value.(time.Time).Unix()
+	if !comparison.Until.IsZero() {
+		return fmt.Sprintf("(%s >= ? AND %s < ?)", column, column), []any{value, comparison.Until.Unix()}, nil
+	}
+	return fmt.Sprintf("%s %s ?", column, operator), []any{value}, nil
+}
+
+// escapeLikePattern godoc
+//
+// Escapes the wildcards of a LIKE pattern with a backslash, so that the value is matched literally.
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
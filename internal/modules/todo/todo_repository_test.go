@@ -1,6 +1,8 @@
 package todo
 
 import (
+	"context"
+	"errors"
 	"github.com/rykeroc/todo-cli/internal/testutils"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -12,14 +14,16 @@ var testItems = []Item{
 	NewItem(
 		0,
 		"item",
-		0,
+		StatusTodo,
+		time.Time{},
 		time.Now(),
 		time.Now(),
 	),
 	NewItem(
 		0,
 		"another item",
-		0,
+		StatusTodo,
+		time.Time{},
 		time.Now(),
 		time.Now(),
 	),
@@ -37,7 +41,7 @@ func TestPersistItem_Success(t *testing.T) {
 
 	t.Run("should persist item successfully", func(t *testing.T) {
 		for _, item := range testItems {
-			insertedId, err := repository.PersistItem(item)
+			insertedId, err := repository.PersistItem(ctx, item)
 			assert.ErrorIs(t, nil, err)
 			assert.Greater(t, insertedId, int64(0))
 		}
@@ -49,13 +53,35 @@ func TestPersistItem_NoDatabase(t *testing.T) {
 
 	t.Run("should have error on no database", func(t *testing.T) {
 		for _, item := range testItems {
-			id, err := repository.PersistItem(item)
+			id, err := repository.PersistItem(ctx, item)
 			assert.Error(t, err)
 			assert.Equal(t, int64(-1), id)
 		}
 	})
 }
 
+func TestPersistItem_CancelledContext(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer afterEach(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	cancelledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	t.Run("should not run statements once the context is cancelled", func(t *testing.T) {
+		id, err := repository.PersistItem(cancelledCtx, testItems[0])
+		assert.ErrorContains(t, err, context.Canceled.Error())
+		assert.Equal(t, int64(-1), id)
+
+		_, err = repository.UpdateItemsById(cancelledCtx, testItems)
+		assert.ErrorContains(t, err, context.Canceled.Error())
+
+		items, err := repository.FindAllItems(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+	})
+}
+
 func TestFindAllItems_Success(t *testing.T) {
 	fixture := testutils.SetupTestFixture(t)
 	defer func(fixture *testutils.TestFixture) {
@@ -68,13 +94,13 @@ func TestFindAllItems_Success(t *testing.T) {
 
 	t.Run("should get all items successfully", func(t *testing.T) {
 		for _, item := range testItems {
-			_, err := repository.PersistItem(item)
+			_, err := repository.PersistItem(ctx, item)
 			if err != nil {
 				t.Fatalf("TestFindAllItems_Success: %v", err)
 			}
 		}
 
-		result, err := repository.FindAllItems()
+		result, err := repository.FindAllItems(ctx)
 		assert.NoError(t, err)
 		assert.Equal(t, len(result), 2)
 	})
@@ -84,7 +110,7 @@ func TestFindAllItems_NoDatabase(t *testing.T) {
 	repository := NewSqliteRepository(nil)
 
 	t.Run("should return error on no database", func(t *testing.T) {
-		items, err := repository.FindAllItems()
+		items, err := repository.FindAllItems(ctx)
 		assert.Error(t, err)
 		assert.Equal(t, 0, len(items))
 	})
@@ -101,7 +127,7 @@ func TestUpdateItemById_Success(t *testing.T) {
 	repository := NewSqliteRepository(fixture.Db)
 
 	t.Run("should update item successfully", func(t *testing.T) {
-		_, err := repository.PersistItem(testItems[0])
+		_, err := repository.PersistItem(ctx, testItems[0])
 		if err != nil {
 			t.Fatalf("TestUpdateItemById_Success: %v", err)
 		}
@@ -109,12 +135,13 @@ func TestUpdateItemById_Success(t *testing.T) {
 		itemToUpdate := NewItem(
 			1,
 			"new name",
-			0,
+			StatusTodo,
+			time.Time{},
 			time.Now(),
 			testItems[0].GetCreatedAt(),
 		)
 
-		affectedRows, err := repository.UpdateItemById(itemToUpdate)
+		affectedRows, err := repository.UpdateItemById(ctx, itemToUpdate)
 		assert.NoError(t, err)
 		assert.Equal(t, int64(1), affectedRows)
 	})
@@ -135,7 +162,7 @@ func TestUpdateItemById_ItemNotExist(t *testing.T) {
 		itemToUpdate.SetName("new name")
 		itemToUpdate.SetUpdatedAt(time.Now())
 
-		affectedRows, err := repository.UpdateItemById(itemToUpdate)
+		affectedRows, err := repository.UpdateItemById(ctx, itemToUpdate)
 		assert.NoError(t, err)
 		assert.Equal(t, int64(0), affectedRows)
 	})
@@ -145,7 +172,7 @@ func TestUpdateItemById_NoDatabase(t *testing.T) {
 	repository := NewSqliteRepository(nil)
 
 	t.Run("should return error on no database", func(t *testing.T) {
-		result, err := repository.UpdateItemById(testItems[0])
+		result, err := repository.UpdateItemById(ctx, testItems[0])
 		assert.Error(t, err)
 		assert.Equal(t, int64(-1), result)
 	})
@@ -162,12 +189,12 @@ func TestDeleteItem_Success(t *testing.T) {
 	repository := NewSqliteRepository(fixture.Db)
 
 	t.Run("should delete existing todo", func(t *testing.T) {
-		_, err := repository.PersistItem(testItems[0])
+		_, err := repository.PersistItem(ctx, testItems[0])
 		if err != nil {
 			t.Fatalf("TestSqliteRepository_UpdateItemById_Success: %v", err)
 		}
 
-		affectedRows, err := repository.DeleteItemById(1)
+		affectedRows, err := repository.DeleteItemById(ctx, 1)
 		assert.NoError(t, err)
 		assert.Equal(t, int64(1), affectedRows)
 	})
@@ -184,7 +211,7 @@ func TestDeleteItem_ItemNotExists(t *testing.T) {
 	repository := NewSqliteRepository(fixture.Db)
 
 	t.Run("should affect 0 rows", func(t *testing.T) {
-		affectedRows, err := repository.UpdateItemById(testItems[0])
+		affectedRows, err := repository.UpdateItemById(ctx, testItems[0])
 		assert.NoError(t, err)
 		assert.Equal(t, int64(0), affectedRows)
 	})
@@ -194,8 +221,992 @@ func TestDeleteItem_NoDatabase(t *testing.T) {
 	repository := NewSqliteRepository(nil)
 
 	t.Run("should return error on no database", func(t *testing.T) {
-		result, err := repository.DeleteItemById(1)
+		result, err := repository.DeleteItemById(ctx, 1)
 		assert.Error(t, err)
 		assert.Equal(t, int64(-1), result)
 	})
 }
+
+func TestWithTransaction(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestWithTransaction: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+	itemCount := func() int {
+		items, err := repository.FindAllItems(ctx)
+		assert.NoError(t, err)
+		return len(items)
+	}
+
+	t.Run("should roll back the statements when fn returns an error", func(t *testing.T) {
+		errAbort := errors.New("abort")
+		err := repository.WithTransaction(ctx, func(txRepository Repository) error {
+			if _, err := txRepository.PersistItem(ctx, testItems[0]); err != nil {
+				return err
+			}
+			// Statements of the transaction see its uncommitted changes
+			items, err := txRepository.FindAllItems(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, items, 1)
+			return errAbort
+		})
+
+		assert.ErrorIs(t, err, errAbort)
+		assert.Equal(t, 0, itemCount())
+	})
+
+	t.Run("should commit the statements when fn succeeds", func(t *testing.T) {
+		err := repository.WithTransaction(ctx, func(txRepository Repository) error {
+			if _, err := txRepository.PersistItem(ctx, testItems[0]); err != nil {
+				return err
+			}
+			// Nested transactions run in the outer transaction
+			return txRepository.WithTransaction(ctx, func(nested Repository) error {
+				_, err := nested.UpdateItemsById(ctx, []Item{testItems[0]})
+				return err
+			})
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, itemCount())
+	})
+}
+
+func TestFindItems_DueFilter(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestFindItems_DueFilter: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	nowTime := time.Now()
+	itemsToPersist := []Item{
+		NewItem(0, "overdue", StatusTodo, nowTime.Add(-time.Hour), nowTime, nowTime),
+		NewItem(0, "completed overdue", StatusDone, nowTime.Add(-time.Hour), nowTime, nowTime),
+		NewItem(0, "upcoming", StatusTodo, nowTime.Add(time.Hour), nowTime, nowTime),
+		NewItem(0, "no due date", StatusTodo, time.Time{}, nowTime, nowTime),
+	}
+	for _, item := range itemsToPersist {
+		if _, err := repository.PersistItem(ctx, item); err != nil {
+			t.Fatalf("TestFindItems_DueFilter: %v", err)
+		}
+	}
+
+	t.Run("should find open items due before a time", func(t *testing.T) {
+		result, err := repository.FindItems(ctx, ItemFilter{OpenOnly: true, DueBefore: nowTime})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "overdue", result[0].GetName())
+		assert.Equal(t, nowTime.Add(-time.Hour).Unix(), result[0].GetDueAt().Unix())
+	})
+
+	t.Run("should find items due within a range", func(t *testing.T) {
+		result, err := repository.FindItems(ctx, ItemFilter{DueFrom: nowTime, DueBefore: nowTime.Add(2 * time.Hour)})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "upcoming", result[0].GetName())
+	})
+
+	t.Run("should keep items without due date when not filtering on due", func(t *testing.T) {
+		result, err := repository.FindItems(ctx, ItemFilter{})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 4)
+	})
+}
+
+func TestFindAllItems_Order(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestFindAllItems_Order: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	nowTime := time.Now()
+	newItem := func(name string, status Status, priority Priority, dueAt time.Time) Item {
+		item := NewItem(0, name, status, dueAt, nowTime, nowTime)
+		item.SetPriority(priority)
+		return item
+	}
+	itemsToPersist := []Item{
+		newItem("completed urgent", StatusDone, PriorityUrgent, time.Time{}),
+		newItem("low", StatusTodo, PriorityLow, nowTime),
+		newItem("high without due", StatusTodo, PriorityHigh, time.Time{}),
+		newItem("high due later", StatusTodo, PriorityHigh, nowTime.Add(2*time.Hour)),
+		newItem("high due soon", StatusTodo, PriorityHigh, nowTime.Add(time.Hour)),
+	}
+	for _, item := range itemsToPersist {
+		if _, err := repository.PersistItem(ctx, item); err != nil {
+			t.Fatalf("TestFindAllItems_Order: %v", err)
+		}
+	}
+
+	t.Run("should order by completion, priority and due date", func(t *testing.T) {
+		result, err := repository.FindAllItems(ctx)
+		assert.NoError(t, err)
+
+		var names []string
+		for _, item := range result {
+			names = append(names, item.GetName())
+		}
+		assert.Equal(
+			t,
+			[]string{"high due soon", "high due later", "high without due", "low", "completed urgent"},
+			names,
+		)
+		assert.Equal(t, PriorityHigh, result[0].GetPriority())
+	})
+
+	t.Run("should order by the sort order of the filter", func(t *testing.T) {
+		testCases := map[string][]string{
+			"due":               {"low", "high due soon", "high due later", "high without due", "completed urgent"},
+			"name":              {"high due later", "high due soon", "high without due", "low", "completed urgent"},
+			"due:desc":          {"high due later", "high due soon", "low", "high without due", "completed urgent"},
+			"priority:asc,name": {"low", "high due later", "high due soon", "high without due", "completed urgent"},
+			"id:desc":           {"high due soon", "high due later", "high without due", "low", "completed urgent"},
+		}
+		for spec, expectedNames := range testCases {
+			order, err := ParseSortKeys(spec)
+			assert.NoError(t, err)
+
+			result, err := repository.FindItems(ctx, ItemFilter{Order: order})
+			assert.NoError(t, err)
+
+			var names []string
+			for _, item := range result {
+				names = append(names, item.GetName())
+			}
+			assert.Equal(t, expectedNames, names, spec)
+		}
+	})
+}
+
+func TestTags(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestTags: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	for _, item := range testItems {
+		if _, err := repository.PersistItem(ctx, item); err != nil {
+			t.Fatalf("TestTags: %v", err)
+		}
+	}
+
+	t.Run("should attach tags", func(t *testing.T) {
+		for _, tag := range []string{"urgent", "backend"} {
+			affectedRows, err := repository.AttachTag(ctx, 1, tag)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), affectedRows)
+		}
+		affectedRows, err := repository.AttachTag(ctx, 2, "backend")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), affectedRows)
+
+		tags, err := repository.FindTagsByItemId(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"backend", "urgent"}, tags)
+
+		item, err := repository.FindItemById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"backend", "urgent"}, item.GetTags())
+	})
+
+	t.Run("should not attach the same tag twice", func(t *testing.T) {
+		affectedRows, err := repository.AttachTag(ctx, 1, "urgent")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), affectedRows)
+	})
+
+	t.Run("should return error when item does not exist", func(t *testing.T) {
+		affectedRows, err := repository.AttachTag(ctx, 100, "urgent")
+		assert.Error(t, err)
+		assert.Equal(t, int64(-1), affectedRows)
+	})
+
+	t.Run("should filter items by tags", func(t *testing.T) {
+		result, err := repository.FindItems(ctx, ItemFilter{Tags: []string{"backend"}})
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+
+		result, err = repository.FindItems(ctx, ItemFilter{Tags: []string{"backend", "urgent"}})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+
+		result, err = repository.FindItems(ctx, ItemFilter{Tags: []string{"backend"}, ExcludedTags: []string{"urgent"}})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, int64(2), result[0].GetId())
+	})
+
+	t.Run("should detach tags", func(t *testing.T) {
+		affectedRows, err := repository.DetachTag(ctx, 1, "urgent")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), affectedRows)
+
+		affectedRows, err = repository.DetachTag(ctx, 1, "urgent")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), affectedRows)
+
+		tags, err := repository.FindAllTags(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"backend"}, tags)
+	})
+
+	t.Run("should detach tags when item is deleted", func(t *testing.T) {
+		_, err := repository.DeleteItemById(ctx, 1)
+		assert.NoError(t, err)
+
+		tags, err := repository.FindTagsByItemId(ctx, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, tags)
+	})
+}
+
+func TestTags_NoDatabase(t *testing.T) {
+	repository := NewSqliteRepository(nil)
+
+	t.Run("should return error on no database", func(t *testing.T) {
+		affectedRows, err := repository.AttachTag(ctx, 1, "tag")
+		assert.Error(t, err)
+		assert.Equal(t, int64(-1), affectedRows)
+
+		affectedRows, err = repository.DetachTag(ctx, 1, "tag")
+		assert.Error(t, err)
+		assert.Equal(t, int64(-1), affectedRows)
+
+		tags, err := repository.FindAllTags(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, tags)
+	})
+}
+
+func TestFindItems_ProjectFilter(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestFindItems_ProjectFilter: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	_, err := fixture.Db.Exec(
+		"INSERT INTO projects (name, archivedAt, updatedAt, createdAt) VALUES ('backend', NULL, 0, 0), ('old', 1, 0, 0)",
+	)
+	if err != nil {
+		t.Fatalf("TestFindItems_ProjectFilter: %v", err)
+	}
+	for _, projectId := range []int64{1, 2, 0} {
+		item := NewItem(0, "item", StatusTodo, time.Time{}, time.Now(), time.Now())
+		item.SetProjectId(projectId)
+		if _, err := repository.PersistItem(ctx, item); err != nil {
+			t.Fatalf("TestFindItems_ProjectFilter: %v", err)
+		}
+	}
+
+	t.Run("should read the project of an item", func(t *testing.T) {
+		item, err := repository.FindItemById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), item.GetProjectId())
+		assert.Equal(t, "backend", item.GetProjectName())
+	})
+
+	t.Run("should filter items by project", func(t *testing.T) {
+		result, err := repository.FindItems(ctx, ItemFilter{ProjectId: 1})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, int64(1), result[0].GetId())
+
+		result, err = repository.FindItems(ctx, ItemFilter{ProjectId: NoProjectId})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, int64(3), result[0].GetId())
+	})
+
+	t.Run("should hide items of archived projects", func(t *testing.T) {
+		result, err := repository.FindItems(ctx, ItemFilter{HideArchivedProjects: true})
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+}
+
+func TestFindItems_Expression(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestFindItems_Expression: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	_, err := fixture.Db.Exec("INSERT INTO projects (name, updatedAt, createdAt) VALUES ('Backend', 0, 0)")
+	if err != nil {
+		t.Fatalf("TestFindItems_Expression: %v", err)
+	}
+	drafts := []struct {
+		name      string
+		priority  Priority
+		dueAt     time.Time
+		projectId int64
+		status    Status
+	}{
+		{"fix login", PriorityHigh, testNow.Add(2 * time.Hour), 1, StatusTodo},
+		{"write docs 100%", PriorityLow, testNow.AddDate(0, 0, 10), 0, StatusInProgress},
+		{"ship release", PriorityUrgent, time.Time{}, 1, StatusDone},
+		{"plan sprint", PriorityNone, testNow.AddDate(0, 0, -1), 0, StatusTodo},
+	}
+	for _, draft := range drafts {
+		item := NewItem(0, draft.name, draft.status, draft.dueAt, testNow, testNow)
+		item.SetPriority(draft.priority)
+		item.SetProjectId(draft.projectId)
+		if _, err := repository.PersistItem(ctx, item); err != nil {
+			t.Fatalf("TestFindItems_Expression: %v", err)
+		}
+	}
+	if _, err := repository.AttachTag(ctx, 2, "backend"); err != nil {
+		t.Fatalf("TestFindItems_Expression: %v", err)
+	}
+	if _, err := repository.AddDependency(ctx, Dependency{ItemId: 4, DependsOnId: 1}); err != nil {
+		t.Fatalf("TestFindItems_Expression: %v", err)
+	}
+
+	tests := []struct {
+		expression string
+		ids        []int64
+	}{
+		{"status:open", []int64{1, 2, 4}},
+		{"status:closed", []int64{3}},
+		{"status:done", []int64{3}},
+		{"status:completed", []int64{3}},
+		{"status:in-progress", []int64{2}},
+		{"status:blocked", nil},
+		{"status:todo", []int64{1, 4}},
+		{"status:open and (tag:backend or priority>=high) and due<+7d", []int64{1}},
+		{"status:open and (tag:backend or priority>=high)", []int64{1, 2}},
+		{"due:today", []int64{1}},
+		{"due<today", []int64{4}},
+		{"due!=today", []int64{2, 3, 4}},
+		{"not due<+7d", []int64{2, 3}},
+		{"due:none", []int64{3}},
+		{"project:backend", []int64{1, 3}},
+		{"project:none", []int64{2, 4}},
+		{`name:"100%"`, []int64{2}},
+		{"name:LOGIN", []int64{1}},
+		{"name=login", nil},
+		{`name="Fix Login"`, []int64{1}},
+		{"id>=2 and id<4", []int64{2, 3}},
+		{"parent:none and priority<=low", []int64{2, 4}},
+	}
+	for _, test := range tests {
+		expression, err := ParseFilter(test.expression, filterDates)
+		if err != nil {
+			t.Fatalf("TestFindItems_Expression: %v", err)
+		}
+
+		result, err := repository.FindItems(ctx, ItemFilter{Expression: expression})
+
+		assert.NoError(t, err, test.expression)
+		var ids []int64
+		for _, item := range result {
+			ids = append(ids, item.GetId())
+		}
+		assert.ElementsMatch(t, test.ids, ids, test.expression)
+	}
+}
+
+func TestOrderByKeys(t *testing.T) {
+	testCases := map[string]string{
+		"":                  itemOrder,
+		"priority":          itemOrder,
+		"due":               "status IN ('done', 'cancelled'), dueAt IS NULL, dueAt, priority DESC, id",
+		"created":           "status IN ('done', 'cancelled'), createdAt DESC, id DESC",
+		"updated:asc":       "status IN ('done', 'cancelled'), updatedAt, id",
+		"name":              "status IN ('done', 'cancelled'), displayName COLLATE NOCASE, id",
+		"due:desc,priority": "status IN ('done', 'cancelled'), dueAt IS NULL, dueAt DESC, priority DESC, id",
+		"name:desc,id:desc": "status IN ('done', 'cancelled'), displayName COLLATE NOCASE DESC, id DESC",
+	}
+	for spec, expected := range testCases {
+		keys, err := ParseSortKeys(spec)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, orderByKeys(keys), spec)
+	}
+}
+
+func TestCompileFilter(t *testing.T) {
+	t.Run("should bind every value to a placeholder", func(t *testing.T) {
+		parsed, err := ParseFilter(`name:"50%_" or not (priority>=high and id!=3)`, filterDates)
+		assert.NoError(t, err)
+
+		condition, args, err := compileFilter(parsed)
+
+		assert.NoError(t, err)
+		assert.Equal(
+			t,
+			`(displayName LIKE ? ESCAPE '\' OR NOT COALESCE((priority >= ? AND NOT COALESCE(id = ?, 0)), 0))`,
+			condition,
+		)
+		assert.Equal(t, []any{`%50\%\_%`, int64(PriorityHigh), int64(3)}, args)
+	})
+}
+
+func TestSubtasks(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestSubtasks: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	// Item 1 has subtask 2, which has subtask 3. Item 4 is unrelated.
+	for _, parentId := range []int64{0, 1, 2, 0} {
+		item := NewItem(0, "item", StatusTodo, time.Time{}, time.Now(), time.Now())
+		item.SetParentId(parentId)
+		if _, err := repository.PersistItem(ctx, item); err != nil {
+			t.Fatalf("TestSubtasks: %v", err)
+		}
+	}
+
+	t.Run("should read the parent of an item", func(t *testing.T) {
+		item, err := repository.FindItemById(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), item.GetParentId())
+	})
+
+	t.Run("should find subtasks at any depth", func(t *testing.T) {
+		result, err := repository.FindDescendantItems(ctx, 1)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, int64(2), result[0].GetId())
+		assert.Equal(t, int64(3), result[1].GetId())
+
+		result, err = repository.FindDescendantItems(ctx, 4)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("should find the direct subtasks of an item", func(t *testing.T) {
+		result, err := repository.FindItems(ctx, ItemFilter{ParentId: 1})
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, int64(2), result[0].GetId())
+	})
+
+	t.Run("should find the lineage of an item", func(t *testing.T) {
+		ids, err := repository.FindLineageIds(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{3, 2, 1}, ids)
+
+		ids, err = repository.FindLineageIds(ctx, 100)
+		assert.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("should update several items", func(t *testing.T) {
+		items, err := repository.FindDescendantItems(ctx, 1)
+		assert.NoError(t, err)
+		for _, item := range items {
+			item.SetStatus(StatusDone)
+		}
+
+		rowCount, err := repository.UpdateItemsById(ctx, items)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), rowCount)
+
+		result, err := repository.FindItems(ctx, ItemFilter{OpenOnly: true})
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("should delete subtasks along with their parent", func(t *testing.T) {
+		rowCount, err := repository.DeleteItemById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rowCount)
+
+		result, err := repository.FindAllItems(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, int64(4), result[0].GetId())
+	})
+}
+
+func TestTrash(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestTrash: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	// Items 1 and 2 were removed a day and an hour ago, item 2 depends on item 1 and item 3 on item 2
+	now := time.Now()
+	for _, deletedAt := range []time.Time{now.Add(-24 * time.Hour), now.Add(-time.Hour), {}} {
+		item := NewItem(0, "item", StatusTodo, time.Time{}, now, now)
+		item.SetDeletedAt(deletedAt)
+		id, err := repository.PersistItem(ctx, item)
+		if err != nil {
+			t.Fatalf("TestTrash: %v", err)
+		}
+		if _, err := repository.AttachTag(ctx, id, "home"); err != nil {
+			t.Fatalf("TestTrash: %v", err)
+		}
+	}
+	for _, dependency := range []Dependency{{ItemId: 2, DependsOnId: 1}, {ItemId: 3, DependsOnId: 2}} {
+		if _, err := repository.AddDependency(ctx, dependency); err != nil {
+			t.Fatalf("TestTrash: %v", err)
+		}
+	}
+
+	t.Run("should exclude items in the trash", func(t *testing.T) {
+		tags, err := repository.FindAllTags(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"home"}, tags)
+
+		items, err := repository.FindAllItems(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, int64(3), items[0].GetId())
+
+		item, err := repository.FindItemById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Nil(t, item)
+
+		dependencies, err := repository.FindAllDependencies(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, dependencies)
+
+		ready, err := repository.FindItems(ctx, ItemFilter{ReadyOnly: true})
+		assert.NoError(t, err)
+		assert.Len(t, ready, 1)
+	})
+
+	t.Run("should find the items in the trash, most recently removed first", func(t *testing.T) {
+		items, err := repository.FindItems(ctx, ItemFilter{Trashed: true})
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, int64(2), items[0].GetId())
+		assert.Equal(t, now.Unix(), items[0].GetDeletedAt().Add(time.Hour).Unix())
+
+		item, err := repository.FindTrashedItemById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"home"}, item.GetTags())
+
+		item, err = repository.FindTrashedItemById(ctx, 3)
+		assert.NoError(t, err)
+		assert.Nil(t, item)
+	})
+
+	t.Run("should find the dependencies of an item in the trash", func(t *testing.T) {
+		dependencies, err := repository.FindItemDependencies(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []Dependency{{ItemId: 2, DependsOnId: 1}, {ItemId: 3, DependsOnId: 2}}, dependencies)
+	})
+
+	t.Run("should delete the items removed before a time", func(t *testing.T) {
+		rowCount, err := repository.DeleteTrashedItems(ctx, now.Add(-2*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rowCount)
+
+		rowCount, err = repository.DeleteTrashedItems(ctx, time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rowCount)
+
+		items, err := repository.FindItems(ctx, ItemFilter{Trashed: true})
+		assert.NoError(t, err)
+		assert.Empty(t, items)
+		items, err = repository.FindAllItems(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestDependencies: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	for range 3 {
+		item := NewItem(0, "item", StatusTodo, time.Time{}, time.Now(), time.Now())
+		if _, err := repository.PersistItem(ctx, item); err != nil {
+			t.Fatalf("TestDependencies: %v", err)
+		}
+	}
+
+	t.Run("should add dependencies once", func(t *testing.T) {
+		rowCount, err := repository.AddDependency(ctx, Dependency{ItemId: 3, DependsOnId: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rowCount)
+
+		rowCount, err = repository.AddDependency(ctx, Dependency{ItemId: 3, DependsOnId: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), rowCount)
+
+		// Self dependencies violate a check and are ignored
+		rowCount, err = repository.AddDependency(ctx, Dependency{ItemId: 3, DependsOnId: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), rowCount)
+
+		dependencies, err := repository.FindAllDependencies(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []Dependency{{ItemId: 3, DependsOnId: 1}}, dependencies)
+	})
+
+	t.Run("should block items until their dependencies are completed", func(t *testing.T) {
+		item, err := repository.FindItemById(ctx, 3)
+		assert.NoError(t, err)
+		assert.True(t, item.GetIsBlocked())
+
+		result, err := repository.FindItems(ctx, ItemFilter{ReadyOnly: true})
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+
+		dependency, _ := repository.FindItemById(ctx, 1)
+		dependency.SetStatus(StatusDone)
+		_, err = repository.UpdateItemById(ctx, dependency)
+		assert.NoError(t, err)
+
+		item, err = repository.FindItemById(ctx, 3)
+		assert.NoError(t, err)
+		assert.False(t, item.GetIsBlocked())
+
+		result, err = repository.FindItems(ctx, ItemFilter{ReadyOnly: true})
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, int64(2), result[0].GetId())
+		assert.Equal(t, int64(3), result[1].GetId())
+	})
+
+	t.Run("should remove dependencies", func(t *testing.T) {
+		rowCount, err := repository.RemoveDependency(ctx, Dependency{ItemId: 3, DependsOnId: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rowCount)
+
+		rowCount, err = repository.RemoveDependency(ctx, Dependency{ItemId: 3, DependsOnId: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), rowCount)
+	})
+}
+
+func TestRestoreItems(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestRestoreItems: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	item := NewItem(0, "kept", StatusTodo, time.Time{}, time.Now(), time.Now())
+	if _, err := repository.PersistItem(ctx, item); err != nil {
+		t.Fatalf("TestRestoreItems: %v", err)
+	}
+	createdAt := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.Local)
+	snapshots := []ItemSnapshot{
+		// The subtask is restored before its parent
+		{Id: 6, Name: "subtask", ParentId: 5, UpdatedAt: createdAt.Unix(), CreatedAt: createdAt.Unix()},
+		{
+			Id: 5, Name: "parent", Tags: []string{"home"}, DependsOn: []int64{1}, Dependents: []int64{1, 99},
+			ProjectId: 42, DueAt: createdAt.Unix(), UpdatedAt: createdAt.Unix(), CreatedAt: createdAt.Unix(),
+		},
+	}
+
+	t.Run("should insert the items with their IDs and timestamps", func(t *testing.T) {
+		err := repository.RestoreItems(ctx, snapshots)
+
+		assert.NoError(t, err)
+		subtask, err := repository.FindItemById(ctx, 6)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), subtask.GetParentId())
+		assert.Equal(t, createdAt, subtask.GetCreatedAt())
+		parent, err := repository.FindItemById(ctx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"home"}, parent.GetTags())
+		assert.Equal(t, createdAt, parent.GetDueAt())
+		// Missing projects and items are dropped
+		assert.Equal(t, int64(0), parent.GetProjectId())
+		dependencies, err := repository.FindAllDependencies(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []Dependency{{ItemId: 1, DependsOnId: 5}, {ItemId: 5, DependsOnId: 1}}, dependencies)
+	})
+
+	t.Run("should overwrite existing items without removing their subtasks", func(t *testing.T) {
+		err := repository.RestoreItems(ctx, []ItemSnapshot{
+			{Id: 5, Name: "renamed", Status: StatusDone, UpdatedAt: createdAt.Unix(), CreatedAt: createdAt.Unix()},
+		})
+
+		assert.NoError(t, err)
+		parent, err := repository.FindItemById(ctx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, "renamed", parent.GetName())
+		assert.Equal(t, StatusDone, parent.GetStatus())
+		assert.Empty(t, parent.GetTags())
+		assert.True(t, parent.GetDueAt().IsZero())
+		subtask, err := repository.FindItemById(ctx, 6)
+		assert.NoError(t, err)
+		assert.NotNil(t, subtask)
+		dependencies, err := repository.FindAllDependencies(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []Dependency{{ItemId: 1, DependsOnId: 5}}, dependencies)
+	})
+
+	t.Run("should restore the status of the item and of entries recorded before items had one", func(t *testing.T) {
+		err := repository.RestoreItems(ctx, []ItemSnapshot{
+			{Id: 5, Name: "parent", Status: StatusWaiting, StatusReason: "vendor", CreatedAt: createdAt.Unix()},
+			{Id: 6, Name: "subtask", IsCompleted: 1, ParentId: 5, CreatedAt: createdAt.Unix()},
+		})
+
+		assert.NoError(t, err)
+		parent, err := repository.FindItemById(ctx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusWaiting, parent.GetStatus())
+		assert.Equal(t, "vendor", parent.GetStatusReason())
+		subtask, err := repository.FindItemById(ctx, 6)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusDone, subtask.GetStatus())
+	})
+}
+
+func TestJournal(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestJournal: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	createdAt := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.Local)
+	for _, summary := range []string{"create item 1", "update item 1", "remove item 1"} {
+		entry := JournalEntry{
+			Summary:   summary,
+			Changes:   []JournalChange{{ItemId: 1, After: &ItemSnapshot{Id: 1, Name: "item", Tags: []string{"a"}}}},
+			CreatedAt: createdAt,
+		}
+		if _, err := repository.PersistJournalEntry(ctx, entry); err != nil {
+			t.Fatalf("TestJournal: %v", err)
+		}
+	}
+
+	t.Run("should find the entries newest first", func(t *testing.T) {
+		entries, err := repository.FindJournalEntries(ctx, 2)
+
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "remove item 1", entries[0].Summary)
+		assert.Equal(t, createdAt, entries[0].CreatedAt)
+		assert.Equal(t, []string{"a"}, entries[0].Changes[0].After.Tags)
+		assert.Nil(t, entries[0].Changes[0].Before)
+	})
+
+	t.Run("should find the entries which undo and redo apply to", func(t *testing.T) {
+		for _, id := range []int64{3, 2} {
+			rowCount, err := repository.SetJournalEntryUndone(ctx, id, true)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), rowCount)
+		}
+
+		last, err := repository.FindLastJournalEntry(ctx, false)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), last.Id)
+		undone, err := repository.FindLastJournalEntry(ctx, true)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), undone.Id)
+	})
+
+	t.Run("should delete the undone entries", func(t *testing.T) {
+		rowCount, err := repository.DeleteUndoneJournalEntries(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), rowCount)
+
+		undone, err := repository.FindLastJournalEntry(ctx, true)
+		assert.NoError(t, err)
+		assert.Nil(t, undone)
+	})
+
+	t.Run("should keep the latest entries", func(t *testing.T) {
+		_, err := repository.PersistJournalEntry(ctx, JournalEntry{Summary: "tag item 1", CreatedAt: createdAt})
+		assert.NoError(t, err)
+
+		rowCount, err := repository.TrimJournal(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rowCount)
+
+		entries, err := repository.FindJournalEntries(ctx, 0)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, "tag item 1", entries[0].Summary)
+	})
+}
+
+func TestItemEvents(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestItemEvents: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	item := NewItem(0, "item", StatusTodo, time.Time{}, time.Now(), time.Now())
+	if _, err := repository.PersistItem(ctx, item); err != nil {
+		t.Fatalf("TestItemEvents: %v", err)
+	}
+	createdAt := time.Date(2026, time.January, 2, 3, 4, 5, 0, time.Local)
+	events := []ItemEvent{
+		{ItemId: 1, Field: ItemEventFieldName, NewValue: "item", Origin: "create", CreatedAt: createdAt},
+		{ItemId: 2, Field: ItemEventFieldName, OldValue: "gone", Origin: "remove", CreatedAt: createdAt.Add(time.Hour)},
+		{ItemId: 1, Field: ItemEventFieldPriority, NewValue: "high", CreatedAt: createdAt.Add(2 * time.Hour)},
+	}
+
+	t.Run("should persist the events", func(t *testing.T) {
+		rowCount, err := repository.PersistItemEvents(ctx, events)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), rowCount)
+	})
+
+	t.Run("should find the events of an item oldest first with its name", func(t *testing.T) {
+		found, err := repository.FindItemEvents(ctx, ItemEventFilter{ItemId: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []ItemEvent{
+			{Id: 1, ItemId: 1, ItemName: "item", Field: ItemEventFieldName, NewValue: "item", Origin: "create",
+				CreatedAt: createdAt},
+			{Id: 3, ItemId: 1, ItemName: "item", Field: ItemEventFieldPriority, NewValue: "high",
+				CreatedAt: createdAt.Add(2 * time.Hour)},
+		}, found)
+	})
+
+	t.Run("should find the events since a time, including deleted items", func(t *testing.T) {
+		found, err := repository.FindItemEvents(ctx, ItemEventFilter{Since: createdAt.Add(time.Hour)})
+
+		assert.NoError(t, err)
+		assert.Len(t, found, 2)
+		assert.Equal(t, int64(2), found[0].ItemId)
+		assert.Equal(t, "", found[0].ItemName)
+		assert.Equal(t, "gone", found[0].OldValue)
+	})
+
+	t.Run("should return error when there is no database", func(t *testing.T) {
+		repository := NewSqliteRepository(nil)
+
+		_, err := repository.PersistItemEvents(ctx, events)
+		assert.Error(t, err)
+		_, err = repository.FindItemEvents(ctx, ItemEventFilter{})
+		assert.Error(t, err)
+	})
+}
+
+func TestSearchItems(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestSearchItems: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	nowTime := time.Now()
+	newItem := func(name string, description string) Item {
+		item := NewItem(0, name, StatusTodo, time.Time{}, nowTime, nowTime)
+		item.SetDescription(description)
+		return item
+	}
+	itemsToPersist := []Item{
+		newItem("Write release notes", "Mention the staging deploy"),
+		newItem("Deploy to staging", ""),
+		newItem("Deploy to production", "After the staging checks"),
+	}
+	for _, item := range itemsToPersist {
+		if _, err := repository.PersistItem(ctx, item); err != nil {
+			t.Fatalf("TestSearchItems: %v", err)
+		}
+	}
+	searchIds := func(query string) []int64 {
+		results, err := repository.SearchItems(ctx, query)
+		assert.NoError(t, err)
+		var ids []int64
+		for _, result := range results {
+			ids = append(ids, result.Item.GetId())
+		}
+		return ids
+	}
+
+	t.Run("should rank matches in the name above matches in the notes", func(t *testing.T) {
+		results, err := repository.SearchItems(ctx, "deploy AND staging")
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, int64(2), results[0].Item.GetId())
+		assert.Greater(t, results[0].Rank, results[1].Rank)
+		assert.GreaterOrEqual(t, results[1].Rank, results[2].Rank)
+	})
+
+	t.Run("should highlight the matches", func(t *testing.T) {
+		results, err := repository.SearchItems(ctx, "staging")
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, "Deploy to "+MatchStart+"staging"+MatchEnd, results[0].NameHighlight)
+		assert.Empty(t, results[0].NotesSnippet)
+		for _, result := range results[1:] {
+			assert.Contains(t, result.NotesSnippet, MatchStart+"staging"+MatchEnd)
+		}
+	})
+
+	t.Run("should support the query syntax", func(t *testing.T) {
+		assert.ElementsMatch(t, []int64{1, 2, 3}, searchIds("deploy OR release"))
+		assert.Equal(t, []int64{2}, searchIds("deploy NOT production NOT notes"))
+		assert.Equal(t, []int64{3}, searchIds(`"to production"`))
+		assert.Equal(t, []int64{1}, searchIds("rel*"))
+		assert.Empty(t, searchIds("kubernetes"))
+	})
+
+	t.Run("should index updated items and forget deleted items", func(t *testing.T) {
+		item, err := repository.FindItemById(ctx, 2)
+		assert.NoError(t, err)
+		item.SetName("Deploy to kubernetes")
+		_, err = repository.UpdateItemById(ctx, item)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2}, searchIds("kubernetes"))
+
+		_, err = repository.DeleteItemById(ctx, 2)
+		assert.NoError(t, err)
+		assert.Empty(t, searchIds("kubernetes"))
+	})
+
+	t.Run("should return ErrValidation when the query is malformed", func(t *testing.T) {
+		_, err := repository.SearchItems(ctx, `"unterminated AND (`)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
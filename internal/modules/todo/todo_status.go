@@ -0,0 +1,103 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+)
+
+// Reopen godoc
+//
+// Move an item by its ID back to the todo status, e.g. after it was completed, cancelled or blocked.
+//
+// Returns nil and error wrapping ErrInvalidID when the ID is not positive, wrapping ErrNotFound when the item does
+// not exist, or wrapping ErrConflict when the item is already todo.
+//
+// Returns nil and error on error.
+//
+// Returns the reopened item and nil on success.
+func (uc *defaultUseCase) Reopen(ctx context.Context, itemId int64) (Item, error) {
+	return uc.changeStatus(ctx, "reopen", itemId, StatusTodo, "")
+}
+
+// Start godoc
+//
+// Move an item by its ID to the in-progress status.
+//
+// Returns nil and error wrapping ErrInvalidID when the ID is not positive, wrapping ErrNotFound when the item does
+// not exist, or wrapping ErrConflict when the item is closed or already in progress.
+//
+// Returns nil and error on error.
+//
+// Returns the started item and nil on success.
+func (uc *defaultUseCase) Start(ctx context.Context, itemId int64) (Item, error) {
+	return uc.changeStatus(ctx, "start", itemId, StatusInProgress, "")
+}
+
+// Block godoc
+//
+// Move an item by its ID to the blocked status, with an optional reason. Blocking a blocked item replaces its
+// reason.
+//
+// Returns nil and error wrapping ErrInvalidID when the ID is not positive, wrapping ErrNotFound when the item does
+// not exist, or wrapping ErrConflict when the item is closed.
+//
+// Returns nil and error on error.
+//
+// Returns the blocked item and nil on success.
+func (uc *defaultUseCase) Block(ctx context.Context, itemId int64, reason string) (Item, error) {
+	return uc.changeStatus(ctx, "block", itemId, StatusBlocked, reason)
+}
+
+// changeStatus godoc
+//
+// Journals the status change of an item under verb, see journaled.
+func (uc *defaultUseCase) changeStatus(
+	ctx context.Context, verb string, itemId int64, status Status, reason string,
+) (Item, error) {
+	var changedItem Item
+	err := uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		if err := txUseCase.capture(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.changeStatus: %v", err)
+		}
+		var err error
+		changedItem, err = txUseCase.setStatus(ctx, itemId, status, reason)
+		if err != nil {
+			return "", err
+		}
+		return summarizeItems(verb, []int64{itemId}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changedItem, nil
+}
+
+// setStatus godoc
+//
+// Implements the status changes in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) setStatus(ctx context.Context, itemId int64, status Status, reason string) (Item, error) {
+	if itemId <= 0 {
+		return nil, fmt.Errorf("defaultUseCase.setStatus: %w", newInvalidIdError(itemId))
+	}
+
+	foundItem, err := uc.repository.FindItemById(ctx, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.setStatus: Failed to find item with ID %d: %v", itemId, err)
+	}
+	if foundItem == nil {
+		return nil, fmt.Errorf("defaultUseCase.setStatus: %w", &NotFoundError{ItemId: itemId})
+	}
+
+	updatedItem, err := uc.domain.UpdateItemStatus(status, reason, foundItem)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.setStatus: Failed to update item with ID %d: %w", itemId, err)
+	}
+	affectedRows, err := uc.repository.UpdateItemById(ctx, updatedItem)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.setStatus: Failed to persist update for item with ID %d: %v", itemId, err)
+	}
+	if affectedRows == 0 {
+		return nil, fmt.Errorf("defaultUseCase.setStatus: %w", &NotFoundError{ItemId: itemId})
+	}
+	return updatedItem, nil
+}
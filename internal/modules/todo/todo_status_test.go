@@ -0,0 +1,93 @@
+package todo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDefaultUseCase_Status(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	for _, draft := range []ItemDraft{{Name: "write report"}, {Name: "renew licence"}} {
+		if _, err := useCase.Create(ctx, draft); err != nil {
+			t.Fatalf("TestDefaultUseCase_Status: %v", err)
+		}
+	}
+
+	t.Run("should start an item", func(t *testing.T) {
+		item, err := useCase.Start(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusInProgress, item.GetStatus())
+		details, err := useCase.Get(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusInProgress, details.Item.GetStatus())
+
+		_, err = useCase.Start(ctx, 1)
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("should block an item with a reason and keep it out of the ready items", func(t *testing.T) {
+		item, err := useCase.Block(ctx, 2, "waiting on finance")
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusBlocked, item.GetStatus())
+		assert.Equal(t, "waiting on finance", item.GetStatusReason())
+		ready, err := useCase.List(ctx, ListOptions{Ready: true})
+		assert.NoError(t, err)
+		assert.Len(t, ready, 1)
+		assert.Equal(t, int64(1), ready[0].GetId())
+		blocked, err := useCase.List(ctx, ListOptions{Filters: []string{"status:blocked"}})
+		assert.NoError(t, err)
+		assert.Len(t, blocked, 1)
+		assert.Equal(t, "waiting on finance", blocked[0].GetStatusReason())
+	})
+
+	t.Run("should reopen a completed item", func(t *testing.T) {
+		_, err := useCase.Complete(ctx, 1)
+		assert.NoError(t, err)
+		_, err = useCase.Block(ctx, 1, "")
+		assert.ErrorIs(t, err, ErrConflict)
+
+		item, err := useCase.Reopen(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, StatusTodo, item.GetStatus())
+		_, err = useCase.Reopen(ctx, 1)
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("should cancel an item through an update", func(t *testing.T) {
+		cancelled := StatusCancelled
+		assert.NoError(t, useCase.Update(ctx, 2, ItemChanges{Status: &cancelled}))
+
+		details, err := useCase.Get(ctx, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusCancelled, details.Item.GetStatus())
+		assert.Equal(t, "", details.Item.GetStatusReason())
+		open, err := useCase.List(ctx, ListOptions{Filters: []string{"status:open"}})
+		assert.NoError(t, err)
+		assert.Len(t, open, 1)
+	})
+
+	t.Run("should undo a status change", func(t *testing.T) {
+		_, err := useCase.Start(ctx, 1)
+		assert.NoError(t, err)
+
+		entry, err := useCase.Undo(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "start item 1", entry.Summary)
+		details, err := useCase.Get(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusTodo, details.Item.GetStatus())
+	})
+
+	t.Run("should return error when the item does not exist", func(t *testing.T) {
+		_, err := useCase.Reopen(ctx, 0)
+		assert.ErrorIs(t, err, ErrInvalidID)
+		_, err = useCase.Start(ctx, 99)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
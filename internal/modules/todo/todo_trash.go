@@ -0,0 +1,253 @@
+package todo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Purge godoc
+//
+// Delete an item by its ID for good, along with its subtasks, whether it is in the trash or not.
+//
+// Returns error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item does not
+// exist.
+//
+// Returns error on error.
+//
+// Returns nil when the item is deleted successfully.
+func (uc *defaultUseCase) Purge(ctx context.Context, itemId int64) error {
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		if err := txUseCase.captureTree(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.Purge: %v", err)
+		}
+		// Subtasks removed on their own are deleted along with the item as well
+		trashedSubtaskIds, err := txUseCase.findTrashedSubtaskIds(ctx, itemId)
+		if err != nil {
+			return "", fmt.Errorf("defaultUseCase.Purge: %v", err)
+		}
+		if err := txUseCase.capture(ctx, trashedSubtaskIds...); err != nil {
+			return "", fmt.Errorf("defaultUseCase.Purge: %v", err)
+		}
+		if err := txUseCase.purge(ctx, itemId); err != nil {
+			return "", err
+		}
+		return summarizeItems("delete", []int64{itemId}), nil
+	})
+}
+
+// purge godoc
+//
+// Implements Purge in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) purge(ctx context.Context, itemId int64) error {
+	if itemId <= 0 {
+		return fmt.Errorf("defaultUseCase.Purge: %w", newInvalidIdError(itemId))
+	}
+
+	// Delete the item by its ID
+	affectedRows, err := uc.repository.DeleteItemById(ctx, itemId)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Purge: Failed to delete item with ID %d: %v", itemId, err)
+	}
+	// Item not deleted as it does not exist
+	if affectedRows == 0 {
+		return fmt.Errorf("defaultUseCase.Purge: %w", &NotFoundError{ItemId: itemId})
+	}
+	return nil
+}
+
+// PurgeItems godoc
+//
+// Delete the selected items for good in a single transaction, see Purge. The selected subtasks of a selected item
+// are deleted along with it.
+//
+// Returns nil and error wrapping ErrValidation when no item is selected or the filter expression is invalid.
+//
+// Returns nil and error on error.
+//
+// Returns the summary of the operation and nil otherwise. No change is saved when it failed for an item.
+func (uc *defaultUseCase) PurgeItems(ctx context.Context, options BulkOptions) (*BulkSummary, error) {
+	scope := bulkScope{verb: "delete", coversSubtasks: true, includesTrash: true}
+	summary, err := uc.runBulk(ctx, options, scope, func(txUseCase *defaultUseCase, result *BulkResult) error {
+		return txUseCase.Purge(ctx, result.ItemId)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.PurgeItems: %w", err)
+	}
+	return summary, nil
+}
+
+// Restore godoc
+//
+// Take an item by its ID out of the trash, along with the subtasks removed with it. The parents of the item which
+// are in the trash are restored as well, so the item keeps its place in the tree.
+//
+// Returns nil and error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item is
+// not in the trash.
+//
+// Returns nil and error on error.
+//
+// Returns the restored items, the item first, and nil on success.
+func (uc *defaultUseCase) Restore(ctx context.Context, itemId int64) ([]Item, error) {
+	var restoredItems []Item
+	err := uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		var err error
+		restoredItems, err = txUseCase.restore(ctx, itemId)
+		if err != nil {
+			return "", err
+		}
+		return summarizeItems("restore", []int64{itemId}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restoredItems, nil
+}
+
+// restore godoc
+//
+// Implements Restore in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) restore(ctx context.Context, itemId int64) ([]Item, error) {
+	if itemId <= 0 {
+		return nil, fmt.Errorf("defaultUseCase.Restore: %w", newInvalidIdError(itemId))
+	}
+
+	trashedItems, err := uc.repository.FindItems(ctx, ItemFilter{Trashed: true})
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Restore: Failed to find items in the trash: %v", err)
+	}
+	trashedById := map[int64]Item{}
+	for _, trashedItem := range trashedItems {
+		trashedById[trashedItem.GetId()] = trashedItem
+	}
+	item, ok := trashedById[itemId]
+	if !ok {
+		return nil, fmt.Errorf("defaultUseCase.Restore: %w", &NotFoundError{ItemId: itemId})
+	}
+
+	restoredItems := []Item{item}
+	for parent, ok := trashedById[item.GetParentId()]; ok; parent, ok = trashedById[parent.GetParentId()] {
+		restoredItems = append(restoredItems, parent)
+	}
+	// Subtasks removed on their own before the item stay in the trash
+	for _, subtaskId := range trashedSubtaskIds(trashedItems, itemId) {
+		subtask := trashedById[subtaskId]
+		if subtask.GetDeletedAt().Equal(item.GetDeletedAt()) {
+			restoredItems = append(restoredItems, subtask)
+		}
+	}
+
+	for _, restoredItem := range restoredItems {
+		if err := uc.capture(ctx, restoredItem.GetId()); err != nil {
+			return nil, fmt.Errorf("defaultUseCase.Restore: %v", err)
+		}
+		if _, err := uc.domain.RestoreTrashedItem(restoredItem); err != nil {
+			return nil, fmt.Errorf("defaultUseCase.Restore: %v", err)
+		}
+	}
+	if _, err := uc.repository.UpdateItemsById(ctx, restoredItems); err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Restore: Failed to restore item with ID %d: %v", itemId, err)
+	}
+	return restoredItems, nil
+}
+
+// ListTrash godoc
+//
+// List the items in the trash, most recently removed first.
+//
+// Returns nil and error on error.
+//
+// Returns the items and nil on success.
+func (uc *defaultUseCase) ListTrash(ctx context.Context) ([]Item, error) {
+	items, err := uc.repository.FindItems(ctx, ItemFilter{Trashed: true})
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.ListTrash: %v", err)
+	}
+	return items, nil
+}
+
+// EmptyTrash godoc
+//
+// Delete for good the items which were moved to the trash before a time, or every item in the trash when before is
+// the zero time.
+//
+// Returns 0 and error on error.
+//
+// Returns the number of deleted items and nil on success.
+func (uc *defaultUseCase) EmptyTrash(ctx context.Context, before time.Time) (int64, error) {
+	var count int64
+	err := uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		trashedItems, err := txUseCase.repository.FindItems(ctx, ItemFilter{Trashed: true})
+		if err != nil {
+			return "", fmt.Errorf("defaultUseCase.EmptyTrash: Failed to find items in the trash: %v", err)
+		}
+		var deletedIds []int64
+		for _, trashedItem := range trashedItems {
+			if before.IsZero() || trashedItem.GetDeletedAt().Unix() < before.Unix() {
+				deletedIds = append(deletedIds, trashedItem.GetId())
+			}
+		}
+		if err := txUseCase.capture(ctx, deletedIds...); err != nil {
+			return "", fmt.Errorf("defaultUseCase.EmptyTrash: %v", err)
+		}
+
+		// Subtasks deleted along with their parent are not counted by the repository
+		if _, err := txUseCase.repository.DeleteTrashedItems(ctx, before); err != nil {
+			return "", fmt.Errorf("defaultUseCase.EmptyTrash: Failed to delete items in the trash: %v", err)
+		}
+		count = int64(len(deletedIds))
+		return summarizeItems("delete", deletedIds), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// findTrashedSubtaskIds godoc
+//
+// Returns the IDs of the subtasks of an item in the trash, at any depth, and nil.
+//
+// Returns nil and error on error.
+func (uc *defaultUseCase) findTrashedSubtaskIds(ctx context.Context, itemId int64) ([]int64, error) {
+	if itemId <= 0 {
+		return nil, nil
+	}
+	trashedItems, err := uc.repository.FindItems(ctx, ItemFilter{Trashed: true})
+	if err != nil {
+		return nil, fmt.Errorf("findTrashedSubtaskIds: Failed to find items in the trash: %v", err)
+	}
+	descendants, err := uc.repository.FindDescendantItems(ctx, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("findTrashedSubtaskIds: Failed to find subtasks of item with ID %d: %v", itemId, err)
+	}
+
+	rootIds := []int64{itemId}
+	for _, descendant := range descendants {
+		rootIds = append(rootIds, descendant.GetId())
+	}
+	return trashedSubtaskIds(trashedItems, rootIds...), nil
+}
+
+// trashedSubtaskIds godoc
+//
+// Returns the IDs of the items in trashedItems which are subtasks, at any depth, of the items with rootIds. A
+// subtask is listed after its parent.
+func trashedSubtaskIds(trashedItems []Item, rootIds ...int64) []int64 {
+	children := map[int64][]int64{}
+	for _, trashedItem := range trashedItems {
+		children[trashedItem.GetParentId()] = append(children[trashedItem.GetParentId()], trashedItem.GetId())
+	}
+
+	var subtaskIds []int64
+	queue := append([]int64{}, rootIds...)
+	for len(queue) > 0 {
+		parentId := queue[0]
+		queue = queue[1:]
+		for _, childId := range children[parentId] {
+			subtaskIds = append(subtaskIds, childId)
+			queue = append(queue, childId)
+		}
+	}
+	return subtaskIds
+}
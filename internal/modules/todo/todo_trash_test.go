@@ -0,0 +1,208 @@
+package todo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDefaultUseCase_Trash(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	// Item 1 has subtask 2, which has subtask 3. Item 4 depends on item 2.
+	for _, draft := range []ItemDraft{
+		{Name: "parent"}, {Name: "subtask", ParentId: 1}, {Name: "nested subtask", ParentId: 2}, {Name: "other"},
+	} {
+		if _, err := useCase.Create(ctx, draft); err != nil {
+			t.Fatalf("TestDefaultUseCase_Trash: %v", err)
+		}
+	}
+	if err := useCase.Depend(ctx, 4, 2); err != nil {
+		t.Fatalf("TestDefaultUseCase_Trash: %v", err)
+	}
+
+	t.Run("should move an item to the trash with its subtasks", func(t *testing.T) {
+		assert.NoError(t, useCase.Remove(ctx, 2))
+
+		items, err := useCase.List(ctx, ListOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		trashedItems, err := useCase.ListTrash(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, trashedItems, 2)
+		_, err = useCase.Get(ctx, 3)
+		assert.ErrorIs(t, err, ErrNotFound)
+		ready, err := useCase.List(ctx, ListOptions{Ready: true})
+		assert.NoError(t, err)
+		assert.Len(t, ready, 2)
+	})
+
+	t.Run("should restore an item with its parents, subtasks and dependencies", func(t *testing.T) {
+		assert.NoError(t, useCase.Remove(ctx, 1))
+
+		restoredItems, err := useCase.Restore(ctx, 3)
+
+		assert.NoError(t, err)
+		assert.Len(t, restoredItems, 3)
+		assert.Equal(t, int64(3), restoredItems[0].GetId())
+		assert.True(t, restoredItems[0].GetDeletedAt().IsZero())
+		details, err := useCase.Get(ctx, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2}, details.DependsOnIds)
+		trashedItems, err := useCase.ListTrash(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, trashedItems)
+	})
+
+	t.Run("should return error when the item is not in the trash", func(t *testing.T) {
+		_, err := useCase.Restore(ctx, 1)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = useCase.Restore(ctx, 0)
+		assert.ErrorIs(t, err, ErrInvalidID)
+	})
+
+	t.Run("should undo and redo a removal", func(t *testing.T) {
+		assert.NoError(t, useCase.Remove(ctx, 2))
+		_, err := useCase.Undo(ctx)
+		assert.NoError(t, err)
+		_, err = useCase.Redo(ctx)
+		assert.NoError(t, err)
+
+		restoredItems, err := useCase.Restore(ctx, 2)
+		assert.NoError(t, err)
+		assert.Len(t, restoredItems, 2)
+		details, err := useCase.Get(ctx, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2}, details.DependsOnIds)
+	})
+
+	t.Run("should delete an item and its subtasks in the trash for good", func(t *testing.T) {
+		assert.NoError(t, useCase.Remove(ctx, 3))
+
+		assert.NoError(t, useCase.Purge(ctx, 1))
+
+		items, err := useCase.List(ctx, ListOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		trashedItems, err := useCase.ListTrash(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, trashedItems)
+		assert.ErrorIs(t, useCase.Purge(ctx, 1), ErrNotFound)
+
+		_, err = useCase.Undo(ctx)
+		assert.NoError(t, err)
+		trashedItems, err = useCase.ListTrash(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, trashedItems, 1)
+	})
+
+	t.Run("should empty the trash", func(t *testing.T) {
+		count, err := useCase.EmptyTrash(ctx, time.Now().Add(-time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+
+		assert.NoError(t, useCase.Remove(ctx, 1))
+
+		count, err = useCase.EmptyTrash(ctx, time.Time{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		trashedItems, err := useCase.ListTrash(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, trashedItems)
+		entries, err := useCase.History(ctx, 1)
+		assert.NoError(t, err)
+		assert.Len(t, entries[0].Changes, 3)
+	})
+}
+
+func TestDefaultUseCase_ProjectItems(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	_, err := fixture.Db.Exec("INSERT INTO projects (name, updatedAt, createdAt) VALUES ('backend', 0, 0), ('ops', 0, 0)")
+	if err != nil {
+		t.Fatalf("TestDefaultUseCase_ProjectItems: %v", err)
+	}
+	// Items 1 and 3 are in project 1, item 2 is a subtask of item 1 without a project and item 3 is in the trash
+	for _, draft := range []ItemDraft{
+		{Name: "parent", ProjectId: 1}, {Name: "subtask", ParentId: 1}, {Name: "trashed", ProjectId: 1},
+		{Name: "other", ProjectId: 2},
+	} {
+		if _, err := useCase.Create(ctx, draft); err != nil {
+			t.Fatalf("TestDefaultUseCase_ProjectItems: %v", err)
+		}
+	}
+	if err := useCase.Remove(ctx, 3); err != nil {
+		t.Fatalf("TestDefaultUseCase_ProjectItems: %v", err)
+	}
+
+	t.Run("should move the items of a project, in the trash or not", func(t *testing.T) {
+		assert.NoError(t, useCase.MoveProjectItems(ctx, 1, 2))
+
+		items, err := useCase.List(ctx, ListOptions{ProjectId: 2})
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		trashedItems, err := useCase.ListTrash(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), trashedItems[0].GetProjectId())
+
+		entry, err := useCase.Undo(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "move items 1, 3", entry.Summary)
+		assert.Empty(t, entry.UnlinkedItemIds)
+	})
+
+	t.Run("should move the items of a project to the trash with their subtasks", func(t *testing.T) {
+		assert.NoError(t, useCase.RemoveProjectItems(ctx, 1))
+
+		items, err := useCase.List(ctx, ListOptions{})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		trashedItems, err := useCase.ListTrash(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, trashedItems, 3)
+		for _, item := range trashedItems {
+			assert.Equal(t, int64(0), item.GetProjectId())
+		}
+		events, err := useCase.Log(ctx, 1, time.Time{})
+		assert.NoError(t, err)
+		assert.Equal(t, ItemEventFieldDeletedAt, events[len(events)-1].Field)
+
+		entry, err := useCase.Undo(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "remove items 1, 3", entry.Summary)
+		items, err = useCase.List(ctx, ListOptions{ProjectId: 1})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+	})
+
+	t.Run("should report the items which cannot be put back in their deleted project", func(t *testing.T) {
+		assert.NoError(t, useCase.MoveProjectItems(ctx, 1, 0))
+		if _, err := fixture.Db.Exec("DELETE FROM projects WHERE id = 1"); err != nil {
+			t.Fatalf("TestDefaultUseCase_ProjectItems: %v", err)
+		}
+
+		entry, err := useCase.Undo(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1, 3}, entry.UnlinkedItemIds)
+		items, err := useCase.List(ctx, ListOptions{ProjectId: NoProjectId})
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+	})
+}
+
+func TestTrashedSubtaskIds(t *testing.T) {
+	var trashedItems []Item
+	for id, parentId := range []int64{0, 0, 1, 2, 0} {
+		item := NewItem(int64(id), "item", StatusTodo, time.Time{}, time.Now(), time.Now())
+		item.SetParentId(parentId)
+		trashedItems = append(trashedItems, item)
+	}
+
+	assert.Equal(t, []int64{2, 3}, trashedSubtaskIds(trashedItems[1:], 1))
+	assert.Empty(t, trashedSubtaskIds(trashedItems[1:], 4))
+}
@@ -1,16 +1,118 @@
 package todo
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // UseCase godoc
 //
 // An interface that defines the behaviour for a todo item use case struct.
 type UseCase interface {
-	Create(string) error
-	List() error
-	Remove(int64) (int64, error)
-	Update(int64, string) (int64, error)
-	Complete(int64) (int64, error)
+	Create(context.Context, ItemDraft) (Item, error)
+	List(context.Context, ListOptions) ([]Item, error)
+	Remove(context.Context, int64) error
+	Update(context.Context, int64, ItemChanges) error
+	Complete(context.Context, int64) (*Completion, error)
+	Tag(context.Context, int64, string) error
+	Untag(context.Context, int64, string) error
+	ListTags(context.Context) ([]string, error)
+	Depend(context.Context, int64, int64) error
+	Undepend(context.Context, int64, int64) error
+	EditNote(context.Context, int64, func(string) (string, error)) error
+	Get(context.Context, int64) (*ItemDetails, error)
+	Search(context.Context, string) ([]SearchResult, error)
+	CompleteItems(context.Context, BulkOptions) (*BulkSummary, error)
+	RemoveItems(context.Context, BulkOptions) (*BulkSummary, error)
+	UpdateItems(context.Context, BulkOptions, ItemChanges) (*BulkSummary, error)
+	Undo(context.Context) (*JournalEntry, error)
+	Redo(context.Context) (*JournalEntry, error)
+	History(context.Context, int) ([]JournalEntry, error)
+	Purge(context.Context, int64) error
+	PurgeItems(context.Context, BulkOptions) (*BulkSummary, error)
+	Restore(context.Context, int64) ([]Item, error)
+	ListTrash(context.Context) ([]Item, error)
+	EmptyTrash(context.Context, time.Time) (int64, error)
+	Reopen(context.Context, int64) (Item, error)
+	Start(context.Context, int64) (Item, error)
+	Block(context.Context, int64, string) (Item, error)
+	Log(context.Context, int64, time.Time) ([]ItemEvent, error)
+	Activity(context.Context, time.Time) ([]ItemEvent, error)
+	MoveProjectItems(context.Context, int64, int64) error
+	RemoveProjectItems(context.Context, int64) error
+}
+
+// ListOptions godoc
+//
+// Defines which items are listed.
+type ListOptions struct {
+	Due DueFilter
+	// Tags keeps items which have every one of these tags.
+	Tags []string
+	// ExcludedTags drops items which have any of these tags.
+	ExcludedTags []string
+	// ProjectId keeps items owned by this project, or items without a project when NoProjectId.
+	// When 0, items owned by archived projects are hidden.
+	ProjectId int64
+	// Ready keeps open items whose dependencies are all completed, ordered topologically.
+	Ready bool
+	// Recurring keeps items which repeat.
+	Recurring bool
+	// Sort orders the items by each key in turn, ignored when Ready is true. No keys lists items by priority.
+	Sort []SortKey
+	// Filters keeps items matching every filter expression, e.g. `status:open and due<+7d`, in addition to the other
+	// options. Empty expressions are ignored.
+	Filters []string
+}
+
+// Completion godoc
+//
+// Defines the outcome of completing an item.
+type Completion struct {
+	// Items holds the completed item followed by the subtasks completed along with it.
+	Items []Item
+	// NextOccurrence is the item created by completing a recurring item, nil when the item does not repeat.
+	NextOccurrence Item
+}
+
+// ItemChanges godoc
+//
+// Defines the changes to apply to an item during an update.
+//
+// Nil fields are left unchanged.
+type ItemChanges struct {
+	Name *string
+	// DueAt set to the zero time removes the due date.
+	DueAt    *time.Time
+	Priority *Priority
+	// ProjectId set to 0 removes the item from its project.
+	ProjectId *int64
+	// ParentId set to 0 makes the item a top level item.
+	ParentId *int64
+	// Recurrence set to an empty expression stops the item from repeating.
+	Recurrence *string
+	// Description set to an empty string removes the notes.
+	Description *string
+	// Status moves the item through the status workflow, see Domain.UpdateItemStatus. Moving it to done completes
+	// it, see Complete.
+	Status *Status
+}
+
+// IsEmpty godoc
+//
+// Returns true when no changes are set.
+func (c ItemChanges) IsEmpty() bool {
+	return c.Name == nil && c.DueAt == nil && c.Priority == nil && c.ProjectId == nil && c.ParentId == nil &&
+		c.Recurrence == nil && c.Description == nil && c.Status == nil
+}
+
+// completes godoc
+//
+// Returns true when the changes move the item to the done status.
+func (c ItemChanges) completes() bool {
+	return c.Status != nil && *c.Status == StatusDone
 }
 
 // defaultUseCase godoc
@@ -19,179 +121,937 @@ type UseCase interface {
 //
 // Adheres to the todo UseCase interface.
 type defaultUseCase struct {
-	domain     Domain
-	repository Repository
+	domain           Domain
+	repository       Repository
+	completionPolicy CompletionPolicy
+	// recorder collects the items changed by the operation which the use case runs, nil outside of an operation.
+	recorder *journalRecorder
 }
 
 // NewUseCase godoc
 //
 // Creates a new UseCase with the passed in Domain and Repository instances.
+//
+// Items with open subtasks cannot be completed.
 func NewUseCase(domain Domain, repository Repository) UseCase {
+	return NewUseCaseWithCompletionPolicy(domain, repository, CompletionPolicyRefuse)
+}
+
+// NewUseCaseWithCompletionPolicy godoc
+//
+// Creates a new UseCase with the passed in Domain and Repository instances, which completes items with open
+// subtasks following the passed in policy.
+func NewUseCaseWithCompletionPolicy(domain Domain, repository Repository, policy CompletionPolicy) UseCase {
 	return &defaultUseCase{
-		domain:     domain,
-		repository: repository,
+		domain:           domain,
+		repository:       repository,
+		completionPolicy: policy,
 	}
 }
 
 // Create godoc
 //
-// Construct a new todo item using the passed in name and persist it locally.
+// Construct a new todo item from the passed in draft and persist it locally.
+//
+// Returns nil and error wrapping ErrValidation when the draft is invalid, or wrapping ErrNotFound when the parent
+// item does not exist.
 //
-// Returns error on error, nil otherwise.
-func (uc *defaultUseCase) Create(name string) error {
-	item, err := uc.domain.CreateItem(name)
+// Returns nil and error on error.
+//
+// Returns the created item, as read back from the database, and nil on success.
+func (uc *defaultUseCase) Create(ctx context.Context, draft ItemDraft) (Item, error) {
+	var createdItem Item
+	err := uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		var err error
+		createdItem, err = txUseCase.create(ctx, draft)
+		if err != nil {
+			return "", err
+		}
+		return summarizeItems("create", []int64{createdItem.GetId()}), nil
+	})
 	if err != nil {
-		return fmt.Errorf("defaultUseCase.Create: %v", err)
+		return nil, err
 	}
-	_, err = uc.repository.PersistItem(item)
+	return createdItem, nil
+}
+
+// create godoc
+//
+// Implements Create in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) create(ctx context.Context, draft ItemDraft) (Item, error) {
+	item, err := uc.domain.CreateItem(draft)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("defaultUseCase.Create: %w", err)
 	}
-	fmt.Printf("Created new todo: %s\n", name)
-	return nil
+	if draft.ParentId != 0 {
+		parent, err := uc.repository.FindItemById(ctx, draft.ParentId)
+		if err != nil {
+			return nil, fmt.Errorf("defaultUseCase.Create: Failed to find parent item with ID %d: %v", draft.ParentId, err)
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("defaultUseCase.Create: parent: %w", &NotFoundError{ItemId: draft.ParentId})
+		}
+	}
+	createdItem, err := uc.persistItemWithTags(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Create: %v", err)
+	}
+	return createdItem, nil
 }
 
 // List godoc
 //
-// Get all persisted todo items and print them in a tabular list.
+// Get the persisted todo items matching the options.
+//
+// Returns nil and error wrapping ErrValidation when the due filter, a tag or the filter expression is invalid. An
+// invalid filter expression is a *FilterError.
+//
+// Returns nil and error on error.
 //
-// Returns error on error, nil otherwise.
-func (uc *defaultUseCase) List() error {
-	items, err := uc.repository.FindAllItems()
+// Returns the items and nil on success.
+func (uc *defaultUseCase) List(ctx context.Context, options ListOptions) ([]Item, error) {
+	filter, err := uc.domain.GetDueItemFilter(options.Due)
 	if err != nil {
-		return fmt.Errorf("defaultUseCase.List: %v", err)
+		return nil, fmt.Errorf("defaultUseCase.List: %w", err)
 	}
-	tabularList, err := uc.domain.GetTabularItemList(items)
+	filter.Tags, err = uc.domain.NormalizeTags(options.Tags)
 	if err != nil {
-		return fmt.Errorf("defaultUseCase.List: %v", err)
+		return nil, fmt.Errorf("defaultUseCase.List: %w", err)
 	}
-
-	fmt.Println(tabularList)
-	return nil
+	filter.ExcludedTags, err = uc.domain.NormalizeTags(options.ExcludedTags)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.List: %w", err)
+	}
+	filter.ProjectId = options.ProjectId
+	filter.HideArchivedProjects = options.ProjectId == 0
+	filter.ReadyOnly = options.Ready
+	filter.RecurringOnly = options.Recurring
+	filter.Order = options.Sort
+	for _, expression := range options.Filters {
+		if expression == "" {
+			continue
+		}
+		parsed, err := uc.domain.ParseFilter(expression)
+		if err != nil {
+			return nil, fmt.Errorf("defaultUseCase.List: %w", err)
+		}
+		if filter.Expression != nil {
+			parsed = &FilterAnd{Left: filter.Expression, Right: parsed}
+		}
+		filter.Expression = parsed
+	}
+	items, err := uc.repository.FindItems(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.List: %v", err)
+	}
+	if options.Ready {
+		dependencies, err := uc.repository.FindAllDependencies(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("defaultUseCase.List: %v", err)
+		}
+		items = uc.domain.SortItemsByDependencies(items, dependencies)
+	}
+	return items, nil
 }
 
 // Remove godoc
 //
-// Remove an item by its ID.
+// Move an item by its ID to the trash, along with its subtasks. Items in the trash are hidden until they are
+// restored, see Restore, or deleted, see Purge and EmptyTrash.
 //
-// Returns -1 and nil when the item does not exist.
+// Returns error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item does not
+// exist or is already in the trash.
 //
-// Returns -1 and error on error.
+// Returns error on error.
 //
-// Returns the deleted item's ID when the item is deleted successfully.
-func (uc *defaultUseCase) Remove(itemId int64) (int64, error) {
-	// Invalid item ID
-	if itemId == 0 {
-		return -1, nil
+// Returns nil when the item is moved to the trash successfully.
+func (uc *defaultUseCase) Remove(ctx context.Context, itemId int64) error {
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		if err := txUseCase.captureTree(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.Remove: %v", err)
+		}
+		if err := txUseCase.remove(ctx, itemId); err != nil {
+			return "", err
+		}
+		return summarizeItems("remove", []int64{itemId}), nil
+	})
+}
+
+// remove godoc
+//
+// Implements Remove in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) remove(ctx context.Context, itemId int64) error {
+	if itemId <= 0 {
+		return fmt.Errorf("defaultUseCase.Remove: %w", newInvalidIdError(itemId))
 	}
 
-	// Delete the item by its ID
-	affectedRows, err := uc.repository.DeleteItemById(itemId)
+	item, err := uc.repository.FindItemById(ctx, itemId)
 	if err != nil {
-		return -1, fmt.Errorf("defaultUseCase.Remove: Failed to delete item with ID %d: %v", itemId, err)
+		return fmt.Errorf("defaultUseCase.Remove: Failed to find item with ID %d: %v", itemId, err)
 	}
-	// Item not deleted as it does not exist
-	if affectedRows == 0 {
-		return -1, nil
+	if item == nil {
+		return fmt.Errorf("defaultUseCase.Remove: %w", &NotFoundError{ItemId: itemId})
+	}
+	descendants, err := uc.repository.FindDescendantItems(ctx, itemId)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Remove: Failed to find subtasks of item with ID %d: %v", itemId, err)
 	}
 
-	return itemId, nil
+	// Subtasks go to the trash along with the item, so they are restored together
+	trashedItems := append([]Item{item}, descendants...)
+	for _, trashedItem := range trashedItems {
+		if _, err := uc.domain.TrashItem(trashedItem); err != nil {
+			return fmt.Errorf("defaultUseCase.Remove: %v", err)
+		}
+	}
+	if _, err := uc.repository.UpdateItemsById(ctx, trashedItems); err != nil {
+		return fmt.Errorf("defaultUseCase.Remove: Failed to move item with ID %d to the trash: %v", itemId, err)
+	}
+	return nil
+}
+
+// MoveProjectItems godoc
+//
+// Move the items of a project, including the items in the trash, into another project, e.g. before the project is
+// deleted. A toProjectId of 0 moves the items out of any project.
+//
+// Returns error on error.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) MoveProjectItems(ctx context.Context, projectId int64, toProjectId int64) error {
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		itemIds, _, err := txUseCase.releaseProjectItems(ctx, projectId, toProjectId)
+		if err != nil {
+			return "", fmt.Errorf("defaultUseCase.MoveProjectItems: %v", err)
+		}
+		return summarizeItems("move", itemIds), nil
+	})
+}
+
+// RemoveProjectItems godoc
+//
+// Move the items of a project, along with their subtasks, to the trash, e.g. before the project is deleted. The
+// items are moved out of the project, so they are restored without a project.
+//
+// Returns error on error.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) RemoveProjectItems(ctx context.Context, projectId int64) error {
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		itemIds, activeIds, err := txUseCase.releaseProjectItems(ctx, projectId, 0)
+		if err != nil {
+			return "", fmt.Errorf("defaultUseCase.RemoveProjectItems: %v", err)
+		}
+		for _, itemId := range activeIds {
+			item, err := txUseCase.repository.FindItemById(ctx, itemId)
+			if err != nil {
+				return "", fmt.Errorf(
+					"defaultUseCase.RemoveProjectItems: Failed to find item with ID %d: %v", itemId, err,
+				)
+			}
+			// The item went to the trash along with its parent
+			if item == nil {
+				continue
+			}
+			if err := txUseCase.captureTree(ctx, itemId); err != nil {
+				return "", fmt.Errorf("defaultUseCase.RemoveProjectItems: %v", err)
+			}
+			if err := txUseCase.remove(ctx, itemId); err != nil {
+				return "", fmt.Errorf("defaultUseCase.RemoveProjectItems: %v", err)
+			}
+		}
+		return summarizeItems("remove", itemIds), nil
+	})
+}
+
+// releaseProjectItems godoc
+//
+// Moves the items of a project, in the trash or not, into the project with toProjectId, in the transaction of the
+// use case, see journaled.
+//
+// Returns nil, nil and error on error.
+//
+// Returns the IDs of the moved items, the IDs of those which are not in the trash, and nil on success.
+func (uc *defaultUseCase) releaseProjectItems(ctx context.Context, projectId int64, toProjectId int64) (
+	[]int64, []int64, error,
+) {
+	items, err := uc.repository.FindItems(ctx, ItemFilter{ProjectId: projectId})
+	if err != nil {
+		return nil, nil, fmt.Errorf("releaseProjectItems: Failed to find items of project %d: %v", projectId, err)
+	}
+	trashedItems, err := uc.repository.FindItems(ctx, ItemFilter{ProjectId: projectId, Trashed: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"releaseProjectItems: Failed to find items of project %d in the trash: %v", projectId, err,
+		)
+	}
+	activeIds := make([]int64, 0, len(items))
+	for _, item := range items {
+		activeIds = append(activeIds, item.GetId())
+	}
+	items = append(items, trashedItems...)
+	if len(items) == 0 {
+		return nil, nil, nil
+	}
+
+	itemIds := make([]int64, 0, len(items))
+	for _, item := range items {
+		itemIds = append(itemIds, item.GetId())
+	}
+	if err := uc.capture(ctx, itemIds...); err != nil {
+		return nil, nil, fmt.Errorf("releaseProjectItems: %v", err)
+	}
+	for _, item := range items {
+		if _, err := uc.domain.UpdateItemProject(toProjectId, item); err != nil {
+			return nil, nil, fmt.Errorf("releaseProjectItems: %v", err)
+		}
+	}
+	if _, err := uc.repository.UpdateItemsById(ctx, items); err != nil {
+		return nil, nil, fmt.Errorf("releaseProjectItems: Failed to move items of project %d: %v", projectId, err)
+	}
+	return itemIds, activeIds, nil
 }
 
 // Update godoc
 //
-// Update an item's name by itemId.
+// Apply the changes to an item by itemId.
+//
+// Returns error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item or its new
+// parent does not exist.
 //
-// Returns -1 and nil if the item does not exist.
+// Returns error wrapping ErrValidation when there are no changes or a change is invalid.
 //
-// Returns -1 and error on error.
+// Changing the status to done completes the item as Complete does, so it returns error wrapping ErrOpenSubtasks
+// when the completion policy is CompletionPolicyRefuse and the item has open subtasks.
+//
+// Returns error on error.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) Update(ctx context.Context, itemId int64, changes ItemChanges) error {
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		// Completing an item may complete its subtasks as well
+		if changes.completes() {
+			if err := txUseCase.captureTree(ctx, itemId); err != nil {
+				return "", fmt.Errorf("defaultUseCase.Update: %v", err)
+			}
+		} else if err := txUseCase.capture(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.Update: %v", err)
+		}
+		if err := txUseCase.update(ctx, itemId, changes); err != nil {
+			return "", err
+		}
+		return summarizeItems("update", []int64{itemId}), nil
+	})
+}
+
+// update godoc
 //
-// Returns updated item id and nil on success.
-func (uc *defaultUseCase) Update(itemId int64, newName string) (int64, error) {
-	// Invalid item ID
-	if itemId == 0 {
-		return -1, nil
+// Implements Update in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) update(ctx context.Context, itemId int64, changes ItemChanges) error {
+	if itemId <= 0 {
+		return fmt.Errorf("defaultUseCase.Update: %w", newInvalidIdError(itemId))
+	}
+	// Nothing to update
+	if changes.IsEmpty() {
+		return fmt.Errorf("defaultUseCase.Update: %w", newValidationError("changes", "no changes for item %d", itemId))
 	}
 	// New name is empty
-	if len(newName) == 0 {
-		return -1, fmt.Errorf("defaultUseCase.Update: New name for item is empty")
+	if changes.Name != nil && len(*changes.Name) == 0 {
+		return fmt.Errorf("defaultUseCase.Update: %w", newValidationError("name", "cannot be empty"))
 	}
 
 	// Find item by ID
-	foundItem, err := uc.repository.FindItemById(itemId)
+	foundItem, err := uc.repository.FindItemById(ctx, itemId)
 	// Error occurred while finding item
 	if err != nil {
-		return -1, fmt.Errorf("defaultUseCase.Update: Failed to find item with ID %d: %v", itemId, err)
+		return fmt.Errorf("defaultUseCase.Update: Failed to find item with ID %d: %v", itemId, err)
 	}
 	// Item not found
 	if foundItem == nil {
-		return -1, nil
+		return fmt.Errorf("defaultUseCase.Update: %w", &NotFoundError{ItemId: itemId})
 	}
 
 	// Update item
-	updatedItem, err := uc.domain.UpdateItemName(newName, foundItem)
-	// Error occurred while updating item
-	if err != nil {
-		return -1, fmt.Errorf("defaultUseCase.Update: Failed to update item with ID %d: %v", itemId, err)
+	updatedItem := foundItem
+	if changes.Name != nil {
+		updatedItem, err = uc.domain.UpdateItemName(*changes.Name, updatedItem)
+		// Error occurred while updating item
+		if err != nil {
+			return fmt.Errorf("defaultUseCase.Update: Failed to update item with ID %d: %w", itemId, err)
+		}
+	}
+	if changes.DueAt != nil {
+		updatedItem, err = uc.domain.UpdateItemDueAt(*changes.DueAt, updatedItem)
+		// Error occurred while updating item
+		if err != nil {
+			return fmt.Errorf("defaultUseCase.Update: Failed to update item with ID %d: %w", itemId, err)
+		}
+	}
+	if changes.Priority != nil {
+		updatedItem, err = uc.domain.UpdateItemPriority(*changes.Priority, updatedItem)
+		// Error occurred while updating item
+		if err != nil {
+			return fmt.Errorf("defaultUseCase.Update: Failed to update item with ID %d: %w", itemId, err)
+		}
+	}
+	if changes.ProjectId != nil {
+		updatedItem, err = uc.domain.UpdateItemProject(*changes.ProjectId, updatedItem)
+		// Error occurred while updating item
+		if err != nil {
+			return fmt.Errorf("defaultUseCase.Update: Failed to update item with ID %d: %w", itemId, err)
+		}
+	}
+	if changes.ParentId != nil {
+		var parentLineage []int64
+		if *changes.ParentId != 0 {
+			parentLineage, err = uc.repository.FindLineageIds(ctx, *changes.ParentId)
+			if err != nil {
+				return fmt.Errorf("defaultUseCase.Update: Failed to find parent item with ID %d: %v", *changes.ParentId, err)
+			}
+			// Parent item not found
+			if len(parentLineage) == 0 {
+				return fmt.Errorf("defaultUseCase.Update: parent: %w", &NotFoundError{ItemId: *changes.ParentId})
+			}
+		}
+		updatedItem, err = uc.domain.UpdateItemParent(*changes.ParentId, parentLineage, updatedItem)
+		// Error occurred while updating item
+		if err != nil {
+			return fmt.Errorf("defaultUseCase.Update: Failed to update item with ID %d: %w", itemId, err)
+		}
+	}
+	if changes.Recurrence != nil {
+		updatedItem, err = uc.domain.UpdateItemRecurrence(*changes.Recurrence, updatedItem)
+		// Error occurred while updating item
+		if err != nil {
+			return fmt.Errorf("defaultUseCase.Update: Failed to update item with ID %d: %w", itemId, err)
+		}
+	}
+	if changes.Description != nil {
+		updatedItem, err = uc.domain.UpdateItemDescription(*changes.Description, updatedItem)
+		// Error occurred while updating item
+		if err != nil {
+			return fmt.Errorf("defaultUseCase.Update: Failed to update item with ID %d: %w", itemId, err)
+		}
+	}
+	// Completing an item is left to complete, which applies the completion policy and repeats recurring items
+	if changes.Status != nil && !changes.completes() {
+		updatedItem, err = uc.domain.UpdateItemStatus(*changes.Status, "", updatedItem)
+		// Error occurred while updating item
+		if err != nil {
+			return fmt.Errorf("defaultUseCase.Update: Failed to update item with ID %d: %w", itemId, err)
+		}
 	}
 
 	// Update the item by its ID
-	affectedRows, err := uc.repository.UpdateItemById(updatedItem)
+	affectedRows, err := uc.repository.UpdateItemById(ctx, updatedItem)
 	// Error while persisting item update
 	if err != nil {
-		return -1, fmt.Errorf("defaultUseCase.Update: Failed to persists update for item with ID %d: %v", itemId, err)
+		return fmt.Errorf("defaultUseCase.Update: Failed to persists update for item with ID %d: %v", itemId, err)
 	}
-	// Item not deleted as it does not exist
+	// Item not updated as it does not exist
 	if affectedRows == 0 {
-		return -1, nil
+		return fmt.Errorf("defaultUseCase.Update: %w", &NotFoundError{ItemId: itemId})
 	}
 
-	return itemId, nil
+	if changes.completes() {
+		if _, err := uc.complete(ctx, itemId); err != nil {
+			return fmt.Errorf("defaultUseCase.Update: %w", err)
+		}
+	}
+	return nil
 }
 
 // Complete godoc
 //
-// Complete a todo item by ID.
+// Complete a todo item by ID, along with its open subtasks when the completion policy is CompletionPolicyCascade.
+//
+// Completing an open recurring item creates its next occurrence.
+//
+// Returns nil and error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item
+// does not exist.
+//
+// Returns nil and error wrapping ErrOpenSubtasks when the completion policy is CompletionPolicyRefuse and the item
+// has open subtasks.
 //
-// Returns -1 and nil if the item does not exist.
+// Returns nil and error on error.
 //
-// Returns -1 and error on error.
+// Returns the completed items, with the next occurrence of a recurring item, and nil on success.
+func (uc *defaultUseCase) Complete(ctx context.Context, itemId int64) (*Completion, error) {
+	var completion *Completion
+	err := uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		if err := txUseCase.captureTree(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.Complete: %v", err)
+		}
+		var err error
+		completion, err = txUseCase.complete(ctx, itemId)
+		if err != nil {
+			return "", err
+		}
+		return summarizeItems("complete", []int64{itemId}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return completion, nil
+}
+
+// complete godoc
 //
-// Returns updated item id and nil on success.
-func (uc *defaultUseCase) Complete(itemId int64) (int64, error) {
-	// Invalid item ID
-	if itemId == 0 {
-		return -1, nil
+// Implements Complete in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) complete(ctx context.Context, itemId int64) (*Completion, error) {
+	if itemId <= 0 {
+		return nil, fmt.Errorf("defaultUseCase.Complete: %w", newInvalidIdError(itemId))
 	}
 
 	// Find item by ID
-	foundItem, err := uc.repository.FindItemById(itemId)
+	foundItem, err := uc.repository.FindItemById(ctx, itemId)
 	// Error occurred while finding item
 	if err != nil {
-		return -1, fmt.Errorf("defaultUseCase.Complete: Failed to find item with ID %d: %v", itemId, err)
+		return nil, fmt.Errorf("defaultUseCase.Complete: Failed to find item with ID %d: %v", itemId, err)
 	}
 	// Item not found
 	if foundItem == nil {
-		return -1, nil
+		return nil, fmt.Errorf("defaultUseCase.Complete: %w", &NotFoundError{ItemId: itemId})
+	}
+
+	descendants, err := uc.repository.FindDescendantItems(ctx, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Complete: Failed to find subtasks of item with ID %d: %v", itemId, err)
 	}
 
 	// Complete item
-	completedItem, err := uc.domain.CompleteItem(foundItem)
+	completedItems, err := uc.domain.CompleteItemTree(foundItem, descendants, uc.completionPolicy)
 	// Err when completing item
 	if err != nil {
-		return -1, fmt.Errorf("defaultUseCase.Complete: Failed to update item with ID %d: %v", itemId, err)
+		return nil, fmt.Errorf("defaultUseCase.Complete: Failed to update item with ID %d: %w", itemId, err)
+	}
+	// Closed items cannot be completed, so a recurring item only creates its next occurrence once
+	occurrence, err := uc.domain.CreateNextOccurrence(foundItem)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Complete: Failed to repeat item with ID %d: %v", itemId, err)
 	}
 
-	// Update the item by its ID
-	affectedRows, err := uc.repository.UpdateItemById(completedItem)
+	// Update the items by their ID
+	affectedRows, err := uc.repository.UpdateItemsById(ctx, completedItems)
 	// Error while persisting item update
 	if err != nil {
-		return -1, fmt.Errorf("defaultUseCase.Complete: Failed to persists update for item with ID %d: %v", itemId, err)
+		return nil, fmt.Errorf("defaultUseCase.Complete: Failed to persists update for item with ID %d: %v", itemId, err)
+	}
+	// Item not updated as it does not exist
+	if affectedRows == 0 {
+		return nil, fmt.Errorf("defaultUseCase.Complete: %w", &NotFoundError{ItemId: itemId})
+	}
+
+	completion := &Completion{Items: completedItems}
+	if occurrence != nil {
+		completion.NextOccurrence, err = uc.persistItemWithTags(ctx, occurrence)
+		if err != nil {
+			return nil, fmt.Errorf("defaultUseCase.Complete: Failed to repeat item with ID %d: %v", itemId, err)
+		}
+	}
+	return completion, nil
+}
+
+// persistItemWithTags godoc
+//
+// Persist a new item along with its tags.
+//
+// Returns nil and error on error.
+//
+// Returns the persisted item, as read back from the database, and nil on success.
+func (uc *defaultUseCase) persistItemWithTags(ctx context.Context, item Item) (Item, error) {
+	itemId, err := uc.repository.PersistItem(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("persistItemWithTags: %v", err)
+	}
+	uc.captureCreated(itemId)
+	for _, tag := range item.GetTags() {
+		if _, err := uc.repository.AttachTag(ctx, itemId, tag); err != nil {
+			return nil, fmt.Errorf("persistItemWithTags: Failed to tag item with ID %d: %v", itemId, err)
+		}
+	}
+	persistedItem, err := uc.repository.FindItemById(ctx, itemId)
+	if err != nil {
+		return nil, fmt.Errorf("persistItemWithTags: Failed to find item with ID %d: %v", itemId, err)
+	}
+	return persistedItem, nil
+}
+
+// Tag godoc
+//
+// Attach a tag to a todo item by ID.
+//
+// Returns error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item does not
+// exist.
+//
+// Returns error wrapping ErrValidation when the tag is invalid.
+//
+// Returns error on error.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) Tag(ctx context.Context, itemId int64, tag string) error {
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		if err := txUseCase.capture(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.Tag: %v", err)
+		}
+		if err := txUseCase.tag(ctx, itemId, tag); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("tag item %d with '%s'", itemId, tag), nil
+	})
+}
+
+// tag godoc
+//
+// Implements Tag in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) tag(ctx context.Context, itemId int64, tag string) error {
+	if itemId <= 0 {
+		return fmt.Errorf("defaultUseCase.Tag: %w", newInvalidIdError(itemId))
+	}
+
+	normalizedTag, err := uc.domain.NormalizeTag(tag)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Tag: %w", err)
+	}
+
+	// Find item by ID
+	foundItem, err := uc.repository.FindItemById(ctx, itemId)
+	// Error occurred while finding item
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Tag: Failed to find item with ID %d: %v", itemId, err)
+	}
+	// Item not found
+	if foundItem == nil {
+		return fmt.Errorf("defaultUseCase.Tag: %w", &NotFoundError{ItemId: itemId})
+	}
+
+	if _, err := uc.repository.AttachTag(ctx, itemId, normalizedTag); err != nil {
+		return fmt.Errorf("defaultUseCase.Tag: Failed to tag item with ID %d: %v", itemId, err)
+	}
+
+	return nil
+}
+
+// Untag godoc
+//
+// Detach a tag from a todo item by ID.
+//
+// Returns error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item does not
+// exist or does not have the tag.
+//
+// Returns error wrapping ErrValidation when the tag is invalid.
+//
+// Returns error on error.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) Untag(ctx context.Context, itemId int64, tag string) error {
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		if err := txUseCase.capture(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.Untag: %v", err)
+		}
+		if err := txUseCase.untag(ctx, itemId, tag); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("remove tag '%s' from item %d", tag, itemId), nil
+	})
+}
+
+// untag godoc
+//
+// Implements Untag in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) untag(ctx context.Context, itemId int64, tag string) error {
+	if itemId <= 0 {
+		return fmt.Errorf("defaultUseCase.Untag: %w", newInvalidIdError(itemId))
+	}
+
+	normalizedTag, err := uc.domain.NormalizeTag(tag)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Untag: %w", err)
 	}
-	// Item not deleted as it does not exist
+
+	affectedRows, err := uc.repository.DetachTag(ctx, itemId, normalizedTag)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Untag: Failed to untag item with ID %d: %v", itemId, err)
+	}
+	// Item does not exist or does not have the tag
 	if affectedRows == 0 {
-		return -1, nil
+		return fmt.Errorf(
+			"defaultUseCase.Untag: %w: item %d does not exist or is not tagged '%s'", ErrNotFound, itemId, normalizedTag,
+		)
+	}
+
+	return nil
+}
+
+// ListTags godoc
+//
+// Get the names of the tags which are in use, sorted by name.
+//
+// Returns nil and error on error.
+//
+// Returns the tag names and nil on success.
+func (uc *defaultUseCase) ListTags(ctx context.Context) ([]string, error) {
+	tags, err := uc.repository.FindAllTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.ListTags: %v", err)
+	}
+	return tags, nil
+}
+
+// Search godoc
+//
+// Get the items whose name or notes match a full-text query, the most relevant first.
+//
+// Returns nil and error wrapping ErrValidation when the query is empty or malformed.
+//
+// Returns nil and error on error.
+//
+// Returns the matching items with their highlights and nil on success.
+func (uc *defaultUseCase) Search(ctx context.Context, query string) ([]SearchResult, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("defaultUseCase.Search: %w", newValidationError("search query", "cannot be empty"))
+	}
+	results, err := uc.repository.SearchItems(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Search: %w", err)
+	}
+	return results, nil
+}
+
+// Depend godoc
+//
+// Make a todo item depend on another todo item by ID, so that it is blocked until the other item is completed.
+//
+// Returns error wrapping ErrInvalidID when an ID is not positive, or wrapping ErrNotFound when one of the items does
+// not exist.
+//
+// Returns error wrapping ErrDependencyCycle when the other item already waits on the item.
+//
+// Returns error on error.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) Depend(ctx context.Context, itemId int64, dependsOnId int64) error {
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		if err := txUseCase.capture(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.Depend: %v", err)
+		}
+		if err := txUseCase.depend(ctx, itemId, dependsOnId); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("make item %d depend on item %d", itemId, dependsOnId), nil
+	})
+}
+
+// depend godoc
+//
+// Implements Depend in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) depend(ctx context.Context, itemId int64, dependsOnId int64) error {
+	for _, id := range []int64{itemId, dependsOnId} {
+		if id <= 0 {
+			return fmt.Errorf("defaultUseCase.Depend: %w", newInvalidIdError(id))
+		}
+	}
+
+	for _, id := range []int64{itemId, dependsOnId} {
+		foundItem, err := uc.repository.FindItemById(ctx, id)
+		// Error occurred while finding item
+		if err != nil {
+			return fmt.Errorf("defaultUseCase.Depend: Failed to find item with ID %d: %v", id, err)
+		}
+		// Item not found
+		if foundItem == nil {
+			return fmt.Errorf("defaultUseCase.Depend: %w", &NotFoundError{ItemId: id})
+		}
+	}
+
+	dependencies, err := uc.repository.FindAllDependencies(ctx)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Depend: %v", err)
+	}
+	dependency, err := uc.domain.CreateDependency(itemId, dependsOnId, dependencies)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Depend: %w", err)
+	}
+
+	if _, err := uc.repository.AddDependency(ctx, dependency); err != nil {
+		return fmt.Errorf("defaultUseCase.Depend: Failed to add dependency of item with ID %d: %v", itemId, err)
+	}
+	return nil
+}
+
+// Undepend godoc
+//
+// Remove the dependency of a todo item on another todo item by ID.
+//
+// Returns error wrapping ErrInvalidID when an ID is not positive, or wrapping ErrNotFound when the item does not
+// depend on the other item.
+//
+// Returns error on error.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) Undepend(ctx context.Context, itemId int64, dependsOnId int64) error {
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		if err := txUseCase.capture(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.Undepend: %v", err)
+		}
+		if err := txUseCase.undepend(ctx, itemId, dependsOnId); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("remove dependency of item %d on item %d", itemId, dependsOnId), nil
+	})
+}
+
+// undepend godoc
+//
+// Implements Undepend in the transaction of the use case, see journaled.
+func (uc *defaultUseCase) undepend(ctx context.Context, itemId int64, dependsOnId int64) error {
+	for _, id := range []int64{itemId, dependsOnId} {
+		if id <= 0 {
+			return fmt.Errorf("defaultUseCase.Undepend: %w", newInvalidIdError(id))
+		}
+	}
+
+	affectedRows, err := uc.repository.RemoveDependency(ctx, Dependency{ItemId: itemId, DependsOnId: dependsOnId})
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Undepend: Failed to remove dependency of item with ID %d: %v", itemId, err)
+	}
+	// Item does not depend on the other item
+	if affectedRows == 0 {
+		return fmt.Errorf(
+			"defaultUseCase.Undepend: %w: item %d does not depend on item %d", ErrNotFound, itemId, dependsOnId,
+		)
+	}
+	return nil
+}
+
+// EditNote godoc
+//
+// Edit the notes of a todo item by ID. The edit function receives the current notes and returns the new notes,
+// which are only saved when they differ from the current ones. The edit function runs before the transaction which
+// saves the notes starts, as it may wait for the user.
+//
+// Returns error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item does not
+// exist.
+//
+// Returns error on error, including errors returned by the edit function.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) EditNote(ctx context.Context, itemId int64, edit func(string) (string, error)) error {
+	if itemId <= 0 {
+		return fmt.Errorf("defaultUseCase.EditNote: %w", newInvalidIdError(itemId))
+	}
+
+	// Find item by ID
+	foundItem, err := uc.repository.FindItemById(ctx, itemId)
+	// Error occurred while finding item
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.EditNote: Failed to find item with ID %d: %v", itemId, err)
+	}
+	// Item not found
+	if foundItem == nil {
+		return fmt.Errorf("defaultUseCase.EditNote: %w", &NotFoundError{ItemId: itemId})
+	}
+
+	description, err := edit(foundItem.GetDescription())
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.EditNote: Failed to edit notes of item with ID %d: %v", itemId, err)
+	}
+	return uc.journaled(ctx, func(txUseCase *defaultUseCase) (string, error) {
+		if err := txUseCase.capture(ctx, itemId); err != nil {
+			return "", fmt.Errorf("defaultUseCase.EditNote: %v", err)
+		}
+		if err := txUseCase.saveNote(ctx, itemId, description); err != nil {
+			return "", err
+		}
+		return summarizeItems("edit the notes of", []int64{itemId}), nil
+	})
+}
+
+// saveNote godoc
+//
+// Saves the notes edited by EditNote in the transaction of the use case, unless they are unchanged.
+//
+// Returns error wrapping ErrNotFound when the item does not exist.
+//
+// Returns error on error.
+//
+// Returns nil on success.
+func (uc *defaultUseCase) saveNote(ctx context.Context, itemId int64, description string) error {
+	// Find item by ID
+	foundItem, err := uc.repository.FindItemById(ctx, itemId)
+	// Error occurred while finding item
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.EditNote: Failed to find item with ID %d: %v", itemId, err)
+	}
+	// Item removed while its notes were edited
+	if foundItem == nil {
+		return fmt.Errorf("defaultUseCase.EditNote: %w", &NotFoundError{ItemId: itemId})
+	}
+
+	previousDescription := foundItem.GetDescription()
+	updatedItem, err := uc.domain.UpdateItemDescription(description, foundItem)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.EditNote: Failed to update item with ID %d: %v", itemId, err)
+	}
+	// Notes unchanged, nothing to save
+	if updatedItem.GetDescription() == previousDescription {
+		return nil
+	}
+
+	// Update the item by its ID
+	affectedRows, err := uc.repository.UpdateItemById(ctx, updatedItem)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.EditNote: Failed to persists update for item with ID %d: %v", itemId, err)
+	}
+	if affectedRows == 0 {
+		return fmt.Errorf("defaultUseCase.EditNote: %w", &NotFoundError{ItemId: itemId})
+	}
+	return nil
+}
+
+// Get godoc
+//
+// Get a todo item by ID along with the IDs of its direct subtasks and of the items it depends on.
+//
+// Returns nil and error wrapping ErrInvalidID when the ID is not positive, or wrapping ErrNotFound when the item
+// does not exist.
+//
+// Returns nil and error on error.
+//
+// Returns the item details and nil on success.
+func (uc *defaultUseCase) Get(ctx context.Context, itemId int64) (*ItemDetails, error) {
+	if itemId <= 0 {
+		return nil, fmt.Errorf("defaultUseCase.Get: %w", newInvalidIdError(itemId))
+	}
+
+	// Find item by ID
+	foundItem, err := uc.repository.FindItemById(ctx, itemId)
+	// Error occurred while finding item
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Get: Failed to find item with ID %d: %v", itemId, err)
+	}
+	// Item not found
+	if foundItem == nil {
+		return nil, fmt.Errorf("defaultUseCase.Get: %w", &NotFoundError{ItemId: itemId})
+	}
+
+	details := ItemDetails{Item: foundItem}
+	subtasks, err := uc.repository.FindItems(ctx, ItemFilter{ParentId: itemId})
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Get: Failed to find subtasks of item with ID %d: %v", itemId, err)
+	}
+	for _, subtask := range subtasks {
+		details.SubtaskIds = append(details.SubtaskIds, subtask.GetId())
+	}
+	dependencies, err := uc.repository.FindAllDependencies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Get: %v", err)
+	}
+	for _, dependency := range dependencies {
+		if dependency.ItemId == itemId {
+			details.DependsOnIds = append(details.DependsOnIds, dependency.DependsOnId)
+		}
 	}
 
-	return itemId, nil
+	return &details, nil
 }
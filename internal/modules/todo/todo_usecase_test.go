@@ -1,12 +1,18 @@
 package todo
 
 import (
+	"context"
+	"fmt"
 	"github.com/rykeroc/todo-cli/internal/testutils"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
+// ctx is passed to every repository and use case call of the tests.
+var ctx = context.Background()
+
 func beforeEach(t *testing.T) (*testutils.TestFixture, UseCase) {
 	fixture := testutils.SetupTestFixture(t)
 	repository := NewSqliteRepository(fixture.Db)
@@ -43,11 +49,14 @@ func TestDefaultUseCase_Create(t *testing.T) {
 
 	t.Run("todo use case create", func(t *testing.T) {
 		for _, test := range testCases {
-			err := useCase.Create(test.name)
+			item, err := useCase.Create(ctx, ItemDraft{Name: test.name})
 			if test.expectError {
 				assert.Error(t, err)
+				assert.Nil(t, item)
 			} else {
 				assert.NoError(t, err)
+				assert.NotZero(t, item.GetId())
+				assert.Equal(t, test.name, item.GetName())
 			}
 		}
 	})
@@ -58,16 +67,23 @@ func TestDefaultUseCase_List(t *testing.T) {
 	defer afterEach(fixture)
 
 	type testCase struct {
+		options     ListOptions
 		expectError bool
 	}
 
 	testCases := []testCase{
-		{expectError: false},
+		{options: ListOptions{}, expectError: false},
+		{options: ListOptions{Due: DueFilterOverdue}, expectError: false},
+		{options: ListOptions{Tags: []string{"backend"}, ExcludedTags: []string{"blocked"}}, expectError: false},
+		{options: ListOptions{Due: "someday"}, expectError: true},
+		{options: ListOptions{Tags: []string{"not valid"}}, expectError: true},
+		{options: ListOptions{Filters: []string{"status:open", "", "tag:backend or due<+7d"}}, expectError: false},
+		{options: ListOptions{Filters: []string{"status:open", "status:"}}, expectError: true},
 	}
 
 	t.Run("todo use case list", func(t *testing.T) {
 		for _, test := range testCases {
-			err := useCase.List()
+			_, err := useCase.List(ctx, test.options)
 			if test.expectError {
 				assert.Error(t, err)
 			} else {
@@ -82,39 +98,419 @@ func TestDefaultUseCase_Remove(t *testing.T) {
 	defer afterEach(fixture)
 
 	type testCase struct {
-		itemId         int64
-		expectedItemId int64
-		expectError    bool
+		itemId      int64
+		expectedErr error
 	}
 
 	testCases := []testCase{
 		{
-			itemId:         1,
-			expectedItemId: 1,
-			expectError:    false,
+			itemId:      1,
+			expectedErr: nil,
+		},
+		{
+			itemId:      1,
+			expectedErr: ErrNotFound,
+		},
+		{
+			itemId:      100,
+			expectedErr: ErrNotFound,
 		},
 		{
-			itemId:         100,
-			expectedItemId: -1,
-			expectError:    false,
+			itemId:      0,
+			expectedErr: ErrInvalidID,
 		},
 	}
 
-	// Insert test item for test case 1
-	err := useCase.Create("item")
+	// Insert test item for test case 1, which is in the trash for test case 2
+	_, err := useCase.Create(ctx, ItemDraft{Name: "item"})
 	if err != nil {
 		log.Fatalf("TestDefaultUseCase_Remove: Error inserting item: %v", err)
 	}
 
 	t.Run("todo use case remove", func(t *testing.T) {
 		for _, test := range testCases {
-			deletedId, err := useCase.Remove(test.itemId)
-			if test.expectError {
-				assert.Error(t, err)
+			err := useCase.Remove(ctx, test.itemId)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		}
+	})
+}
+
+func TestDefaultUseCase_Update(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	newName := "new name"
+	dueAt := time.Now().Add(time.Hour)
+	noDueAt := time.Time{}
+	priority := PriorityHigh
+	unknownPriority := Priority(9)
+
+	type testCase struct {
+		itemId      int64
+		changes     ItemChanges
+		expectedErr error
+	}
+
+	testCases := []testCase{
+		{
+			itemId:      1,
+			changes:     ItemChanges{Name: &newName},
+			expectedErr: nil,
+		},
+		{
+			itemId:      1,
+			changes:     ItemChanges{DueAt: &dueAt},
+			expectedErr: nil,
+		},
+		{
+			itemId:      1,
+			changes:     ItemChanges{DueAt: &noDueAt},
+			expectedErr: nil,
+		},
+		{
+			itemId:      1,
+			changes:     ItemChanges{Priority: &priority},
+			expectedErr: nil,
+		},
+		{
+			itemId:      1,
+			changes:     ItemChanges{Priority: &unknownPriority},
+			expectedErr: ErrValidation,
+		},
+		{
+			itemId:      1,
+			changes:     ItemChanges{},
+			expectedErr: ErrValidation,
+		},
+		{
+			itemId:      100,
+			changes:     ItemChanges{Name: &newName},
+			expectedErr: ErrNotFound,
+		},
+		{
+			itemId:      -1,
+			changes:     ItemChanges{Name: &newName},
+			expectedErr: ErrInvalidID,
+		},
+	}
+
+	// Insert test item
+	_, err := useCase.Create(ctx, ItemDraft{Name: "item"})
+	if err != nil {
+		log.Fatalf("TestDefaultUseCase_Update: Error inserting item: %v", err)
+	}
+
+	t.Run("todo use case update", func(t *testing.T) {
+		for _, test := range testCases {
+			err := useCase.Update(ctx, test.itemId, test.changes)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
 			} else {
 				assert.NoError(t, err)
 			}
-			assert.Equal(t, test.expectedItemId, deletedId)
+		}
+	})
+}
+
+func TestDefaultUseCase_Tag(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	// Insert test item
+	_, err := useCase.Create(ctx, ItemDraft{Name: "item", Tags: []string{"backend"}})
+	if err != nil {
+		log.Fatalf("TestDefaultUseCase_Tag: Error inserting item: %v", err)
+	}
+
+	t.Run("todo use case tag", func(t *testing.T) {
+		assert.NoError(t, useCase.Tag(ctx, 1, "Urgent"))
+		assert.ErrorIs(t, useCase.Tag(ctx, 100, "urgent"), ErrNotFound)
+
+		var validationErr *ValidationError
+		assert.ErrorAs(t, useCase.Tag(ctx, 1, "not valid"), &validationErr)
+		assert.Equal(t, "tag", validationErr.Field)
+	})
+
+	t.Run("todo use case untag", func(t *testing.T) {
+		assert.NoError(t, useCase.Untag(ctx, 1, "urgent"))
+		assert.ErrorIs(t, useCase.Untag(ctx, 1, "urgent"), ErrNotFound)
+	})
+
+	t.Run("todo use case list tags", func(t *testing.T) {
+		tags, err := useCase.ListTags(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"backend"}, tags)
+	})
+}
+
+func TestDefaultUseCase_Subtasks(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer afterEach(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+	refusingUseCase := NewUseCase(NewDomain(), repository)
+	cascadingUseCase := NewUseCaseWithCompletionPolicy(NewDomain(), repository, CompletionPolicyCascade)
+
+	for _, draft := range []ItemDraft{{Name: "parent"}, {Name: "child", ParentId: 1}, {Name: "grandchild", ParentId: 2}} {
+		if _, err := refusingUseCase.Create(ctx, draft); err != nil {
+			log.Fatalf("TestDefaultUseCase_Subtasks: Error inserting item: %v", err)
+		}
+	}
+
+	t.Run("should refuse subtasks of missing items", func(t *testing.T) {
+		_, err := refusingUseCase.Create(ctx, ItemDraft{Name: "orphan", ParentId: 100})
+		assert.Error(t, err)
+
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		parentId := int64(100)
+		err = refusingUseCase.Update(ctx, 1, ItemChanges{ParentId: &parentId})
+		var notFoundErr *NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+		assert.Equal(t, int64(100), notFoundErr.ItemId)
+	})
+
+	t.Run("should refuse cycles", func(t *testing.T) {
+		parentId := int64(3)
+		err := refusingUseCase.Update(ctx, 1, ItemChanges{ParentId: &parentId})
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "parent", validationErr.Field)
+	})
+
+	t.Run("should refuse to complete item with open subtasks", func(t *testing.T) {
+		completion, err := refusingUseCase.Complete(ctx, 1)
+		assert.ErrorIs(t, err, ErrOpenSubtasks)
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Nil(t, completion)
+	})
+
+	t.Run("should complete open subtasks along with the item", func(t *testing.T) {
+		completion, err := cascadingUseCase.Complete(ctx, 2)
+		assert.NoError(t, err)
+		assert.Len(t, completion.Items, 2)
+		assert.Equal(t, int64(2), completion.Items[0].GetId())
+		assert.Nil(t, completion.NextOccurrence)
+
+		grandchild, err := repository.FindItemById(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusDone, grandchild.GetStatus())
+
+		// Every subtask is completed now
+		completion, err = refusingUseCase.Complete(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), completion.Items[0].GetId())
+
+		completion, err = refusingUseCase.Complete(ctx, 100)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, completion)
+	})
+
+	t.Run("should apply the completion policy when the status is updated to done", func(t *testing.T) {
+		for _, draft := range []ItemDraft{{Name: "release"}, {Name: "changelog", ParentId: 4}} {
+			if _, err := refusingUseCase.Create(ctx, draft); err != nil {
+				t.Fatalf("TestDefaultUseCase_Subtasks: %v", err)
+			}
+		}
+		done := StatusDone
+
+		err := refusingUseCase.Update(ctx, 4, ItemChanges{Status: &done})
+		assert.ErrorIs(t, err, ErrOpenSubtasks)
+		parent, err := repository.FindItemById(ctx, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusTodo, parent.GetStatus())
+
+		assert.NoError(t, cascadingUseCase.Update(ctx, 4, ItemChanges{Status: &done}))
+		child, err := repository.FindItemById(ctx, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusDone, child.GetStatus())
+	})
+
+	t.Run("should make a subtask a top level item", func(t *testing.T) {
+		parentId := int64(0)
+		assert.NoError(t, refusingUseCase.Update(ctx, 3, ItemChanges{ParentId: &parentId}))
+	})
+}
+
+func TestDefaultUseCase_Depend(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	for _, name := range []string{"build", "deploy"} {
+		if _, err := useCase.Create(ctx, ItemDraft{Name: name}); err != nil {
+			log.Fatalf("TestDefaultUseCase_Depend: Error inserting item: %v", err)
+		}
+	}
+
+	t.Run("todo use case depend", func(t *testing.T) {
+		assert.NoError(t, useCase.Depend(ctx, 2, 1))
+
+		err := useCase.Depend(ctx, 1, 2)
+		assert.ErrorIs(t, err, ErrDependencyCycle)
+		assert.ErrorIs(t, err, ErrConflict)
+
+		assert.ErrorIs(t, useCase.Depend(ctx, 2, 100), ErrNotFound)
+		assert.ErrorIs(t, useCase.Depend(ctx, 0, 1), ErrInvalidID)
+
+		items, err := useCase.List(ctx, ListOptions{Ready: true})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, int64(1), items[0].GetId())
+	})
+
+	t.Run("todo use case undepend", func(t *testing.T) {
+		assert.NoError(t, useCase.Undepend(ctx, 2, 1))
+		assert.ErrorIs(t, useCase.Undepend(ctx, 2, 1), ErrNotFound)
+	})
+}
+
+func TestDefaultUseCase_CompleteRecurring(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer afterEach(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+	useCase := NewUseCase(NewDomain(), repository)
+
+	_, err := useCase.Create(ctx, ItemDraft{Name: "Release notes", Tags: []string{"chores"}, Recurrence: "weekly"})
+	if err != nil {
+		log.Fatalf("TestDefaultUseCase_CompleteRecurring: Error inserting item: %v", err)
+	}
+
+	t.Run("should create the next occurrence once", func(t *testing.T) {
+		completion, err := useCase.Complete(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), completion.NextOccurrence.GetId())
+		assert.Equal(t, []string{"chores"}, completion.NextOccurrence.GetTags())
+
+		completion, err = useCase.Complete(ctx, 1)
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.Nil(t, completion)
+
+		// The series moved to the next occurrence, so completing the reopened item does not repeat it again
+		_, err = useCase.Reopen(ctx, 1)
+		assert.NoError(t, err)
+		completion, err = useCase.Complete(ctx, 1)
+		assert.NoError(t, err)
+		assert.Nil(t, completion.NextOccurrence)
+
+		items, err := repository.FindItems(ctx, ItemFilter{RecurringOnly: true})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, int64(2), items[0].GetId())
+		assert.Equal(t, StatusTodo, items[0].GetStatus())
+		assert.Equal(t, []string{"chores"}, items[0].GetTags())
+		assert.False(t, items[0].GetDueAt().IsZero())
+
+		listed, err := useCase.List(ctx, ListOptions{Recurring: true})
+		assert.NoError(t, err)
+		assert.Len(t, listed, 1)
+	})
+
+	t.Run("should create the next occurrence when the status is updated to done", func(t *testing.T) {
+		done := StatusDone
+		assert.NoError(t, useCase.Update(ctx, 2, ItemChanges{Status: &done}))
+
+		items, err := repository.FindItems(ctx, ItemFilter{RecurringOnly: true})
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, int64(3), items[0].GetId())
+		assert.Equal(t, StatusTodo, items[0].GetStatus())
+
+		// The next occurrence is undone along with the update
+		_, err = useCase.Undo(ctx)
+		assert.NoError(t, err)
+		items, err = repository.FindAllItems(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+	})
+
+	t.Run("should stop the series", func(t *testing.T) {
+		stop := ""
+		assert.NoError(t, useCase.Update(ctx, 2, ItemChanges{Recurrence: &stop}))
+
+		completion, err := useCase.Complete(ctx, 2)
+		assert.NoError(t, err)
+		assert.Nil(t, completion.NextOccurrence)
+
+		items, err := repository.FindAllItems(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+	})
+}
+
+func TestDefaultUseCase_EditNote(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer afterEach(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+	useCase := NewUseCase(NewDomain(), repository)
+
+	if _, err := useCase.Create(ctx, ItemDraft{Name: "item", Description: "first"}); err != nil {
+		log.Fatalf("TestDefaultUseCase_EditNote: Error inserting item: %v", err)
+	}
+
+	t.Run("should pass the current notes and save the edited notes", func(t *testing.T) {
+		var current string
+		err := useCase.EditNote(ctx, 1, func(notes string) (string, error) {
+			current = notes
+			return notes + "\nsecond\n", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "first", current)
+
+		item, err := repository.FindItemById(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "first\nsecond", item.GetDescription())
+	})
+
+	t.Run("should return error when editing fails", func(t *testing.T) {
+		err := useCase.EditNote(ctx, 1, func(notes string) (string, error) {
+			return "", fmt.Errorf("editor crashed")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("should return error when item does not exist", func(t *testing.T) {
+		err := useCase.EditNote(ctx, 100, func(notes string) (string, error) {
+			return notes, nil
+		})
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("should get item", func(t *testing.T) {
+		details, err := useCase.Get(ctx, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), details.Item.GetId())
+		assert.Empty(t, details.SubtaskIds)
+		assert.Empty(t, details.DependsOnIds)
+
+		details, err = useCase.Get(ctx, 100)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, details)
+	})
+}
+
+func TestDefaultUseCase_Search(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	if _, err := useCase.Create(ctx, ItemDraft{Name: "Deploy", Description: "Check the staging logs"}); err != nil {
+		log.Fatalf("TestDefaultUseCase_Search: Error inserting item: %v", err)
+	}
+
+	t.Run("should find items by their notes", func(t *testing.T) {
+		results, err := useCase.Search(ctx, "logs")
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Deploy", results[0].Item.GetName())
+	})
+
+	t.Run("should return ErrValidation when the query is empty or malformed", func(t *testing.T) {
+		for _, query := range []string{" ", `"logs`} {
+			results, err := useCase.Search(ctx, query)
+			assert.ErrorIs(t, err, ErrValidation, query)
+			assert.Nil(t, results)
 		}
 	})
 }
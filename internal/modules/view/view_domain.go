@@ -0,0 +1,163 @@
+package view
+
+import (
+	"fmt"
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"github.com/rykeroc/todo-cli/internal/dateparse"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"regexp"
+	"strings"
+)
+
+// DefaultStaleDays godoc
+//
+// Number of days without an update after which an open item is listed by the stale view.
+const DefaultStaleDays = 14
+
+// namePattern godoc
+//
+// View names are typed as `--view` values, so they are restricted to characters which need no quoting.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Domain godoc
+//
+// An interface that defines the behaviour for a view domain service struct.
+type Domain interface {
+	CreateView(string, string, string, string) (View, error)
+	UpdateView(string, string, string, View) (View, error)
+	BuiltInViews() []View
+}
+
+// defaultDomain godoc
+//
+// A structure which adheres to the Domain interface.
+type defaultDomain struct {
+	clock     clock.Clock
+	staleDays int
+}
+
+// NewDomain godoc
+//
+// Creates a new view Domain instance which uses the system clock and DefaultStaleDays.
+func NewDomain() Domain {
+	return NewDomainWithStaleDays(DefaultStaleDays)
+}
+
+// NewDomainWithStaleDays godoc
+//
+// Creates a new view Domain instance which uses the system clock and whose stale view lists the open items which
+// were not updated in staleDays days.
+func NewDomainWithStaleDays(staleDays int) Domain {
+	return &defaultDomain{
+		clock:     clock.NewSystemClock(),
+		staleDays: staleDays,
+	}
+}
+
+// NewDomainWithClock godoc
+//
+// Creates a new view Domain instance which reads the current time from the passed in clock.
+func NewDomainWithClock(c clock.Clock) Domain {
+	return &defaultDomain{
+		clock:     c,
+		staleDays: DefaultStaleDays,
+	}
+}
+
+// CreateView godoc
+//
+// Creates a new View instance with a filter expression, a sort order and the columns of its table and returns it.
+//
+// Returns nil and error wrapping ErrInvalidName, ErrBuiltIn or ErrInvalidView when the view cannot be saved.
+//
+// Returns a new View and nil on success.
+func (d *defaultDomain) CreateView(name string, filter string, sort string, columns string) (View, error) {
+	normalizedName := strings.TrimSpace(name)
+	if !namePattern.MatchString(normalizedName) {
+		return nil, fmt.Errorf(
+			"CreateView: %w '%s', use lower case letters, digits, dashes and underscores", ErrInvalidName, name,
+		)
+	}
+	for _, builtIn := range d.BuiltInViews() {
+		if builtIn.GetName() == normalizedName {
+			return nil, fmt.Errorf("CreateView: %w: '%s'", ErrBuiltIn, normalizedName)
+		}
+	}
+
+	nowTime := d.clock.Now()
+	view, err := d.UpdateView(filter, sort, columns, NewView(0, normalizedName, "", "", nowTime, nowTime))
+	if err != nil {
+		return nil, fmt.Errorf("CreateView: %w", err)
+	}
+	return view, nil
+}
+
+// UpdateView godoc
+//
+// Replaces the filter expression, the sort order and the columns of a view.
+//
+// Returns nil and error wrapping ErrInvalidView when the filter expression, the sort order or the columns are
+// invalid.
+//
+// Returns nil and error when the view is nil.
+//
+// Returns the updated view and nil on success.
+func (d *defaultDomain) UpdateView(filter string, sort string, columns string, view View) (View, error) {
+	if view == nil {
+		return nil, fmt.Errorf("UpdateView: view is nil")
+	}
+	filter = strings.TrimSpace(filter)
+	if filter != "" {
+		if _, err := todo.ParseFilter(filter, dateparse.NewParser(d.clock)); err != nil {
+			return nil, fmt.Errorf("UpdateView: %w: %w", ErrInvalidView, err)
+		}
+	}
+	sort = strings.TrimSpace(sort)
+	if sort != "" {
+		sortKeys, err := todo.ParseSortKeys(sort)
+		if err != nil {
+			return nil, fmt.Errorf("UpdateView: %w: %w", ErrInvalidView, err)
+		}
+		sort = todo.FormatSortKeys(sortKeys)
+	}
+	columns = strings.TrimSpace(columns)
+	if columns != "" {
+		itemColumns, err := todo.ParseItemColumns(columns)
+		if err != nil {
+			return nil, fmt.Errorf("UpdateView: %w: %w", ErrInvalidView, err)
+		}
+		columnNames := make([]string, 0, len(itemColumns))
+		for _, column := range itemColumns {
+			columnNames = append(columnNames, string(column))
+		}
+		columns = strings.Join(columnNames, ",")
+	}
+
+	view.SetFilter(filter)
+	view.SetSort(sort)
+	view.SetColumns(columns)
+	view.SetUpdatedAt(d.clock.Now())
+	return view, nil
+}
+
+// BuiltInViews godoc
+//
+// Returns the views which are provided by the app, in the order they are documented.
+func (d *defaultDomain) BuiltInViews() []View {
+	return []View{
+		newBuiltInView("today", "Open items due today", "status:open and due:today", string(todo.SortOrderPriority)),
+		newBuiltInView("overdue", "Open items past their due date", "status:open and due<now", string(todo.SortOrderDue)),
+		newBuiltInView(
+			"upcoming", "Open items due in the next 7 days, after today", "status:open and due>today and due<=+7d",
+			string(todo.SortOrderDue),
+		),
+		newBuiltInView(
+			"completed", "Items completed this week", `status:done and updated>="this week"`,
+			string(todo.SortOrderUpdated),
+		),
+		newBuiltInView(
+			"stale", fmt.Sprintf("Open items not updated in %d days", d.staleDays),
+			fmt.Sprintf("status:open and updated<-%dd", d.staleDays), string(todo.SortOrderUpdated),
+		),
+	}
+}
@@ -0,0 +1,110 @@
+package view
+
+import (
+	"errors"
+	"github.com/rykeroc/todo-cli/internal/clock"
+	"github.com/rykeroc/todo-cli/internal/dateparse"
+	"github.com/rykeroc/todo-cli/internal/modules/todo"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, time.October, 14, 10, 30, 0, 0, time.Local)
+
+var domain = NewDomainWithClock(clock.NewFixedClock(testNow))
+
+func TestDefaultDomain_CreateView(t *testing.T) {
+	t.Run("should create a view", func(t *testing.T) {
+		view, err := domain.CreateView(" mine ", " tag:backend and due<+7d ", "DUE", "ID, name,due")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "mine", view.GetName())
+		assert.Equal(t, "tag:backend and due<+7d", view.GetFilter())
+		assert.Equal(t, "due", view.GetSort())
+		assert.Equal(t, "id,name,due", view.GetColumns())
+		assert.Equal(t, testNow, view.GetCreatedAt())
+		assert.Equal(t, testNow, view.GetUpdatedAt())
+	})
+
+	t.Run("should normalize a sort specification with several fields", func(t *testing.T) {
+		view, err := domain.CreateView("recent", "", "Updated:DESC, name:desc", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "updated,name:desc", view.GetSort())
+	})
+
+	t.Run("should create a view without filter or sort", func(t *testing.T) {
+		view, err := domain.CreateView("everything", "", "", "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, view.GetFilter())
+		assert.Empty(t, view.GetSort())
+		assert.Empty(t, view.GetColumns())
+	})
+
+	t.Run("should return error because of an invalid name", func(t *testing.T) {
+		for _, name := range []string{"", "My View", "-mine", "Mine"} {
+			view, err := domain.CreateView(name, "", "", "")
+			assert.Nil(t, view)
+			assert.ErrorIs(t, err, ErrInvalidName, name)
+		}
+	})
+
+	t.Run("should return error because of a built-in name", func(t *testing.T) {
+		view, err := domain.CreateView("today", "tag:a", "", "")
+
+		assert.Nil(t, view)
+		assert.ErrorIs(t, err, ErrBuiltIn)
+	})
+
+	t.Run("should return error because of an invalid filter", func(t *testing.T) {
+		view, err := domain.CreateView("mine", "status:", "", "")
+
+		assert.Nil(t, view)
+		assert.ErrorIs(t, err, ErrInvalidView)
+		var filterErr *todo.FilterError
+		assert.True(t, errors.As(err, &filterErr))
+	})
+
+	t.Run("should return error because of an invalid sort order", func(t *testing.T) {
+		view, err := domain.CreateView("mine", "", "random", "")
+
+		assert.Nil(t, view)
+		assert.ErrorIs(t, err, ErrInvalidView)
+	})
+
+	t.Run("should return error because of invalid columns", func(t *testing.T) {
+		for _, columns := range []string{"id,owner", "id,name,id"} {
+			view, err := domain.CreateView("mine", "", "", columns)
+			assert.Nil(t, view)
+			assert.ErrorIs(t, err, ErrInvalidView, columns)
+			assert.ErrorIs(t, err, todo.ErrValidation, columns)
+		}
+	})
+}
+
+func TestDefaultDomain_BuiltInViews(t *testing.T) {
+	t.Run("should return valid built-in views", func(t *testing.T) {
+		var names []string
+		for _, view := range domain.BuiltInViews() {
+			names = append(names, view.GetName())
+			assert.True(t, view.IsBuiltIn())
+			assert.NotEmpty(t, view.GetDescription())
+
+			_, err := todo.ParseFilter(view.GetFilter(), dateparse.NewParser(clock.NewFixedClock(testNow)))
+			assert.NoError(t, err, view.GetName())
+			_, err = todo.ParseSortKeys(view.GetSort())
+			assert.NoError(t, err, view.GetName())
+		}
+		assert.Equal(t, []string{"today", "overdue", "upcoming", "completed", "stale"}, names)
+	})
+
+	t.Run("should list the items not updated in the stale days", func(t *testing.T) {
+		views := NewDomainWithStaleDays(30).BuiltInViews()
+		stale := views[len(views)-1]
+
+		assert.Equal(t, "status:open and updated<-30d", stale.GetFilter())
+		assert.Equal(t, "Open items not updated in 30 days", stale.GetDescription())
+	})
+}
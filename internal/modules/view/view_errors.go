@@ -0,0 +1,24 @@
+package view
+
+import "errors"
+
+// ErrNotFound godoc
+//
+// Returned when no built-in or saved view exists with a name.
+var ErrNotFound = errors.New("view not found")
+
+// ErrBuiltIn godoc
+//
+// Returned when a built-in view is saved over or deleted.
+var ErrBuiltIn = errors.New("view is built in")
+
+// ErrInvalidName godoc
+//
+// Returned when a view name is not made of lower case letters, digits, dashes and underscores.
+var ErrInvalidName = errors.New("invalid view name")
+
+// ErrInvalidView godoc
+//
+// Returned when the filter expression or the sort order of a saved view is invalid. The error of an invalid filter
+// expression also wraps the *todo.FilterError.
+var ErrInvalidView = errors.New("invalid view")
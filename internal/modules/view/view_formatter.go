@@ -0,0 +1,76 @@
+package view
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+)
+
+// FormatViewTable godoc
+//
+// Returns a string representation of a tabular list of the views that are passed in.
+//
+// Returns empty string and error on error writing the list with the tab writer.
+//
+// Returns "No views..." and nil when views is an empty slice.
+//
+// Returns views in a tabular format and nil on success.
+func FormatViewTable(views []View) (string, error) {
+	if len(views) == 0 {
+		return fmt.Sprintf("No views...\n"), nil
+	}
+
+	var buffer bytes.Buffer
+
+	padding := 4
+	tabWidth := 4
+	tw := tabwriter.NewWriter(&buffer, 0, tabWidth, padding, ' ', 0)
+
+	// Write header to the tabWriter
+	_, err := fmt.Fprintln(tw, "Name\tFilter\tSort\tColumns\tDescription")
+	if err != nil {
+		return "", fmt.Errorf("FormatViewTable: Error writing table header to tabWriter: %v", err)
+	}
+
+	// Write separator
+	_, err = fmt.Fprintln(tw, "----\t------\t----\t-------\t-----------")
+	if err != nil {
+		return "", fmt.Errorf("FormatViewTable: Error writing table header to tabWriter: %v", err)
+	}
+
+	for _, view := range views {
+		description := view.GetDescription()
+		if !view.IsBuiltIn() {
+			description = "Saved view"
+		}
+		_, err := fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\n",
+			view.GetName(),
+			orDash(view.GetFilter()),
+			orDash(view.GetSort()),
+			orDash(view.GetColumns()),
+			description,
+		)
+		if err != nil {
+			return "", fmt.Errorf("FormatViewTable: Error writing view '%s': %v", view.GetName(), err)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return "", fmt.Errorf(
+			"FormatViewTable: Failed to flush tabWriter: %v", err,
+		)
+	}
+	return buffer.String(), nil
+}
+
+// orDash godoc
+//
+// Returns the value, or "-" when it is empty.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
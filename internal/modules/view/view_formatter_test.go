@@ -0,0 +1,27 @@
+package view
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFormatViewTable(t *testing.T) {
+	t.Run("should return tabular list", func(t *testing.T) {
+		mine := NewView(1, "mine", "", "due", testNow, testNow)
+		mine.SetColumns("id,name")
+		views := append(domain.BuiltInViews()[:1], mine)
+
+		result, err := FormatViewTable(views)
+
+		assert.NoError(t, err)
+		assert.Contains(t, result, "today    status:open and due:today    priority    -          Open items due today\n")
+		assert.Contains(t, result, "mine     -                            due         id,name    Saved view\n")
+	})
+
+	t.Run("should return 'No views...' when views is empty", func(t *testing.T) {
+		result, err := FormatViewTable(nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "No views...\n", result)
+	})
+}
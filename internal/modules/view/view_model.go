@@ -0,0 +1,194 @@
+package view
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// View godoc
+//
+// Defines an interface for a view, a named filter expression, sort order and table columns of `todo list`, with
+// getters and setters for encapsulation purposes.
+type View interface {
+	GetId() int64
+	GetName() string
+	GetDescription() string
+	GetFilter() string
+	SetFilter(string)
+	GetSort() string
+	SetSort(string)
+	GetColumns() string
+	SetColumns(string)
+	IsBuiltIn() bool
+	GetUpdatedAt() time.Time
+	SetUpdatedAt(time.Time)
+	GetCreatedAt() time.Time
+}
+
+// view godoc
+//
+// Defines a view structure.
+//
+// Implements the View interface.
+type view struct {
+	id          int64
+	name        string
+	description string
+	filter      string
+	sort        string
+	columns     string
+	builtIn     bool
+	updatedAt   time.Time
+	createdAt   time.Time
+}
+
+// NewView godoc
+//
+// Create a new instance of view which adheres to the View interface.
+//
+// filter is a filter expression of the todo items and sort a sort specification such as `due:desc,name`, both may
+// be empty. The view shows every column of the table until SetColumns is called.
+func NewView(
+	id int64,
+	name string,
+	filter string,
+	sort string,
+	updatedAt time.Time,
+	createdAt time.Time,
+) View {
+	return &view{
+		id:        id,
+		name:      name,
+		filter:    filter,
+		sort:      sort,
+		updatedAt: updatedAt,
+		createdAt: createdAt,
+	}
+}
+
+// newBuiltInView godoc
+//
+// Create a new instance of view for a view which is provided by the app rather than saved in the database.
+func newBuiltInView(name string, description string, filter string, sort string) View {
+	return &view{
+		name:        name,
+		description: description,
+		filter:      filter,
+		sort:        sort,
+		builtIn:     true,
+	}
+}
+
+// NewViewFromRow godoc
+//
+// Create a new instance of view by scanning a sql.Rows struct.
+//
+// Returns nil and error on error.
+//
+// Return a new View and nil on success.
+func NewViewFromRow(rows *sql.Rows) (View, error) {
+	var view view
+	var updatedAtTimestamp, createdAtTimestamp int64
+
+	err := rows.Scan(
+		&view.id, &view.name, &view.filter, &view.sort, &view.columns, &updatedAtTimestamp, &createdAtTimestamp,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("NewViewFromRow: %v", err)
+	}
+	view.updatedAt = time.Unix(updatedAtTimestamp, 0)
+	view.createdAt = time.Unix(createdAtTimestamp, 0)
+	return &view, nil
+}
+
+// GetId godoc
+//
+// Returns the ID of the view, 0 for built-in views.
+func (view *view) GetId() int64 {
+	return view.id
+}
+
+// GetName godoc
+//
+// Returns the name of the view.
+func (view *view) GetName() string {
+	return view.name
+}
+
+// GetDescription godoc
+//
+// Returns what a built-in view shows. Saved views have no description.
+func (view *view) GetDescription() string {
+	return view.description
+}
+
+// GetFilter godoc
+//
+// Returns the filter expression of the view, empty when the view shows every item.
+func (view *view) GetFilter() string {
+	return view.filter
+}
+
+// SetFilter godoc
+//
+// Sets the filter expression of the view.
+func (view *view) SetFilter(filter string) {
+	view.filter = filter
+}
+
+// GetSort godoc
+//
+// Returns the sort order of the view, empty when the view uses the configured sort order.
+func (view *view) GetSort() string {
+	return view.sort
+}
+
+// SetSort godoc
+//
+// Sets the sort order of the view.
+func (view *view) SetSort(sort string) {
+	view.sort = sort
+}
+
+// GetColumns godoc
+//
+// Returns the columns of the table of the view, such as `id,name,due`, empty when the view uses every column.
+func (view *view) GetColumns() string {
+	return view.columns
+}
+
+// SetColumns godoc
+//
+// Sets the columns of the table of the view.
+func (view *view) SetColumns(columns string) {
+	view.columns = columns
+}
+
+// IsBuiltIn godoc
+//
+// Returns true when the view is provided by the app rather than saved in the database.
+func (view *view) IsBuiltIn() bool {
+	return view.builtIn
+}
+
+// GetUpdatedAt godoc
+//
+// Returns the time that the view was last saved. The zero time is returned for built-in views.
+func (view *view) GetUpdatedAt() time.Time {
+	return view.updatedAt
+}
+
+// SetUpdatedAt godoc
+//
+// Sets the updated at time of the view.
+func (view *view) SetUpdatedAt(time time.Time) {
+	view.updatedAt = time
+}
+
+// GetCreatedAt godoc
+//
+// Returns the time that the view was first saved. The zero time is returned for built-in views.
+func (view *view) GetCreatedAt() time.Time {
+	return view.createdAt
+}
@@ -0,0 +1,32 @@
+package view
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewView(t *testing.T) {
+	var id int64 = 1
+	updatedAt := time.Now()
+	createdAt := updatedAt
+
+	view := NewView(id, "mine", "tag:backend", "due", updatedAt, createdAt)
+
+	assert.Equal(t, id, view.GetId())
+	assert.Equal(t, "mine", view.GetName())
+	assert.Equal(t, "tag:backend", view.GetFilter())
+	assert.Equal(t, "due", view.GetSort())
+	assert.Empty(t, view.GetColumns())
+	assert.False(t, view.IsBuiltIn())
+	assert.Empty(t, view.GetDescription())
+	assert.Equal(t, updatedAt, view.GetUpdatedAt())
+	assert.Equal(t, createdAt, view.GetCreatedAt())
+
+	view.SetFilter("status:open")
+	view.SetSort("")
+	view.SetColumns("id,name")
+	assert.Equal(t, "status:open", view.GetFilter())
+	assert.Empty(t, view.GetSort())
+	assert.Equal(t, "id,name", view.GetColumns())
+}
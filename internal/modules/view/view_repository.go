@@ -0,0 +1,235 @@
+package view
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+)
+
+// Repository godoc
+//
+// Define a repository for a collection of saved View.
+type Repository interface {
+	PersistView(context.Context, View) (int64, error)
+	FindAllViews(context.Context) ([]View, error)
+	FindViewByName(context.Context, string) (View, error)
+	UpdateViewById(context.Context, View) (int64, error)
+	DeleteViewById(context.Context, int64) (int64, error)
+}
+
+// sqliteRepository godoc
+//
+// Define a repository for a collection of View that adheres to Repository.
+type sqliteRepository struct {
+	db *sql.DB
+}
+
+// NewSqliteRepository godoc
+// Create a new instance of sqliteRepository that adheres to Repository.
+func NewSqliteRepository(db *sql.DB) Repository {
+	return &sqliteRepository{
+		db: db,
+	}
+}
+
+// tableName godoc
+//
+// Name for the database table which hold the saved views.
+const tableName = "views"
+
+// viewColumns godoc
+//
+// Columns selected for a view, in the order expected by NewViewFromRow.
+const viewColumns = "id, name, filterExpression, sortOrder, itemColumns, updatedAt, createdAt"
+
+// PersistView godoc
+//
+// Adds a View to the database.
+//
+// Returns -1 and an error on error.
+//
+// Returns ID (Greater than 0) of inserted view and nil on success.
+func (repo *sqliteRepository) PersistView(ctx context.Context, viewToPersist View) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("PersistView: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (name, filterExpression, sortOrder, itemColumns, updatedAt, createdAt) "+
+			"VALUES (?, ?, ?, ?, ?, ?)",
+		tableName,
+	)
+	result, err := repo.db.ExecContext(
+		ctx,
+		query,
+		viewToPersist.GetName(),
+		viewToPersist.GetFilter(),
+		viewToPersist.GetSort(),
+		viewToPersist.GetColumns(),
+		viewToPersist.GetUpdatedAt().Unix(),
+		viewToPersist.GetCreatedAt().Unix(),
+	)
+	if err != nil {
+		return -1, fmt.Errorf("PersistView: %v", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return -1, fmt.Errorf("PersistView: %v", err)
+	}
+
+	return id, nil
+}
+
+// FindAllViews godoc
+//
+// Retrieves the saved views, sorted by name.
+//
+// Returns nil and error on error.
+//
+// Returns a slice containing View instances and nil on success.
+func (repo *sqliteRepository) FindAllViews(ctx context.Context) (result []View, err error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindAllViews: database connection is nil")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s ORDER BY name", viewColumns, tableName)
+	rows, err := repo.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("FindAllViews: %v", err)
+	}
+	// Close rows on exit
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("FindAllViews: Failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: FindAllViews: Failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	result = []View{}
+	for rows.Next() {
+		view, err := NewViewFromRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("FindAllViews: %v", err)
+		}
+		result = append(result, view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("FindAllViews: %v", err)
+	}
+
+	return result, nil
+}
+
+// FindViewByName godoc
+//
+// Get a saved view by its name, ignoring case.
+//
+// Returns nil and nil when no view is found.
+//
+// Returns nil and error on error.
+//
+// Returns the found View and nil on success.
+func (repo *sqliteRepository) FindViewByName(ctx context.Context, name string) (found View, err error) {
+	if repo.db == nil {
+		return nil, fmt.Errorf("FindViewByName: database connection is nil")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE name = ?", viewColumns, tableName)
+	rows, err := repo.db.QueryContext(ctx, query, name)
+	if err != nil {
+		return nil, fmt.Errorf("FindViewByName: %v", err)
+	}
+	// Close rows on exit
+	defer func(rows *sql.Rows) {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			if err == nil {
+				// Return `closeErr` if `err` is not set already
+				err = fmt.Errorf("FindViewByName: Failed to close rows: %w", closeErr)
+			} else {
+				// Log `closeErr` when `err` is already set
+				log.Warnf("WARNING: FindViewByName: Failed to close rows (original error: %v): %v", err, closeErr)
+			}
+		}
+	}(rows)
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	view, err := NewViewFromRow(rows)
+	if err != nil {
+		return nil, fmt.Errorf("FindViewByName: %v", err)
+	}
+	return view, nil
+}
+
+// UpdateViewById godoc
+//
+// Update a View in the database table using its ID.
+//
+// Returns -1 and error on error.
+//
+// Returns number of updated rows and nil on success. If a view is updated the number of updated rows will be 1,
+// else 0.
+func (repo *sqliteRepository) UpdateViewById(ctx context.Context, viewToUpdate View) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("UpdateViewById: database connection is nil")
+	}
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET filterExpression = ?, sortOrder = ?, itemColumns = ?, updatedAt = ? WHERE id = ?",
+		tableName,
+	)
+	result, err := repo.db.ExecContext(
+		ctx,
+		query,
+		viewToUpdate.GetFilter(),
+		viewToUpdate.GetSort(),
+		viewToUpdate.GetColumns(),
+		viewToUpdate.GetUpdatedAt().Unix(),
+		viewToUpdate.GetId(),
+	)
+	if err != nil {
+		return -1, fmt.Errorf("UpdateViewById: %v", err)
+	}
+
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("UpdateViewById: %v", err)
+	}
+	return rowCount, nil
+}
+
+// DeleteViewById godoc
+//
+// Delete a View in the database table using its ID.
+//
+// Returns -1 and error on error.
+//
+// Returns number of deleted rows and nil on success. If a view is deleted the number of deleted rows will be 1,
+// else 0.
+func (repo *sqliteRepository) DeleteViewById(ctx context.Context, idToDelete int64) (int64, error) {
+	if repo.db == nil {
+		return -1, fmt.Errorf("DeleteViewById: database connection is nil")
+	}
+
+	result, err := repo.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = ?", tableName), idToDelete)
+	if err != nil {
+		return -1, fmt.Errorf("DeleteViewById: %v", err)
+	}
+	rowCount, err := result.RowsAffected()
+	if err != nil {
+		return -1, fmt.Errorf("DeleteViewById: %v", err)
+	}
+	return rowCount, nil
+}
@@ -0,0 +1,69 @@
+package view
+
+import (
+	"github.com/rykeroc/todo-cli/internal/testutils"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSqliteRepository(t *testing.T) {
+	fixture := testutils.SetupTestFixture(t)
+	defer func(fixture *testutils.TestFixture) {
+		err := fixture.CleanupTestFixture()
+		if err != nil {
+			log.Fatalf("TestSqliteRepository: Error on cleanup: %v", err)
+		}
+	}(fixture)
+	repository := NewSqliteRepository(fixture.Db)
+
+	t.Run("should persist and find views", func(t *testing.T) {
+		newView := NewView(0, "mine", "tag:a", "due", testNow, testNow)
+		newView.SetColumns("id,name")
+		id, err := repository.PersistView(ctx, newView)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), id)
+
+		view, err := repository.FindViewByName(ctx, "MINE")
+		assert.NoError(t, err)
+		assert.Equal(t, "mine", view.GetName())
+		assert.Equal(t, "tag:a", view.GetFilter())
+		assert.Equal(t, "due", view.GetSort())
+		assert.Equal(t, "id,name", view.GetColumns())
+		assert.Equal(t, testNow.Unix(), view.GetUpdatedAt().Unix())
+
+		view, err = repository.FindViewByName(ctx, "other")
+		assert.NoError(t, err)
+		assert.Nil(t, view)
+	})
+
+	t.Run("should not persist two views with the same name", func(t *testing.T) {
+		_, err := repository.PersistView(ctx, NewView(0, "Mine", "", "", testNow, testNow))
+		assert.Error(t, err)
+	})
+
+	t.Run("should update and delete views", func(t *testing.T) {
+		view, err := repository.FindViewByName(ctx, "mine")
+		assert.NoError(t, err)
+		view.SetFilter("tag:b")
+		view.SetColumns("")
+
+		rowCount, err := repository.UpdateViewById(ctx, view)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rowCount)
+
+		views, err := repository.FindAllViews(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, views, 1)
+		assert.Equal(t, "tag:b", views[0].GetFilter())
+		assert.Empty(t, views[0].GetColumns())
+
+		rowCount, err = repository.DeleteViewById(ctx, view.GetId())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), rowCount)
+
+		views, err = repository.FindAllViews(ctx)
+		assert.NoError(t, err)
+		assert.Empty(t, views)
+	})
+}
@@ -0,0 +1,144 @@
+package view
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// UseCase godoc
+//
+// An interface that defines the behaviour for a view use case struct.
+type UseCase interface {
+	Save(context.Context, string, string, string, string) (View, error)
+	List(context.Context) ([]View, error)
+	Find(context.Context, string) (View, error)
+	Delete(context.Context, string) error
+}
+
+// defaultUseCase godoc
+//
+// A structure which takes a view domain and repository.
+//
+// Adheres to the view UseCase interface.
+type defaultUseCase struct {
+	domain     Domain
+	repository Repository
+}
+
+// NewUseCase godoc
+//
+// Creates a new UseCase with the passed in Domain and Repository instances.
+func NewUseCase(domain Domain, repository Repository) UseCase {
+	return &defaultUseCase{
+		domain:     domain,
+		repository: repository,
+	}
+}
+
+// Save godoc
+//
+// Save a view with a filter expression, a sort order and the columns of its table, replacing the saved view with the
+// same name.
+//
+// Returns nil and error wrapping ErrInvalidName, ErrBuiltIn or ErrInvalidView when the view cannot be saved.
+//
+// Returns nil and error on error.
+//
+// Returns the saved view, as read back from the database, and nil on success.
+func (uc *defaultUseCase) Save(ctx context.Context, name string, filter string, sort string, columns string) (
+	View, error,
+) {
+	view, err := uc.domain.CreateView(name, filter, sort, columns)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Save: %w", err)
+	}
+
+	existingView, err := uc.repository.FindViewByName(ctx, view.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Save: %v", err)
+	}
+	if existingView == nil {
+		_, err = uc.repository.PersistView(ctx, view)
+	} else {
+		existingView, err = uc.domain.UpdateView(view.GetFilter(), view.GetSort(), view.GetColumns(), existingView)
+		if err != nil {
+			return nil, fmt.Errorf("defaultUseCase.Save: %w", err)
+		}
+		_, err = uc.repository.UpdateViewById(ctx, existingView)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Save: Failed to save view '%s': %v", view.GetName(), err)
+	}
+
+	savedView, err := uc.repository.FindViewByName(ctx, view.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Save: %v", err)
+	}
+	return savedView, nil
+}
+
+// List godoc
+//
+// Get the built-in views followed by the saved views, sorted by name.
+//
+// Returns nil and error on error.
+//
+// Returns the views and nil on success.
+func (uc *defaultUseCase) List(ctx context.Context) ([]View, error) {
+	savedViews, err := uc.repository.FindAllViews(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.List: %v", err)
+	}
+	return append(uc.domain.BuiltInViews(), savedViews...), nil
+}
+
+// Find godoc
+//
+// Find a built-in or saved view by name.
+//
+// Returns nil and error wrapping ErrNotFound when no view exists with the name.
+//
+// Returns nil and error on error.
+//
+// Returns the view and nil on success.
+func (uc *defaultUseCase) Find(ctx context.Context, name string) (View, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, builtIn := range uc.domain.BuiltInViews() {
+		if builtIn.GetName() == name {
+			return builtIn, nil
+		}
+	}
+
+	view, err := uc.repository.FindViewByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("defaultUseCase.Find: %v", err)
+	}
+	if view == nil {
+		return nil, fmt.Errorf("defaultUseCase.Find: %w: '%s'", ErrNotFound, name)
+	}
+	return view, nil
+}
+
+// Delete godoc
+//
+// Delete a saved view by name.
+//
+// Returns error wrapping ErrBuiltIn when the view is built in, or wrapping ErrNotFound when no view exists with the
+// name.
+//
+// Returns error on error, nil otherwise.
+func (uc *defaultUseCase) Delete(ctx context.Context, name string) error {
+	view, err := uc.Find(ctx, name)
+	if err != nil {
+		return fmt.Errorf("defaultUseCase.Delete: %w", err)
+	}
+	if view.IsBuiltIn() {
+		return fmt.Errorf("defaultUseCase.Delete: %w: '%s'", ErrBuiltIn, view.GetName())
+	}
+
+	if _, err := uc.repository.DeleteViewById(ctx, view.GetId()); err != nil {
+		return fmt.Errorf("defaultUseCase.Delete: Failed to delete view '%s': %v", view.GetName(), err)
+	}
+	return nil
+}
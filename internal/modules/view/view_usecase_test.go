@@ -0,0 +1,104 @@
+package view
+
+import (
+	"context"
+	"github.com/rykeroc/todo-cli/internal/testutils"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// ctx is passed to every repository and use case call of the tests.
+var ctx = context.Background()
+
+func beforeEach(t *testing.T) (*testutils.TestFixture, UseCase) {
+	fixture := testutils.SetupTestFixture(t)
+	repository := NewSqliteRepository(fixture.Db)
+	return fixture, NewUseCase(domain, repository)
+}
+
+func afterEach(fixture *testutils.TestFixture) {
+	err := fixture.CleanupTestFixture()
+	if err != nil {
+		log.Fatalf("afterEach: Error on cleanup: %v", err)
+	}
+}
+
+func TestDefaultUseCase_Save(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	t.Run("should save a view", func(t *testing.T) {
+		view, err := useCase.Save(ctx, "backend", "tag:backend", "due", "id,name")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), view.GetId())
+		assert.Equal(t, "tag:backend", view.GetFilter())
+		assert.Equal(t, "due", view.GetSort())
+		assert.Equal(t, "id,name", view.GetColumns())
+	})
+
+	t.Run("should replace the saved view with the same name", func(t *testing.T) {
+		view, err := useCase.Save(ctx, "backend", "tag:backend and status:open", "", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), view.GetId())
+		assert.Equal(t, "tag:backend and status:open", view.GetFilter())
+		assert.Empty(t, view.GetSort())
+		assert.Empty(t, view.GetColumns())
+	})
+
+	t.Run("should return errors of invalid views", func(t *testing.T) {
+		_, err := useCase.Save(ctx, "overdue", "", "", "")
+		assert.ErrorIs(t, err, ErrBuiltIn)
+
+		_, err = useCase.Save(ctx, "other", "tag:", "", "")
+		assert.ErrorIs(t, err, ErrInvalidView)
+	})
+}
+
+func TestDefaultUseCase_ListFindDelete(t *testing.T) {
+	fixture, useCase := beforeEach(t)
+	defer afterEach(fixture)
+
+	for _, name := range []string{"zeta", "alpha"} {
+		if _, err := useCase.Save(ctx, name, "status:open", "", ""); err != nil {
+			t.Fatalf("TestDefaultUseCase_ListFindDelete: %v", err)
+		}
+	}
+
+	t.Run("should list the built-in views then the saved views", func(t *testing.T) {
+		views, err := useCase.List(ctx)
+
+		assert.NoError(t, err)
+		var names []string
+		for _, view := range views {
+			names = append(names, view.GetName())
+		}
+		assert.Equal(t, []string{"today", "overdue", "upcoming", "completed", "stale", "alpha", "zeta"}, names)
+	})
+
+	t.Run("should find built-in and saved views", func(t *testing.T) {
+		view, err := useCase.Find(ctx, "Today")
+		assert.NoError(t, err)
+		assert.True(t, view.IsBuiltIn())
+
+		view, err = useCase.Find(ctx, "alpha")
+		assert.NoError(t, err)
+		assert.Equal(t, "status:open", view.GetFilter())
+
+		view, err = useCase.Find(ctx, "missing")
+		assert.Nil(t, view)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("should delete saved views only", func(t *testing.T) {
+		assert.NoError(t, useCase.Delete(ctx, "alpha"))
+		assert.ErrorIs(t, useCase.Delete(ctx, "alpha"), ErrNotFound)
+		assert.ErrorIs(t, useCase.Delete(ctx, "stale"), ErrBuiltIn)
+
+		views, err := useCase.List(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, views, 6)
+	})
+}
@@ -0,0 +1,349 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// DefaultName godoc
+//
+// Name of the profile which is used until another profile is created and selected. It cannot be deleted.
+const DefaultName = "default"
+
+// DefaultDatabaseFilename godoc
+//
+// Name of the database file of the default profile, in the configuration directory.
+const DefaultDatabaseFilename = "todo.db"
+
+// profilesFilename godoc
+//
+// Name of the file, in the configuration directory, which records the profiles and the active profile.
+const profilesFilename = "profiles.yaml"
+
+// profilesDirname godoc
+//
+// Name of the directory, in the configuration directory, which holds the database files of new profiles.
+const profilesDirname = "profiles"
+
+// ErrNotFound godoc
+//
+// Returned when no profile exists with a name.
+var ErrNotFound = errors.New("profile not found")
+
+// ErrExists godoc
+//
+// Returned when a profile is created with the name of an existing profile.
+var ErrExists = errors.New("profile already exists")
+
+// ErrInvalidName godoc
+//
+// Returned when a profile name is not made of lower case letters, digits, dashes and underscores.
+var ErrInvalidName = errors.New("invalid profile name")
+
+// ErrInUse godoc
+//
+// Returned when the default profile or the active profile is deleted.
+var ErrInUse = errors.New("profile is in use")
+
+// namePattern godoc
+//
+// Profile names are used as file names, so they are restricted to characters which are safe on every platform.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Profile godoc
+//
+// A named todo list, backed by its own database file.
+type Profile struct {
+	Name string `yaml:"-"`
+	// Database is the absolute path of the database file of the profile.
+	Database string `yaml:"database"`
+	// IsActive is true for the profile which is used when no profile is selected.
+	IsActive bool `yaml:"-"`
+}
+
+// Store godoc
+//
+// An interface that defines the behaviour for a collection of profiles.
+type Store interface {
+	List() ([]Profile, error)
+	Find(string) (Profile, error)
+	Active() (Profile, error)
+	Create(string, string) (Profile, error)
+	Use(string) error
+	Delete(string) (Profile, error)
+}
+
+// profilesFile godoc
+//
+// Defines the content of the profiles file. The default profile is not recorded.
+type profilesFile struct {
+	Active   string             `yaml:"active,omitempty"`
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// fileStore godoc
+//
+// A Store which records the profiles in a YAML file of a configuration directory.
+//
+// Adheres to the Store interface.
+type fileStore struct {
+	configDir string
+}
+
+// NewFileStore godoc
+//
+// Creates a new Store which records the profiles in the configuration directory configDir.
+func NewFileStore(configDir string) Store {
+	return &fileStore{configDir: configDir}
+}
+
+// List godoc
+//
+// Get every profile, the default profile first and the other profiles sorted by name.
+//
+// Returns nil and error on error.
+//
+// Returns the profiles and nil on success.
+func (s *fileStore) List() ([]Profile, error) {
+	file, err := s.read()
+	if err != nil {
+		return nil, fmt.Errorf("List: %v", err)
+	}
+
+	profiles := []Profile{s.defaultProfile(file)}
+	for name, profile := range file.Profiles {
+		profile.Name = name
+		profile.IsActive = file.Active == name
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles[1:], func(i, j int) bool {
+		return profiles[i+1].Name < profiles[j+1].Name
+	})
+	return profiles, nil
+}
+
+// Find godoc
+//
+// Get a profile by name.
+//
+// Returns an empty Profile and error wrapping ErrNotFound when no profile exists with the name.
+//
+// Returns an empty Profile and error on error.
+//
+// Returns the profile and nil on success.
+func (s *fileStore) Find(name string) (Profile, error) {
+	file, err := s.read()
+	if err != nil {
+		return Profile{}, fmt.Errorf("Find: %v", err)
+	}
+	profile, err := s.find(file, name)
+	if err != nil {
+		return Profile{}, fmt.Errorf("Find: %w", err)
+	}
+	return profile, nil
+}
+
+// Active godoc
+//
+// Get the active profile, the default profile when no other profile was selected with Use.
+//
+// Returns an empty Profile and error on error.
+//
+// Returns the active profile and nil on success.
+func (s *fileStore) Active() (Profile, error) {
+	file, err := s.read()
+	if err != nil {
+		return Profile{}, fmt.Errorf("Active: %v", err)
+	}
+	profile, err := s.find(file, file.Active)
+	if err != nil {
+		// The active profile was removed from the file by hand
+		return s.defaultProfile(file), nil
+	}
+	return profile, nil
+}
+
+// Create godoc
+//
+// Create a profile backed by the database file at the path database. When database is empty, the database file is
+// created in the profiles directory of the configuration directory, named after the profile.
+//
+// Returns an empty Profile and error wrapping ErrInvalidName or ErrExists when the name cannot be used.
+//
+// Returns an empty Profile and error on error.
+//
+// Returns the created profile and nil on success.
+func (s *fileStore) Create(name string, database string) (Profile, error) {
+	if !namePattern.MatchString(name) {
+		return Profile{}, fmt.Errorf(
+			"Create: %w '%s', use lower case letters, digits, dashes and underscores", ErrInvalidName, name,
+		)
+	}
+
+	file, err := s.read()
+	if err != nil {
+		return Profile{}, fmt.Errorf("Create: %v", err)
+	}
+	if _, err := s.find(file, name); err == nil {
+		return Profile{}, fmt.Errorf("Create: %w: '%s'", ErrExists, name)
+	}
+
+	if database == "" {
+		database = filepath.Join(s.configDir, profilesDirname, name+".db")
+	}
+	database, err = filepath.Abs(database)
+	if err != nil {
+		return Profile{}, fmt.Errorf("Create: %v", err)
+	}
+
+	if file.Profiles == nil {
+		file.Profiles = map[string]Profile{}
+	}
+	profile := Profile{Name: name, Database: database}
+	file.Profiles[name] = profile
+	if err := s.write(file); err != nil {
+		return Profile{}, fmt.Errorf("Create: %v", err)
+	}
+	return profile, nil
+}
+
+// Use godoc
+//
+// Make a profile the active profile.
+//
+// Returns error wrapping ErrNotFound when no profile exists with the name.
+//
+// Returns error on error, nil otherwise.
+func (s *fileStore) Use(name string) error {
+	file, err := s.read()
+	if err != nil {
+		return fmt.Errorf("Use: %v", err)
+	}
+	if _, err := s.find(file, name); err != nil {
+		return fmt.Errorf("Use: %w", err)
+	}
+
+	file.Active = name
+	if name == DefaultName {
+		file.Active = ""
+	}
+	if err := s.write(file); err != nil {
+		return fmt.Errorf("Use: %v", err)
+	}
+	return nil
+}
+
+// Delete godoc
+//
+// Delete a profile. The database file of the profile is kept.
+//
+// Returns an empty Profile and error wrapping ErrNotFound when no profile exists with the name, or wrapping ErrInUse
+// when the profile is the default or the active profile.
+//
+// Returns an empty Profile and error on error.
+//
+// Returns the deleted profile and nil on success.
+func (s *fileStore) Delete(name string) (Profile, error) {
+	file, err := s.read()
+	if err != nil {
+		return Profile{}, fmt.Errorf("Delete: %v", err)
+	}
+	profile, err := s.find(file, name)
+	if err != nil {
+		return Profile{}, fmt.Errorf("Delete: %w", err)
+	}
+	if profile.Name == DefaultName || profile.IsActive {
+		return Profile{}, fmt.Errorf("Delete: %w: '%s' is the %s profile", ErrInUse, name, describeInUse(profile))
+	}
+
+	delete(file.Profiles, name)
+	if err := s.write(file); err != nil {
+		return Profile{}, fmt.Errorf("Delete: %v", err)
+	}
+	return profile, nil
+}
+
+// describeInUse godoc
+//
+// Returns "default" or "active", the reason why a profile cannot be deleted.
+func describeInUse(profile Profile) string {
+	if profile.Name == DefaultName {
+		return "default"
+	}
+	return "active"
+}
+
+// find godoc
+//
+// Looks up a profile by name in the content of the profiles file. An empty name refers to the default profile.
+//
+// Returns an empty Profile and error wrapping ErrNotFound when no profile exists with the name.
+//
+// Returns the profile and nil on success.
+func (s *fileStore) find(file profilesFile, name string) (Profile, error) {
+	if name == "" || name == DefaultName {
+		return s.defaultProfile(file), nil
+	}
+	profile, ok := file.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: '%s'", ErrNotFound, name)
+	}
+	profile.Name = name
+	profile.IsActive = file.Active == name
+	return profile, nil
+}
+
+// defaultProfile godoc
+//
+// Returns the default profile, whose database file is in the configuration directory.
+func (s *fileStore) defaultProfile(file profilesFile) Profile {
+	_, activeExists := file.Profiles[file.Active]
+	return Profile{
+		Name:     DefaultName,
+		Database: filepath.Join(s.configDir, DefaultDatabaseFilename),
+		IsActive: file.Active == "" || !activeExists,
+	}
+}
+
+// read godoc
+//
+// Reads the profiles file. A missing file has no profiles.
+//
+// Returns an empty profilesFile and error on error.
+//
+// Returns the content of the file and nil on success.
+func (s *fileStore) read() (profilesFile, error) {
+	var file profilesFile
+	content, err := os.ReadFile(filepath.Join(s.configDir, profilesFilename))
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return file, fmt.Errorf("read: %v", err)
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return file, fmt.Errorf("read: %s is invalid: %v", profilesFilename, err)
+	}
+	return file, nil
+}
+
+// write godoc
+//
+// Replaces the profiles file with the content.
+//
+// Returns error on error, nil otherwise.
+func (s *fileStore) write(file profilesFile) error {
+	content, err := yaml.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(s.configDir, profilesFilename), content, 0644); err != nil {
+		return fmt.Errorf("write: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package profile
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+)
+
+// FormatProfileTable godoc
+//
+// Returns a string representation of a tabular list of the profiles that are passed in, where the active profile is
+// marked with an asterisk.
+//
+// Returns empty string and error on error writing the list with the tab writer.
+//
+// Returns profiles in a tabular format and nil on success.
+func FormatProfileTable(profiles []Profile) (string, error) {
+	var buffer bytes.Buffer
+
+	padding := 4
+	tabWidth := 4
+	tw := tabwriter.NewWriter(&buffer, 0, tabWidth, padding, ' ', 0)
+
+	// Write header to the tabWriter
+	_, err := fmt.Fprintln(tw, "Active\tName\tDatabase")
+	if err != nil {
+		return "", fmt.Errorf("FormatProfileTable: Error writing table header to tabWriter: %v", err)
+	}
+
+	// Write separator
+	_, err = fmt.Fprintln(tw, "------\t----\t--------")
+	if err != nil {
+		return "", fmt.Errorf("FormatProfileTable: Error writing table header to tabWriter: %v", err)
+	}
+
+	for _, profile := range profiles {
+		active := ""
+		if profile.IsActive {
+			active = "*"
+		}
+		_, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", active, profile.Name, profile.Database)
+		if err != nil {
+			return "", fmt.Errorf("FormatProfileTable: Error writing profile %s: %v", profile.Name, err)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return "", fmt.Errorf("FormatProfileTable: Failed to flush tabWriter: %v", err)
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,25 @@
+package profile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFormatProfileTable(t *testing.T) {
+	profiles := []Profile{
+		{Name: DefaultName, Database: "/config/todo.db"},
+		{Name: "work", Database: "/config/profiles/work.db", IsActive: true},
+	}
+
+	result, err := FormatProfileTable(profiles)
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"Active    Name       Database\n"+
+			"------    ----       --------\n"+
+			"          default    /config/todo.db\n"+
+			"*         work       /config/profiles/work.db\n",
+		result,
+	)
+}
@@ -0,0 +1,158 @@
+package profile
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStore_List(t *testing.T) {
+	t.Run("should only return the active default profile when no profile was created", func(t *testing.T) {
+		configDir := t.TempDir()
+		store := NewFileStore(configDir)
+
+		profiles, err := store.List()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Profile{
+			{Name: DefaultName, Database: filepath.Join(configDir, DefaultDatabaseFilename), IsActive: true},
+		}, profiles)
+	})
+
+	t.Run("should return the default profile first and the other profiles sorted by name", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+		_, _ = store.Create("work", "")
+		_, _ = store.Create("home", "")
+
+		profiles, err := store.List()
+
+		assert.NoError(t, err)
+		assert.Len(t, profiles, 3)
+		assert.Equal(t, DefaultName, profiles[0].Name)
+		assert.Equal(t, "home", profiles[1].Name)
+		assert.Equal(t, "work", profiles[2].Name)
+	})
+
+	t.Run("should return error when the profiles file is invalid", func(t *testing.T) {
+		configDir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(configDir, profilesFilename), []byte("profiles: ["), 0644))
+		store := NewFileStore(configDir)
+
+		_, err := store.List()
+
+		assert.ErrorContains(t, err, "profiles.yaml is invalid")
+	})
+}
+
+func TestFileStore_Create(t *testing.T) {
+	t.Run("should create the database of the profile in the profiles directory", func(t *testing.T) {
+		configDir := t.TempDir()
+		store := NewFileStore(configDir)
+
+		created, err := store.Create("work", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Profile{Name: "work", Database: filepath.Join(configDir, "profiles", "work.db")}, created)
+		found, err := store.Find("work")
+		assert.NoError(t, err)
+		assert.Equal(t, created, found)
+	})
+
+	t.Run("should make the database path absolute", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+
+		created, err := store.Create("shared", "shared.db")
+
+		assert.NoError(t, err)
+		assert.True(t, filepath.IsAbs(created.Database))
+		assert.Equal(t, "shared.db", filepath.Base(created.Database))
+	})
+
+	t.Run("should return ErrInvalidName when the name is invalid", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+
+		for _, name := range []string{"", "Work", "my work", "../work", "-work"} {
+			_, err := store.Create(name, "")
+			assert.ErrorIs(t, err, ErrInvalidName, name)
+		}
+	})
+
+	t.Run("should return ErrExists when the profile exists", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+		_, _ = store.Create("work", "")
+
+		for _, name := range []string{"work", DefaultName} {
+			_, err := store.Create(name, "")
+			assert.ErrorIs(t, err, ErrExists, name)
+		}
+	})
+}
+
+func TestFileStore_Use(t *testing.T) {
+	t.Run("should make the profile active", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+		_, _ = store.Create("work", "")
+
+		assert.NoError(t, store.Use("work"))
+
+		active, err := store.Active()
+		assert.NoError(t, err)
+		assert.Equal(t, "work", active.Name)
+		assert.True(t, active.IsActive)
+	})
+
+	t.Run("should make the default profile active again", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+		_, _ = store.Create("work", "")
+		_ = store.Use("work")
+
+		assert.NoError(t, store.Use(DefaultName))
+
+		active, err := store.Active()
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultName, active.Name)
+	})
+
+	t.Run("should return ErrNotFound when the profile does not exist", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+
+		assert.ErrorIs(t, store.Use("work"), ErrNotFound)
+	})
+}
+
+func TestFileStore_Delete(t *testing.T) {
+	t.Run("should forget the profile and keep its database", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+		created, _ := store.Create("work", "")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(created.Database), 0755))
+		assert.NoError(t, os.WriteFile(created.Database, nil, 0644))
+
+		deleted, err := store.Delete("work")
+
+		assert.NoError(t, err)
+		assert.Equal(t, created, deleted)
+		_, err = store.Find("work")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.FileExists(t, created.Database)
+	})
+
+	t.Run("should return ErrInUse for the default and the active profile", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+		_, _ = store.Create("work", "")
+		_ = store.Use("work")
+
+		for _, name := range []string{DefaultName, "work"} {
+			_, err := store.Delete(name)
+			assert.ErrorIs(t, err, ErrInUse, name)
+		}
+	})
+
+	t.Run("should return ErrNotFound when the profile does not exist", func(t *testing.T) {
+		store := NewFileStore(t.TempDir())
+
+		_, err := store.Delete("work")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,318 @@
+package recurrence
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency godoc
+//
+// Defines the unit of time between two occurrences of a Rule, named after the iCalendar FREQ values.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+	Yearly  Frequency = "YEARLY"
+)
+
+// Rule godoc
+//
+// A recurrence rule covering a practical subset of the iCalendar RRULE: a frequency, an interval and, for weekly
+// rules, the days of the week.
+//
+// Supported expressions (case-insensitive):
+//
+//   - Keywords: `daily`, `weekly`, `monthly`, `yearly`, `weekdays`
+//   - Intervals: `every day`, `every 3 days`, `every 2 weeks`, `every month`, `every 6 months`
+//   - Weekdays: `every mon`, `every mon,wed,fri`, `every tue and thu`
+//   - RRULE: `FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE`, with or without the `RRULE:` prefix
+type Rule struct {
+	Frequency Frequency
+	// Interval is the number of frequency units between two occurrences, at least 1.
+	Interval int
+	// Weekdays restricts weekly rules to these days of the week, sorted from Monday to Sunday.
+	Weekdays []time.Weekday
+}
+
+// IsZero godoc
+//
+// Returns true when the rule is empty, i.e. there is no recurrence.
+func (r Rule) IsZero() bool {
+	return r.Frequency == ""
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+	"sun": time.Sunday, "sunday": time.Sunday,
+}
+
+var units = map[string]Frequency{
+	"day": Daily, "days": Daily,
+	"week": Weekly, "weeks": Weekly,
+	"month": Monthly, "months": Monthly,
+	"year": Yearly, "years": Yearly,
+}
+
+// intervalPattern godoc
+//
+// Matches `every N units` and `every unit`.
+var intervalPattern = regexp.MustCompile(`^every\s+(?:(\d+)\s+)?([a-z]+)$`)
+
+// Parse godoc
+//
+// Parses a recurrence expression.
+//
+// Returns an empty Rule and error when the expression is not supported.
+//
+// Returns the Rule and nil on success.
+func Parse(expr string) (Rule, error) {
+	normalized := strings.ToLower(strings.TrimSpace(expr))
+	if normalized == "" {
+		return Rule{}, fmt.Errorf("recurrence is empty")
+	}
+
+	switch normalized {
+	case "daily":
+		return Rule{Frequency: Daily, Interval: 1}, nil
+	case "weekly":
+		return Rule{Frequency: Weekly, Interval: 1}, nil
+	case "monthly":
+		return Rule{Frequency: Monthly, Interval: 1}, nil
+	case "yearly", "annually":
+		return Rule{Frequency: Yearly, Interval: 1}, nil
+	case "weekdays", "every weekday":
+		return Rule{
+			Frequency: Weekly,
+			Interval:  1,
+			Weekdays:  []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		}, nil
+	}
+
+	if strings.HasPrefix(normalized, "rrule:") || strings.HasPrefix(normalized, "freq=") {
+		return parseRRule(expr)
+	}
+
+	if match := intervalPattern.FindStringSubmatch(normalized); match != nil {
+		if frequency, ok := units[match[2]]; ok {
+			interval := 1
+			if match[1] != "" {
+				interval, _ = strconv.Atoi(match[1])
+			}
+			if interval < 1 {
+				return Rule{}, fmt.Errorf("'%s' is not a valid recurrence: interval must be at least 1", expr)
+			}
+			return Rule{Frequency: frequency, Interval: interval}, nil
+		}
+	}
+
+	if days, ok := strings.CutPrefix(normalized, "every "); ok {
+		fields := strings.FieldsFunc(days, func(r rune) bool { return r == ',' || r == ' ' })
+		var weekdays []time.Weekday
+		for _, field := range fields {
+			if field == "and" {
+				continue
+			}
+			weekday, ok := weekdayNames[field]
+			if !ok {
+				return Rule{}, fmt.Errorf("'%s' is not a valid recurrence: '%s' is not a day of the week", expr, field)
+			}
+			weekdays = append(weekdays, weekday)
+		}
+		if len(weekdays) > 0 {
+			return Rule{Frequency: Weekly, Interval: 1, Weekdays: sortWeekdays(weekdays)}, nil
+		}
+	}
+
+	return Rule{}, fmt.Errorf("'%s' is not a valid recurrence", expr)
+}
+
+// parseRRule godoc
+//
+// Parses the FREQ, INTERVAL and BYDAY parts of an iCalendar RRULE.
+//
+// Returns an empty Rule and error when a part is missing, unknown or invalid.
+//
+// Returns the Rule and nil on success.
+func parseRRule(expr string) (Rule, error) {
+	value := strings.ToUpper(strings.TrimSpace(expr))
+	value = strings.TrimPrefix(value, "RRULE:")
+
+	rule := Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, partValue, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("'%s' is not a valid recurrence: '%s' is not a KEY=VALUE pair", expr, part)
+		}
+		switch key {
+		case "FREQ":
+			switch frequency := Frequency(partValue); frequency {
+			case Daily, Weekly, Monthly, Yearly:
+				rule.Frequency = frequency
+			default:
+				return Rule{}, fmt.Errorf("'%s' is not a valid recurrence: unsupported FREQ '%s'", expr, partValue)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(partValue)
+			if err != nil || interval < 1 {
+				return Rule{}, fmt.Errorf("'%s' is not a valid recurrence: invalid INTERVAL '%s'", expr, partValue)
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			var weekdays []time.Weekday
+			for _, code := range strings.Split(partValue, ",") {
+				weekday, ok := weekdayCodes[code]
+				if !ok {
+					return Rule{}, fmt.Errorf("'%s' is not a valid recurrence: unsupported BYDAY '%s'", expr, code)
+				}
+				weekdays = append(weekdays, weekday)
+			}
+			rule.Weekdays = sortWeekdays(weekdays)
+		default:
+			return Rule{}, fmt.Errorf("'%s' is not a valid recurrence: unsupported part '%s'", expr, key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return Rule{}, fmt.Errorf("'%s' is not a valid recurrence: FREQ is missing", expr)
+	}
+	if len(rule.Weekdays) > 0 && rule.Frequency != Weekly {
+		return Rule{}, fmt.Errorf("'%s' is not a valid recurrence: BYDAY is only supported with FREQ=WEEKLY", expr)
+	}
+	return rule, nil
+}
+
+// String godoc
+//
+// Returns the rule in its iCalendar RRULE form, e.g. `FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE`. The interval is
+// omitted when it is 1. Returns empty string for the zero Rule.
+func (r Rule) String() string {
+	if r.IsZero() {
+		return ""
+	}
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.Weekdays) > 0 {
+		var codes []string
+		for _, weekday := range r.Weekdays {
+			codes = append(codes, strings.ToUpper(weekday.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Describe godoc
+//
+// Returns a short human-readable description of the rule, e.g. `every 2 weeks on Mon, Wed`.
+// Returns empty string for the zero Rule.
+func (r Rule) Describe() string {
+	if r.IsZero() {
+		return ""
+	}
+	unit := map[Frequency]string{Daily: "day", Weekly: "week", Monthly: "month", Yearly: "year"}[r.Frequency]
+	description := "every " + unit
+	if r.Interval > 1 {
+		description = fmt.Sprintf("every %d %ss", r.Interval, unit)
+	}
+	if len(r.Weekdays) > 0 {
+		var names []string
+		for _, weekday := range r.Weekdays {
+			names = append(names, weekday.String()[:3])
+		}
+		description += " on " + strings.Join(names, ", ")
+	}
+	return description
+}
+
+// Next godoc
+//
+// Returns the first occurrence of the rule strictly after t, keeping the time of day of t.
+//
+// Monthly and yearly rules keep the day of the month of t, falling back to the last day of shorter months.
+// Weekly rules with weekdays count weeks from Monday. Returns t for the zero Rule.
+func (r Rule) Next(t time.Time) time.Time {
+	interval := max(r.Interval, 1)
+
+	switch r.Frequency {
+	case Daily:
+		return t.AddDate(0, 0, interval)
+	case Weekly:
+		if len(r.Weekdays) == 0 {
+			return t.AddDate(0, 0, 7*interval)
+		}
+		// Remaining days in the current week, which runs from Monday to Sunday
+		offset := daysSinceMonday(t.Weekday())
+		for _, weekday := range r.Weekdays {
+			if daysSinceMonday(weekday) > offset {
+				return t.AddDate(0, 0, daysSinceMonday(weekday)-offset)
+			}
+		}
+		// First day of the next week in the interval
+		return t.AddDate(0, 0, 7*interval-offset+daysSinceMonday(r.Weekdays[0]))
+	case Monthly:
+		return addMonths(t, interval)
+	case Yearly:
+		return addMonths(t, 12*interval)
+	}
+	return t
+}
+
+// NextAfter godoc
+//
+// Returns the first occurrence of the rule following from which is strictly after after. Occurrences which would
+// already be in the past are skipped. Returns from for the zero Rule.
+func (r Rule) NextAfter(from time.Time, after time.Time) time.Time {
+	if r.IsZero() {
+		return from
+	}
+	next := r.Next(from)
+	for !next.After(after) {
+		next = r.Next(next)
+	}
+	return next
+}
+
+// addMonths godoc
+//
+// Adds months to t, clamping the day of the month to the last day of the resulting month.
+func addMonths(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(),
+		t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	return firstOfMonth.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// daysSinceMonday godoc
+//
+// Returns the position of the weekday in a week starting on Monday, from 0 to 6.
+func daysSinceMonday(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+// sortWeekdays godoc
+//
+// Sorts the weekdays from Monday to Sunday and removes duplicates.
+func sortWeekdays(weekdays []time.Weekday) []time.Weekday {
+	slices.SortFunc(weekdays, func(a, b time.Weekday) int {
+		return daysSinceMonday(a) - daysSinceMonday(b)
+	})
+	return slices.Compact(weekdays)
+}
@@ -0,0 +1,128 @@
+package recurrence
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// testNow is a Wednesday
+var testNow = time.Date(2026, time.October, 14, 10, 30, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	type testCase struct {
+		expr     string
+		expected string
+	}
+
+	testCases := []testCase{
+		{expr: "daily", expected: "FREQ=DAILY"},
+		{expr: "Weekly", expected: "FREQ=WEEKLY"},
+		{expr: "monthly", expected: "FREQ=MONTHLY"},
+		{expr: "yearly", expected: "FREQ=YEARLY"},
+		{expr: "weekdays", expected: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		{expr: "every day", expected: "FREQ=DAILY"},
+		{expr: "every 3 days", expected: "FREQ=DAILY;INTERVAL=3"},
+		{expr: "every 2 weeks", expected: "FREQ=WEEKLY;INTERVAL=2"},
+		{expr: "every 6 months", expected: "FREQ=MONTHLY;INTERVAL=6"},
+		{expr: "every fri", expected: "FREQ=WEEKLY;BYDAY=FR"},
+		{expr: "every fri,mon", expected: "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{expr: "every tuesday and thursday", expected: "FREQ=WEEKLY;BYDAY=TU,TH"},
+		{expr: "FREQ=WEEKLY;INTERVAL=2;BYDAY=WE,MO", expected: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{expr: "RRULE:FREQ=MONTHLY;INTERVAL=1", expected: "FREQ=MONTHLY"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.expr, func(t *testing.T) {
+			rule, err := Parse(test.expr)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, rule.String())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"", "sometimes", "every 0 days", "every fortnight", "every mon,someday",
+		"FREQ=HOURLY", "INTERVAL=2", "FREQ=DAILY;INTERVAL=x", "FREQ=MONTHLY;BYDAY=MO", "FREQ=WEEKLY;COUNT=3",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			rule, err := Parse(expr)
+
+			assert.Error(t, err)
+			assert.True(t, rule.IsZero())
+		})
+	}
+}
+
+func TestRule_Describe(t *testing.T) {
+	assert.Equal(t, "every day", Rule{Frequency: Daily, Interval: 1}.Describe())
+	assert.Equal(t, "every 3 months", Rule{Frequency: Monthly, Interval: 3}.Describe())
+	assert.Equal(
+		t,
+		"every 2 weeks on Mon, Wed",
+		Rule{Frequency: Weekly, Interval: 2, Weekdays: []time.Weekday{time.Monday, time.Wednesday}}.Describe(),
+	)
+	assert.Equal(t, "", Rule{}.Describe())
+}
+
+func TestRule_Next(t *testing.T) {
+	type testCase struct {
+		expr     string
+		from     time.Time
+		expected time.Time
+	}
+
+	testCases := []testCase{
+		{expr: "daily", from: testNow, expected: time.Date(2026, time.October, 15, 10, 30, 0, 0, time.UTC)},
+		{expr: "every 3 days", from: testNow, expected: time.Date(2026, time.October, 17, 10, 30, 0, 0, time.UTC)},
+		{expr: "weekly", from: testNow, expected: time.Date(2026, time.October, 21, 10, 30, 0, 0, time.UTC)},
+		{expr: "every mon,fri", from: testNow, expected: time.Date(2026, time.October, 16, 10, 30, 0, 0, time.UTC)},
+		{expr: "every mon,wed", from: testNow, expected: time.Date(2026, time.October, 19, 10, 30, 0, 0, time.UTC)},
+		{
+			expr:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+			from:     testNow,
+			expected: time.Date(2026, time.October, 26, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			expr:     "weekdays",
+			from:     time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC),
+		},
+		{expr: "monthly", from: testNow, expected: time.Date(2026, time.November, 14, 10, 30, 0, 0, time.UTC)},
+		{
+			expr:     "monthly",
+			from:     time.Date(2027, time.January, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2027, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			expr:     "yearly",
+			from:     time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2029, time.February, 28, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.expr, func(t *testing.T) {
+			rule, err := Parse(test.expr)
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.expected, rule.Next(test.from))
+		})
+	}
+}
+
+func TestRule_NextAfter(t *testing.T) {
+	rule, _ := Parse("weekly")
+
+	t.Run("should skip occurrences in the past", func(t *testing.T) {
+		from := time.Date(2026, time.September, 30, 17, 0, 0, 0, time.UTC)
+
+		assert.Equal(t, time.Date(2026, time.October, 14, 17, 0, 0, 0, time.UTC), rule.NextAfter(from, testNow))
+	})
+
+	t.Run("should return the next occurrence when it is in the future", func(t *testing.T) {
+		assert.Equal(t, time.Date(2026, time.October, 21, 10, 30, 0, 0, time.UTC), rule.NextAfter(testNow, testNow))
+	})
+}
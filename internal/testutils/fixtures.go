@@ -1,14 +1,12 @@
 package testutils
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 
 	_ "github.com/mattn/go-sqlite3"
-
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/sqlite"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/rykeroc/todo-cli/internal/data"
 )
 
 // TestFixture godoc
@@ -22,12 +20,15 @@ type TestFixture struct {
 //
 // Sets up an in memory SQLite database for integration tests.
 func SetupTestFixture(t *testing.T) *TestFixture {
-	db, err := sql.Open("sqlite3", ":memory:")
+	db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=on")
 
 	if err != nil {
 		t.Fatalf("SetupTestFixture: Failed to open in memory database connection: %v", err)
 	}
 
+	// Every connection to `:memory:` opens a new empty database, so keep a single connection
+	db.SetMaxOpenConns(1)
+
 	if err = db.Ping(); err != nil {
 		t.Fatalf("SetupTestFixture: Failed to ping database: %v", err)
 	}
@@ -42,29 +43,13 @@ func SetupTestFixture(t *testing.T) *TestFixture {
 }
 
 // initializeSchema godoc
-// Runs migrations on a SQLite database.
+// Runs the embedded migrations on a SQLite database.
 //
 // Returns error on error, else nil
 func initializeSchema(db *sql.DB, t *testing.T) error {
-	driver, err := sqlite.WithInstance(db, &sqlite.Config{})
-	if err != nil {
-		t.Fatalf("initializeSchema: Failed to get sqlite driver instance: %v", err)
-	}
-
-	migrationsDir := "file://./migrations"
 	databaseName := "todo-cli"
-	m, err := migrate.NewWithDatabaseInstance(
-		migrationsDir,
-		databaseName,
-		driver,
-	)
-	if err != nil {
-		t.Fatalf("initializeSchema: Failed to get migrate instance: %v", err)
-	}
-
-	err = m.Up()
-	if err != nil {
-		t.Fatalf("initializeSchema: Failed run migrate up: %v", err)
+	if err := data.RunMigrations(context.Background(), db, databaseName); err != nil {
+		t.Fatalf("initializeSchema: %v", err)
 	}
 
 	return nil